@@ -23,6 +23,7 @@ func NewApp() *cli.App {
 		NewVersionCommand(),
 		NewDumpCommand(),
 		NewRestoreCommand(),
+		NewBackupCommand(),
 		NewBenchCommand(),
 		NewPebbleCommand(),
 	}
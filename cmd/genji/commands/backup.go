@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/cmd/genji/dbutil"
+	"github.com/urfave/cli/v2"
+)
+
+// NewBackupCommand returns a cli.Command for "genji backup".
+func NewBackupCommand() *cli.Command {
+	cmd := cli.Command{
+		Name:      "backup",
+		Usage:     "Create a consistent backup of a database.",
+		UsageText: `genji backup [options] dbpath`,
+		Description: `The backup command copies a database and outputs an archive that can be restored
+with genji restore --from-backup.
+
+A database can only be opened by one process at a time: this command cannot back up
+a database that is open in another process. Live databases are backed up by the process
+using them, by calling DB.Backup, while their write transactions continue.
+
+By default, the backup is sent to the standard output:
+
+$ genji backup my.db > my.backup
+
+The backup command can also write directly into a file:
+
+$ genji backup -f my.backup my.db
+
+Encrypted databases produce encrypted backups, which must be opened with the same key once restored.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "file",
+				Aliases: []string{"f"},
+				Usage:   "name of the file to output to. Defaults to STDOUT.",
+			},
+		},
+	}
+
+	cmd.Action = func(c *cli.Context) error {
+		f := c.String("file")
+		dbPath := c.Args().First()
+		if dbPath == "" {
+			return errors.New(cmd.UsageText)
+		}
+
+		db, err := dbutil.OpenDB(c.Context, dbPath, c.String("encryption-key"))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var w io.Writer = os.Stdout
+
+		if f != "" {
+			file, err := os.Create(f)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			w = file
+		}
+
+		return db.Backup(w)
+	}
+
+	return &cmd
+}
@@ -10,17 +10,30 @@ import (
 func NewRestoreCommand() (cmd *cli.Command) {
 	return &cli.Command{
 		Name:      "restore",
-		Usage:     "Restore a database from a file created by genji dump",
-		UsageText: `genji restore dumpFile dbPath`,
+		Usage:     "Restore a database from a file created by genji dump or genji backup",
+		UsageText: `genji restore [options] dumpFile dbPath`,
 		Description: `The restore command can restore a database from a text file.
 
-	$ genji restore dump.sql mydb`,
-		Flags: []cli.Flag{},
+	$ genji restore dump.sql mydb
+
+With the --from-backup option, it restores a database from an archive created by genji backup.
+The database path must not exist.
+
+	$ genji restore --from-backup my.backup mydb`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "from-backup",
+				Usage: "restore from an archive created by genji backup.",
+			},
+		},
 		Action: func(c *cli.Context) error {
 			args := c.Args()
 			if args.Len() != 2 {
 				return errors.New(cmd.UsageText)
 			}
+			if c.Bool("from-backup") {
+				return dbutil.RestoreBackup(args.First(), args.Get(args.Len()-1))
+			}
 			return dbutil.Restore(c.Context, nil, args.First(), args.Get(args.Len()-1))
 		},
 	}
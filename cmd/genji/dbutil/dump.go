@@ -12,6 +12,7 @@ import (
 
 // Dump takes a database and dumps its content as SQL queries in the given writer.
 // If tables is provided, only selected tables will be outputted.
+// Otherwise, views and triggers are outputted after the tables.
 func Dump(db *genji.DB, w io.Writer, tables ...string) error {
 	tx, err := db.Begin(false)
 	if err != nil {
@@ -35,6 +36,9 @@ func Dump(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpTable(tx, w, query, name)
 	})
+	if err == nil && len(tables) == 0 {
+		err = dumpViewsAndTriggers(tx, w, i > 0)
+	}
 	if err != nil {
 		_, er := fmt.Fprintln(w, "ROLLBACK;")
 		return multierr.Append(err, er)
@@ -76,6 +80,7 @@ func dumpTable(tx *genji.Tx, w io.Writer, query, tableName string) error {
 
 // DumpSchema takes a database and dumps its schema as SQL queries in the given writer.
 // If tables are provided, only selected tables will be outputted.
+// Otherwise, views and triggers are outputted after the tables.
 func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 	tx, err := db.Begin(false)
 	if err != nil {
@@ -84,7 +89,7 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 	defer tx.Rollback()
 
 	i := 0
-	return QueryTables(tx, tables, func(name, query string) error {
+	err = QueryTables(tx, tables, func(name, query string) error {
 		// Blank separation between tables.
 		if i > 0 {
 			if _, err := fmt.Fprintln(w, ""); err != nil {
@@ -95,6 +100,36 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpSchema(tx, w, query, name)
 	})
+	if err != nil || len(tables) > 0 {
+		return err
+	}
+
+	return dumpViewsAndTriggers(tx, w, i > 0)
+}
+
+// dumpViewsAndTriggers displays the CREATE VIEW and CREATE TRIGGER statements
+// of all the views and triggers. Triggers come last so that they don't run
+// while the dump is being restored.
+// If sep is true, they are separated from what precedes them by a blank line.
+func dumpViewsAndTriggers(tx *genji.Tx, w io.Writer, sep bool) error {
+	write := func(name, query string) error {
+		if sep {
+			if _, err := fmt.Fprintln(w, ""); err != nil {
+				return err
+			}
+			sep = false
+		}
+
+		_, err := fmt.Fprintf(w, "%s;\n", query)
+		return err
+	}
+
+	err := QueryViews(tx, write)
+	if err != nil {
+		return err
+	}
+
+	return QueryTriggers(tx, write)
 }
 
 // dumpSchema displays the schema of the given table as SQL statements.
@@ -2,10 +2,13 @@ package dbutil
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,3 +96,68 @@ func TestDump(t *testing.T) {
 		})
 	}
 }
+
+func TestDumpViewsAndTriggers(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// b_view sorts before c_view by name but depends on it,
+	// so it must be dumped after it.
+	err = db.Exec(`
+		CREATE TABLE foo (a INTEGER, b INTEGER);
+		INSERT INTO foo (a, b) VALUES (1, 2), (3, 4);
+		CREATE VIEW c_view AS SELECT a FROM foo WHERE b > 2;
+		CREATE VIEW b_view AS SELECT a FROM c_view;
+		CREATE TABLE bar (a INTEGER);
+		CREATE TRIGGER foo_insert AFTER INSERT ON foo DO INSERT INTO bar (a) VALUES (NEW.a);
+	`)
+	assert.NoError(t, err)
+
+	want := `BEGIN TRANSACTION;
+CREATE TABLE bar (a INTEGER);
+
+CREATE TABLE foo (a INTEGER, b INTEGER);
+INSERT INTO foo VALUES {"a": 1, "b": 2};
+INSERT INTO foo VALUES {"a": 3, "b": 4};
+
+CREATE VIEW c_view AS SELECT a FROM foo WHERE b > 2;
+CREATE VIEW b_view AS SELECT a FROM c_view;
+CREATE TRIGGER foo_insert AFTER INSERT ON foo FOR EACH ROW DO INSERT INTO bar (a) VALUES (NEW.a);
+COMMIT;
+`
+
+	var got bytes.Buffer
+	err = Dump(db, &got)
+	assert.NoError(t, err)
+	require.Equal(t, want, got.String())
+
+	// ensure the dump can be restored
+	restored, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer restored.Close()
+
+	err = ExecSQL(context.Background(), restored, &got, io.Discard)
+	assert.NoError(t, err)
+
+	d, err := restored.QueryDocument("SELECT * FROM b_view")
+	assert.NoError(t, err)
+	var a int
+	err = document.Scan(d, &a)
+	assert.NoError(t, err)
+	require.Equal(t, 3, a)
+
+	// the trigger must not run while restoring
+	d, err = restored.QueryDocument("SELECT COUNT(*) FROM bar")
+	assert.NoError(t, err)
+	err = document.Scan(d, &a)
+	assert.NoError(t, err)
+	require.Equal(t, 0, a)
+
+	// views and triggers are not dumped when tables are selected
+	got.Reset()
+	err = Dump(db, &got, "foo")
+	assert.NoError(t, err)
+	require.NotContains(t, got.String(), "VIEW")
+	require.NotContains(t, got.String(), "TRIGGER")
+}
@@ -37,3 +37,22 @@ func Restore(ctx context.Context, db *genji.DB, dumpFile, dbPath string) error {
 
 	return ExecSQL(ctx, db, file, ioutil.Discard)
 }
+
+// RestoreBackup creates a database at dbPath from a file created by genji backup.
+func RestoreBackup(backupFile, dbPath string) error {
+	if dbPath == "" {
+		return errors.New("database path expected")
+	}
+
+	if backupFile == "" {
+		return errors.New("backup file expected")
+	}
+
+	file, err := os.Open(backupFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return genji.RestoreBackup(file, dbPath)
+}
@@ -31,6 +31,112 @@ func QueryTables(tx *genji.Tx, tables []string, fn func(name, query string) erro
 	})
 }
 
+// QueryViews calls fn for every view of the database.
+// Views are ordered so that a view always comes after the views
+// it selects from, allowing the statements to be replayed in order.
+func QueryViews(tx *genji.Tx, fn func(name, query string) error) error {
+	res, err := tx.Query("SELECT name, sql FROM __genji_catalog WHERE type = 'view'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	var names []string
+	queries := make(map[string]string)
+	deps := make(map[string][]string)
+	err = res.Iterate(func(d types.Document) error {
+		var name, query string
+		if err := document.Scan(d, &name, &query); err != nil {
+			return err
+		}
+
+		q, err := parser.ParseQuery(query)
+		if err != nil {
+			return err
+		}
+
+		sel, err := parser.ParseQuery(q.Statements[0].(*statement.CreateViewStmt).Info.Query)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+		queries[name] = query
+		deps[name] = selectedTables(sel.Statements[0].(*statement.SelectStmt))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	done := make(map[string]bool)
+	var visit func(name string) error
+	visit = func(name string) error {
+		if _, ok := queries[name]; !ok || done[name] {
+			return nil
+		}
+		done[name] = true
+
+		for _, dep := range deps[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		return fn(name, queries[name])
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryTriggers calls fn for every trigger of the database.
+func QueryTriggers(tx *genji.Tx, fn func(name, query string) error) error {
+	res, err := tx.Query("SELECT name, sql FROM __genji_catalog WHERE type = 'trigger'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	return res.Iterate(func(d types.Document) error {
+		var name, query string
+		if err := document.Scan(d, &name, &query); err != nil {
+			return err
+		}
+
+		return fn(name, query)
+	})
+}
+
+// selectedTables returns the names of the tables and views
+// used by the FROM and JOIN clauses of the given statement.
+func selectedTables(stmt *statement.SelectStmt) []string {
+	var names []string
+
+	for _, core := range stmt.CompoundSelect {
+		if core.Subquery != nil {
+			names = append(names, selectedTables(core.Subquery)...)
+		} else if core.TableName != "" {
+			names = append(names, core.TableName)
+		}
+
+		for _, j := range core.Joins {
+			if j.Subquery != nil {
+				names = append(names, selectedTables(j.Subquery)...)
+			} else if j.TableName != "" {
+				names = append(names, j.TableName)
+			}
+		}
+	}
+
+	return names
+}
+
 func ListIndexes(db *genji.DB, tableName string) ([]string, error) {
 	var listName []string
 	q := "SELECT sql FROM __genji_catalog WHERE type = 'index'"
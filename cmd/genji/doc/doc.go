@@ -46,11 +46,16 @@ func scanFuncDocString(s *scanner.Scanner) (string, error) {
 	tok2, _, _ := s.Scan()
 	if tok2 != scanner.EOF && tok2 == scanner.DOT {
 		// tok1 is a package because tok2 is a "."
+		// package functions can be named after keywords (e.g. strings.replace)
 		tok3, _, lit3 := s.Scan()
-		if tok3 != scanner.IDENT {
+		switch {
+		case tok3 == scanner.IDENT:
+			return funcDocString(lit1, lit3)
+		case tok3.IsKeyword():
+			return funcDocString(lit1, strings.ToLower(tok3.String()))
+		default:
 			return "", ErrInvalid
 		}
-		return funcDocString(lit1, lit3)
 	} else {
 		// no package, it's a builtin function
 		return funcDocString("", lit1)
@@ -3,19 +3,37 @@ package doc
 type functionDocs map[string]string
 
 var packageDocs = map[string]functionDocs{
-	"math": mathDocs,
-	"":     builtinDocs,
+	"math":    mathDocs,
+	"strings": stringsDocs,
+	"":        builtinDocs,
 }
 
 var builtinDocs = functionDocs{
-	"pk":     "The pk() function returns the primary key for the current document",
-	"count":  "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
-	"min":    "Returns the minimum value of the arg1 expression in a group.",
-	"max":    "Returns the maximum value of the arg1 expressein in a group.",
-	"sum":    "The sum function returns the sum of all values taken by the arg1 expression in a group.",
-	"avg":    "The avg function returns the average of all values taken by the arg1 expression in a group.",
-	"typeof": "The typeof function returns the type of arg1.",
-	"len":    "Then len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
+	"pk":              "The pk() function returns the primary key for the current document",
+	"commit_sequence": "The commit_sequence function returns the sequence number of the last committed write transaction. Sequence numbers start at zero every time the database is opened.",
+	"count":           "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
+	"min":             "Returns the minimum value of the arg1 expression in a group.",
+	"max":             "Returns the maximum value of the arg1 expressein in a group.",
+	"sum":             "The sum function returns the sum of all values taken by the arg1 expression in a group.",
+	"avg":             "The avg function returns the average of all values taken by the arg1 expression in a group.",
+	"typeof":          "The typeof function returns the type of arg1.",
+	"len":             "Then len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
+	"array_append":    "The array_append function returns a copy of the array arg1 with arg2 appended to it.",
+	"array_remove":    "The array_remove function returns a copy of the array arg1 without the values equal to arg2.",
+	"array_contains":  "The array_contains function returns true if the array arg1 contains a value equal to arg2.",
+	"array_slice":     "The array_slice function returns the values of the array arg1 from the index arg2 up to the index arg3, excluded, or up to the end of the array if arg3 is not provided. Indexes start at 0.",
+	"array_concat":    "The array_concat function returns the concatenation of the arrays arg1, arg2 and the following arguments.",
+	"document_keys":   "The document_keys function returns an array containing the field names of the document arg1.",
+	"document_merge":  "The document_merge function returns a document containing the fields of the documents arg1, arg2 and the following arguments. If a field is present in several documents, the value of the last one is used.",
+	"json_extract":    "The json_extract function returns the value of the document or array arg1 located at the path arg2, e.g. '$.a.b[0]', or NULL if there is none. If arg1 is a text, it is parsed as JSON.",
+	"row_number":      "The row_number window function returns the position of the current row within its partition, starting at 1. It requires an OVER clause.",
+	"rank":            "The rank window function returns the rank of the current row within its partition, with gaps for rows having the same ORDER BY values. It requires an OVER clause.",
+	"dense_rank":      "The dense_rank window function returns the rank of the current row within its partition, without gaps. It requires an OVER clause.",
+	"lag":             "The lag window function returns the value of arg1 evaluated on the row located arg2 rows before the current row within its partition, or arg3 if there is no such row. arg2 defaults to 1 and arg3 to NULL. It requires an OVER clause.",
+	"lead":            "The lead window function returns the value of arg1 evaluated on the row located arg2 rows after the current row within its partition, or arg3 if there is no such row. arg2 defaults to 1 and arg3 to NULL. It requires an OVER clause.",
+	"now":             "The now function returns the current timestamp.",
+	"date_trunc":      "The date_trunc function truncates the timestamp arg2 to the precision arg1, which must be one of 'microsecond', 'millisecond', 'second', 'minute', 'hour', 'day', 'week', 'month', 'quarter' or 'year'. Texts are converted to timestamps.",
+	"extract":         "The extract function returns the field arg1 of the timestamp arg2, which must be one of 'year', 'quarter', 'month', 'week', 'day', 'dow', 'doy', 'hour', 'minute', 'second', 'millisecond', 'microsecond' or 'epoch'. Texts are converted to timestamps.",
 }
 
 var mathDocs = functionDocs{
@@ -28,3 +46,20 @@ var mathDocs = functionDocs{
 	"atan2": "Returns the arctangent of arg1/arg2, using the signs of the two to determine the quadrant of the return value.",
 	"floor": "Returns the greatest integer value less than or equal to arg1.",
 }
+
+var stringsDocs = functionDocs{
+	"lower":          "Returns arg1 converted to lowercase.",
+	"upper":          "Returns arg1 converted to uppercase.",
+	"trim":           "Returns arg1 without the leading and trailing characters contained in arg2. arg2 defaults to a space.",
+	"ltrim":          "Returns arg1 without the leading characters contained in arg2. arg2 defaults to a space.",
+	"rtrim":          "Returns arg1 without the trailing characters contained in arg2. arg2 defaults to a space.",
+	"substr":         "Returns the part of arg1 starting at the position arg2, and of length arg3 if provided. Positions start at 1.",
+	"replace":        "Returns arg1 with all occurrences of arg2 replaced by arg3.",
+	"split":          "Returns an array containing the parts of arg1 separated by arg2.",
+	"concat_ws":      "Returns the concatenation of arg2 and the following arguments, separated by arg1. NULL arguments are ignored.",
+	"position":       "Returns the position of the first occurrence of arg2 in arg1, starting at 1, or 0 if arg1 doesn't contain arg2.",
+	"lpad":           "Returns arg1 prepended with the characters of arg3, repeated until it reaches the length arg2. If arg1 is longer than arg2, it is truncated. arg3 defaults to a space.",
+	"rpad":           "Returns arg1 appended with the characters of arg3, repeated until it reaches the length arg2. If arg1 is longer than arg2, it is truncated. arg3 defaults to a space.",
+	"starts_with":    "Returns true if arg1 starts with arg2.",
+	"regexp_replace": "Returns arg1 with all the matches of the regular expression arg2 replaced by arg3. arg3 can refer to the submatches using $1, $2, etc.",
+}
@@ -12,6 +12,18 @@ func init() {
 		tokenDocs[tok] = "TODO"
 	}
 
-	tokenDocs[scanner.BY] = "See GROUP BY, ORDER BY"
+	tokenDocs[scanner.ANALYZE] = "ANALYZE [TABLE] collects the number of documents, the number of distinct values and a histogram of the values of the primary key and the indexes of [TABLE], or of every table if omitted. The query planner uses them to choose the most selective index. EXPLAIN ANALYZE [STATEMENT] runs [STATEMENT], rolls back its changes and reports the number of documents produced, the time spent and the bytes read by each operator"
+	tokenDocs[scanner.BY] = "See GROUP BY, ORDER BY, PARTITION BY"
+	tokenDocs[scanner.INTERVAL] = "INTERVAL [TEXT] returns the interval represented by [TEXT], e.g. INTERVAL '1 month 2 hours' or INTERVAL '1h30m'. It can be added to or subtracted from a timestamp or another interval"
 	tokenDocs[scanner.FROM] = "FROM [TABLE] selects documents in the table named [TABLE]"
+	tokenDocs[scanner.OVER] = "[FUNCTION] OVER ([PARTITION BY ...] [ORDER BY ...]) computes the window function [FUNCTION] for every document, without grouping them"
+	tokenDocs[scanner.PARTITION] = "PARTITION BY [EXPR] splits the documents of a window in partitions sharing the same value for [EXPR]. See OVER"
+	tokenDocs[scanner.UNNEST] = "UNNEST([EXPR]) AS [ALIAS] joins each document with every value of the array [EXPR], available as [ALIAS], e.g. SELECT t.id, item FROM t, UNNEST(t.items) AS item"
+	tokenDocs[scanner.FOREIGN] = "FOREIGN KEY ([PATHS]) REFERENCES [TABLE] [(PATHS)] [ON DELETE CASCADE | SET NULL | RESTRICT] is a table constraint ensuring that the documents referenced by [PATHS] exist in [TABLE]. See REFERENCES"
+	tokenDocs[scanner.REFERENCES] = "[FIELD] REFERENCES [TABLE] [(PATH)] [ON DELETE CASCADE | SET NULL | RESTRICT] ensures that the document of [TABLE] referenced by [FIELD] exists, e.g. CREATE TABLE orders (id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE). Deleting a referenced document fails by default"
+	tokenDocs[scanner.RELEASE] = "RELEASE [SAVEPOINT] [NAME] removes the savepoint [NAME] and the savepoints created after it, keeping their changes"
+	tokenDocs[scanner.SAVEPOINT] = "SAVEPOINT [NAME] marks the current state of a transaction. ROLLBACK TO [SAVEPOINT] [NAME] undoes the changes made after the savepoint without ending the transaction"
+	tokenDocs[scanner.TRIGGER] = "A TRIGGER runs an INSERT, UPDATE or DELETE statement BEFORE or AFTER each document of a table is inserted, updated or deleted, with the OLD and NEW documents available, e.g. CREATE TRIGGER audit_users AFTER DELETE ON users DO INSERT INTO audit (id) VALUES (OLD.id)"
+	tokenDocs[scanner.VIEW] = "A VIEW is a named SELECT query that can be used in the FROM clause like a table, e.g. CREATE VIEW adults AS SELECT * FROM users WHERE age >= 18"
+	tokenDocs[scanner.TYPETIMESTAMP] = "TIMESTAMP is the type of date and time values, stored in UTC with a nanosecond precision. Texts are converted to TIMESTAMP using the RFC 3339 format, e.g. '2006-01-02T15:04:05Z', '2006-01-02 15:04:05' or '2006-01-02'"
 }
@@ -49,6 +49,11 @@ var commands = []command{
 		DisplayName: ".tables",
 		Description: "List names of tables.",
 	},
+	{
+		Name:        ".views",
+		DisplayName: ".views",
+		Description: "List names of views.",
+	},
 	{
 		Name:        ".indexes",
 		Options:     "[table_name]",
@@ -150,6 +155,25 @@ func runTablesCmd(db *genji.DB, w io.Writer) error {
 	})
 }
 
+// runViewsCmd displays all views.
+func runViewsCmd(db *genji.DB, w io.Writer) error {
+	res, err := db.Query("SELECT name FROM __genji_catalog WHERE type = 'view'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	return res.Iterate(func(d types.Document) error {
+		var viewName string
+		err = document.Scan(d, &viewName)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, viewName)
+		return err
+	})
+}
+
 // runIndexesCmd displays a list of indexes. If table is non-empty, it only
 // displays that table's indexes. If not, it displays all indexes.
 func runIndexesCmd(db *genji.DB, tableName string, w io.Writer) error {
@@ -52,6 +52,25 @@ func TestRunTablesCmd(t *testing.T) {
 	}
 }
 
+func TestRunViewsCmd(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE foo(a, b);
+		CREATE VIEW foo_b AS SELECT b FROM foo;
+		CREATE VIEW foo_a AS SELECT a FROM foo;
+	`)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = runViewsCmd(db, &buf)
+	assert.NoError(t, err)
+
+	require.Equal(t, "foo_a\nfoo_b\n", buf.String())
+}
+
 func TestIndexesCmd(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -445,6 +445,12 @@ func (sh *Shell) runCommand(ctx context.Context, in string) error {
 		}
 
 		return runTablesCmd(sh.db, os.Stdout)
+	case ".views":
+		if len(cmd) > 1 {
+			return fmt.Errorf(getUsage(".views"))
+		}
+
+		return runViewsCmd(sh.db, os.Stdout)
 	case ".exit", "exit":
 		if len(cmd) > 1 {
 			return fmt.Errorf(getUsage(".exit"))
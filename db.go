@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"io"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -74,8 +75,25 @@ func (db *DB) Close() error {
 	return db.DB.Close()
 }
 
+// Backup writes a consistent copy of the database to w, while write transactions
+// may continue. The backup can be restored with RestoreBackup.
+// Encrypted databases produce encrypted backups, which must be opened
+// with the same encryption key once restored.
+func (db *DB) Backup(w io.Writer) error {
+	return db.DB.Backup(w)
+}
+
+// RestoreBackup creates a database at the given path from a backup created by DB.Backup.
+// The path must not exist or be an empty directory.
+func RestoreBackup(r io.Reader, path string) error {
+	return database.Restore(r, path)
+}
+
 // Begin starts a new transaction.
 // The returned transaction must be closed either by calling Rollback or Commit.
+// Multiple write transactions can run concurrently. If one of them conflicts
+// with another, its statements return an error for which IsConflictError is true:
+// the transaction must be rolled back and can be retried.
 func (db *DB) Begin(writable bool) (*Tx, error) {
 	tx, err := db.DB.BeginTx(&database.TxOptions{
 		ReadOnly: !writable,
@@ -187,6 +205,25 @@ func (tx *Tx) Commit() error {
 	return tx.tx.Commit()
 }
 
+// Savepoint creates a savepoint with the given name.
+// Rolling back to it undoes the changes made after its creation
+// without ending the transaction. Savepoints can be nested.
+func (tx *Tx) Savepoint(name string) error {
+	return tx.tx.Savepoint(name)
+}
+
+// RollbackToSavepoint undoes the changes made since the most recent savepoint
+// with the given name. The savepoint remains active and can be rolled back to again.
+func (tx *Tx) RollbackToSavepoint(name string) error {
+	return tx.tx.RollbackToSavepoint(name)
+}
+
+// ReleaseSavepoint removes the most recent savepoint with the given name
+// and the savepoints created after it, keeping their changes.
+func (tx *Tx) ReleaseSavepoint(name string) error {
+	return tx.tx.ReleaseSavepoint(name)
+}
+
 // Query the database withing the transaction and returns the result.
 // Closing the returned result after usage is not mandatory.
 func (tx *Tx) Query(q string, args ...interface{}) (*Result, error) {
@@ -396,6 +433,19 @@ func (r *Result) Fields() []string {
 	return []string{"*"}
 }
 
+// RowsAffected returns the number of documents inserted, updated or deleted
+// by the statement. It is known once the result has been iterated.
+func (r *Result) RowsAffected() int64 {
+	return r.result.RowsAffected()
+}
+
+// LastInsertID returns the key of the last document inserted by the statement,
+// if it is an integer, i.e. a docid or an integer primary key.
+// It is known once the result has been iterated.
+func (r *Result) LastInsertID() int64 {
+	return r.result.LastInsertID()
+}
+
 // Close the result stream.
 func (r *Result) Close() (err error) {
 	if r == nil {
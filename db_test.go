@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji"
 	"github.com/genjidb/genji/document"
@@ -195,6 +196,414 @@ func TestQueryDocument(t *testing.T) {
 	})
 }
 
+func TestResultChanges(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT)")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		query        string
+		rowsAffected int64
+		lastInsertID int64
+	}{
+		{"INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3)", 3, 3},
+		{"INSERT INTO test (a, b) VALUES (1, 5) ON CONFLICT DO NOTHING", 0, 0},
+		{"INSERT INTO test (a, b) VALUES (1, 10) ON CONFLICT DO REPLACE", 1, 0},
+		{"UPDATE test SET b = b + 100 WHERE a > 1", 2, 0},
+		{"UPDATE test SET a = a + 10 WHERE a = 1", 1, 0},
+		{"DELETE FROM test WHERE a < 10", 2, 0},
+		{"SELECT * FROM test", 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			res, err := db.Query(test.query)
+			assert.NoError(t, err)
+			defer res.Close()
+
+			err = res.Iterate(func(d types.Document) error {
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Equal(t, test.rowsAffected, res.RowsAffected())
+			require.Equal(t, test.lastInsertID, res.LastInsertID())
+		})
+	}
+}
+
+func TestTxSavepoint(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	err = tx.Exec("CREATE TABLE test(a INT PRIMARY KEY); INSERT INTO test (a) VALUES (1)")
+	assert.NoError(t, err)
+
+	err = tx.Savepoint("batch")
+	assert.NoError(t, err)
+
+	// the second insert fails, undo the whole batch and retry it
+	err = tx.Exec("INSERT INTO test (a) VALUES (2), (1)")
+	assert.Error(t, err)
+	err = tx.RollbackToSavepoint("batch")
+	assert.NoError(t, err)
+	err = tx.Exec("INSERT INTO test (a) VALUES (3)")
+	assert.NoError(t, err)
+	err = tx.ReleaseSavepoint("batch")
+	assert.NoError(t, err)
+
+	err = tx.RollbackToSavepoint("batch")
+	assert.Error(t, err)
+
+	assert.NoError(t, tx.Commit())
+
+	var a []int
+	res, err := db.Query("SELECT a FROM test")
+	assert.NoError(t, err)
+	defer res.Close()
+	err = res.Iterate(func(d types.Document) error {
+		var i int
+		err := document.Scan(d, &i)
+		a = append(a, i)
+		return err
+	})
+	assert.NoError(t, err)
+	require.Equal(t, []int{1, 3}, a)
+}
+
+func TestSubscribe(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT, c INT); CREATE TABLE other(a INT)")
+	assert.NoError(t, err)
+
+	sub := db.Subscribe("test")
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = db.Exec("INSERT INTO test (a, b, c) VALUES (1, 1, 1), (2, 2, 2); INSERT INTO other (a) VALUES (1)")
+	assert.NoError(t, err)
+
+	cs, err := sub.Next(ctx)
+	assert.NoError(t, err)
+	require.Len(t, cs.Changes, 2)
+	require.Equal(t, genji.ChangeInsert, cs.Changes[0].Type)
+	require.Equal(t, "test", cs.Changes[0].TableName)
+	require.Equal(t, "[1]", cs.Changes[0].Key.String())
+	testutil.RequireDocJSONEq(t, cs.Changes[0].Document, `{"a": 1, "b": 1, "c": 1}`)
+	require.Equal(t, "[2]", cs.Changes[1].Key.String())
+
+	// changes made to other tables are not delivered
+	err = db.Exec("INSERT INTO other (a) VALUES (2)")
+	assert.NoError(t, err)
+
+	// rolled back changes are not delivered
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	err = tx.Exec("INSERT INTO test (a) VALUES (3)")
+	assert.NoError(t, err)
+	err = tx.Savepoint("sp")
+	assert.NoError(t, err)
+	err = tx.Exec("DELETE FROM test WHERE a = 1")
+	assert.NoError(t, err)
+	err = tx.RollbackToSavepoint("sp")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+
+	err = db.Update(func(tx *genji.Tx) error {
+		return tx.Exec("UPDATE test SET b = 10, c = NULL WHERE a = 1; DELETE FROM test WHERE a = 2")
+	})
+	assert.NoError(t, err)
+
+	// both inserts into other were committed in their own transaction
+	next, err := sub.Next(ctx)
+	assert.NoError(t, err)
+	require.Equal(t, cs.Sequence+3, next.Sequence)
+	require.Len(t, next.Changes, 2)
+
+	require.Equal(t, genji.ChangeReplace, next.Changes[0].Type)
+	testutil.RequireDocJSONEq(t, next.Changes[0].Document, `{"a": 1, "b": 10}`)
+	require.Equal(t, []document.Op{
+		document.NewSetOp(document.NewPath("b"), types.NewIntegerValue(10)),
+		document.NewDeleteOp(document.NewPath("c"), types.NewIntegerValue(1)),
+	}, next.Changes[0].Ops)
+
+	require.Equal(t, genji.ChangeDelete, next.Changes[1].Type)
+	require.Equal(t, "[2]", next.Changes[1].Key.String())
+	testutil.RequireDocJSONEq(t, next.Changes[1].Document, `{"a": 2, "b": 2, "c": 2}`)
+
+	d, err := db.QueryDocument("SELECT commit_sequence()")
+	assert.NoError(t, err)
+	var seq uint64
+	err = document.Scan(d, &seq)
+	assert.NoError(t, err)
+	require.Equal(t, next.Sequence, seq)
+
+	// reading from a closed subscription
+	assert.NoError(t, sub.Close())
+	_, err = sub.Next(ctx)
+	require.True(t, genji.IsSubscriptionClosedError(err), err)
+}
+
+func TestSubscribeConcurrentCommits(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY)")
+	assert.NoError(t, err)
+
+	sub := db.Subscribe("test")
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// transactions writing different documents commit concurrently
+	const writers, commits = 8, 25
+	var g errgroup.Group
+	for w := 0; w < writers; w++ {
+		w := w
+		g.Go(func() error {
+			for i := 0; i < commits; i++ {
+				err := db.Exec("INSERT INTO test (a) VALUES (?)", w*commits+i)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	assert.NoError(t, g.Wait())
+
+	// every transaction is delivered once, in the order of the sequence numbers
+	var prev uint64
+	seen := make(map[string]bool)
+	for i := 0; i < writers*commits; i++ {
+		cs, err := sub.Next(ctx)
+		assert.NoError(t, err)
+		if prev != 0 {
+			require.Equal(t, prev+1, cs.Sequence)
+		}
+		prev = cs.Sequence
+
+		require.Len(t, cs.Changes, 1)
+		k := cs.Changes[0].Key.String()
+		require.False(t, seen[k], k)
+		seen[k] = true
+	}
+
+	d, err := db.QueryDocument("SELECT commit_sequence()")
+	assert.NoError(t, err)
+	var seq uint64
+	err = document.Scan(d, &seq)
+	assert.NoError(t, err)
+	require.Equal(t, prev, seq)
+}
+
+func TestConcurrentWriteTransactions(t *testing.T) {
+	newDB := func(t *testing.T) *genji.DB {
+		db, err := genji.Open(":memory:")
+		assert.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT); INSERT INTO test (a, b) VALUES (1, 0), (2, 0)")
+		assert.NoError(t, err)
+		return db
+	}
+
+	t.Run("different documents", func(t *testing.T) {
+		db := newDB(t)
+
+		tx1, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx1.Rollback()
+
+		tx2, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx2.Rollback()
+
+		assert.NoError(t, tx1.Exec("UPDATE test SET b = 1 WHERE a = 1"))
+		assert.NoError(t, tx2.Exec("UPDATE test SET b = 2 WHERE a = 2"))
+		assert.NoError(t, tx2.Exec("INSERT INTO test (a, b) VALUES (3, 3)"))
+		assert.NoError(t, tx1.Commit())
+		assert.NoError(t, tx2.Commit())
+
+		d, err := db.QueryDocument("SELECT SUM(b) AS s FROM test")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"s": 6}`)
+	})
+
+	t.Run("document modified by a committed transaction", func(t *testing.T) {
+		db := newDB(t)
+
+		tx1, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx1.Rollback()
+
+		tx2, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx2.Rollback()
+
+		assert.NoError(t, tx1.Exec("UPDATE test SET b = b + 1 WHERE a = 1"))
+		assert.NoError(t, tx1.Commit())
+
+		// tx2 started before tx1 committed, its update could be lost
+		err = tx2.Exec("UPDATE test SET b = b + 1 WHERE a = 1")
+		require.True(t, genji.IsConflictError(err), err)
+		assert.NoError(t, tx2.Rollback())
+
+		// retry
+		assert.NoError(t, db.Exec("UPDATE test SET b = b + 1 WHERE a = 1"))
+		d, err := db.QueryDocument("SELECT b FROM test WHERE a = 1")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"b": 2}`)
+	})
+
+	t.Run("deadlock", func(t *testing.T) {
+		db := newDB(t)
+
+		tx1, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx1.Rollback()
+
+		tx2, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx2.Rollback()
+
+		assert.NoError(t, tx1.Exec("UPDATE test SET b = 1 WHERE a = 1"))
+		assert.NoError(t, tx2.Exec("UPDATE test SET b = 2 WHERE a = 2"))
+
+		var g errgroup.Group
+		g.Go(func() error {
+			// waits for tx2
+			err := tx1.Exec("UPDATE test SET b = 1 WHERE a = 2")
+			if err != nil {
+				return err
+			}
+			return tx1.Commit()
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		err = tx2.Exec("UPDATE test SET b = 2 WHERE a = 1")
+		require.True(t, genji.IsConflictError(err), err)
+		assert.NoError(t, tx2.Rollback())
+
+		assert.NoError(t, g.Wait())
+		d, err := db.QueryDocument("SELECT SUM(b) AS s FROM test")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"s": 2}`)
+	})
+
+	t.Run("unique constraint", func(t *testing.T) {
+		db := newDB(t)
+		assert.NoError(t, db.Exec("CREATE TABLE u(a INT UNIQUE)"))
+
+		tx1, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx1.Rollback()
+
+		tx2, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx2.Rollback()
+
+		assert.NoError(t, tx1.Exec("INSERT INTO u (a) VALUES (1)"))
+
+		var g errgroup.Group
+		g.Go(func() error {
+			// waits for tx1
+			return tx2.Exec("INSERT INTO u (a) VALUES (1)")
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, tx1.Commit())
+		require.True(t, genji.IsConflictError(g.Wait()))
+	})
+
+	t.Run("concurrent inserts", func(t *testing.T) {
+		db := newDB(t)
+		assert.NoError(t, db.Exec("CREATE TABLE log(worker INT, i INT)"))
+
+		var g errgroup.Group
+		for w := 0; w < 8; w++ {
+			w := w
+			g.Go(func() error {
+				for i := 0; i < 50; i++ {
+					err := db.Exec("INSERT INTO log (worker, i) VALUES (?, ?)", w, i)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		assert.NoError(t, g.Wait())
+
+		d, err := db.QueryDocument("SELECT COUNT(*) AS n FROM log")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"n": 400}`)
+	})
+
+	t.Run("docid sequence", func(t *testing.T) {
+		for _, commit := range []bool{true, false} {
+			dir := t.TempDir()
+
+			db, err := genji.Open(dir)
+			assert.NoError(t, err)
+			assert.NoError(t, db.Exec("CREATE TABLE log(i INT)"))
+
+			// tx1 leases the first values of the sequence
+			tx1, err := db.Begin(true)
+			assert.NoError(t, err)
+			assert.NoError(t, tx1.Exec("INSERT INTO log (i) VALUES (0)"))
+
+			// tx2 uses the values leased by tx1, then extends the lease
+			tx2, err := db.Begin(true)
+			assert.NoError(t, err)
+			for i := 1; i <= 100; i++ {
+				assert.NoError(t, tx2.Exec("INSERT INTO log (i) VALUES (?)", i))
+			}
+			assert.NoError(t, tx2.Commit())
+
+			if commit {
+				assert.NoError(t, tx1.Commit())
+			} else {
+				assert.NoError(t, tx1.Rollback())
+			}
+
+			// simulate a crash: the sequences are not released
+			assert.NoError(t, db.DB.DB.Close())
+
+			db, err = genji.Open(dir)
+			assert.NoError(t, err)
+
+			for i := 101; i <= 200; i++ {
+				assert.NoError(t, db.Exec("INSERT INTO log (i) VALUES (?)", i))
+			}
+
+			d, err := db.QueryDocument("SELECT COUNT(*) AS n FROM log")
+			assert.NoError(t, err)
+			if commit {
+				testutil.RequireDocJSONEq(t, d, `{"n": 201}`)
+			} else {
+				testutil.RequireDocJSONEq(t, d, `{"n": 200}`)
+			}
+			assert.NoError(t, db.Close())
+		}
+	})
+}
+
 func TestPrepareThreadSafe(t *testing.T) {
 	db, err := genji.Open(":memory:")
 	assert.NoError(t, err)
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/genjidb/genji/types"
 )
@@ -21,6 +22,10 @@ func CastAs(v types.Value, t types.ValueType) (types.Value, error) {
 		return CastAsInteger(v)
 	case types.DoubleValue:
 		return CastAsDouble(v)
+	case types.TimestampValue:
+		return CastAsTimestamp(v)
+	case types.IntervalValue:
+		return CastAsInterval(v)
 	case types.BlobValue:
 		return CastAsBlob(v)
 	case types.TextValue:
@@ -132,8 +137,57 @@ func CastAsDouble(v types.Value) (types.Value, error) {
 	return nil, fmt.Errorf("cannot cast %s as double", v.Type())
 }
 
+// CastAsTimestamp casts according to the following rules:
+// Text: uses types.ParseTimestamp to determine the timestamp value,
+// it fails if the text doesn't contain a valid timestamp.
+// Any other type is considered an invalid cast.
+func CastAsTimestamp(v types.Value) (types.Value, error) {
+	// Null values always remain null.
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	switch v.Type() {
+	case types.TimestampValue:
+		return v, nil
+	case types.TextValue:
+		t, err := types.ParseTimestamp(types.As[string](v))
+		if err != nil {
+			return nil, fmt.Errorf(`cannot cast %q as timestamp`, v.V())
+		}
+		return types.NewTimestampValue(t), nil
+	}
+
+	return nil, fmt.Errorf("cannot cast %s as timestamp", v.Type())
+}
+
+// CastAsInterval casts according to the following rules:
+// Text: uses types.ParseInterval to determine the interval value,
+// it fails if the text doesn't contain a valid interval.
+// Any other type is considered an invalid cast.
+func CastAsInterval(v types.Value) (types.Value, error) {
+	// Null values always remain null.
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	switch v.Type() {
+	case types.IntervalValue:
+		return v, nil
+	case types.TextValue:
+		i, err := types.ParseInterval(types.As[string](v))
+		if err != nil {
+			return nil, fmt.Errorf(`cannot cast %q as interval`, v.V())
+		}
+		return types.NewIntervalValue(i), nil
+	}
+
+	return nil, fmt.Errorf("cannot cast %s as interval", v.Type())
+}
+
 // CastAsText returns a JSON representation of v.
 // If the representation is a string, it gets unquoted.
+// Timestamps are represented using the RFC 3339 format.
 func CastAsText(v types.Value) (types.Value, error) {
 	// Null values always remain null.
 	if v.Type() == types.NullValue {
@@ -143,6 +197,10 @@ func CastAsText(v types.Value) (types.Value, error) {
 	switch v.Type() {
 	case types.TextValue:
 		return v, nil
+	case types.TimestampValue:
+		return types.NewTextValue(types.As[time.Time](v).Format(time.RFC3339Nano)), nil
+	case types.IntervalValue:
+		return types.NewTextValue(types.As[types.Interval](v).String()), nil
 	case types.BlobValue:
 		return types.NewTextValue(base64.StdEncoding.EncodeToString(types.As[[]byte](v))), nil
 	}
@@ -206,7 +206,9 @@ func NewValue(x interface{}) (types.Value, error) {
 	case time.Duration:
 		return types.NewIntegerValue(v.Nanoseconds()), nil
 	case time.Time:
-		return types.NewTextValue(v.Format(time.RFC3339Nano)), nil
+		return types.NewTimestampValue(v), nil
+	case types.Interval:
+		return types.NewIntervalValue(v), nil
 	case nil:
 		return types.NewNullValue(), nil
 	case types.Document:
@@ -25,7 +25,7 @@ func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 	var i, j int
 	for {
 		for i < len(f1) && (j >= len(f2) || f1[i] < f2[j]) {
-			v, err := d1.GetByField(f1[j])
+			v, err := d1.GetByField(f1[i])
 			if err != nil {
 				return nil, err
 			}
@@ -46,6 +46,11 @@ func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 			break
 		}
 
+		// compare the values only when both documents have the field
+		if i == len(f1) || j == len(f2) || f1[i] != f2[j] {
+			continue
+		}
+
 		v1, err := d1.GetByField(f1[i])
 		if err != nil {
 			return nil, err
@@ -65,13 +70,13 @@ func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 		} else {
 			switch v1.Type() {
 			case types.DocumentValue:
-				subOps, err := diff(append(path, PathFragment{FieldName: f1[i]}), types.As[types.Document](v1), types.As[types.Document](v2))
+				subOps, err := diff(path.ExtendField(f1[i]), types.As[types.Document](v1), types.As[types.Document](v2))
 				if err != nil {
 					return nil, err
 				}
 				ops = append(ops, subOps...)
 			case types.ArrayValue:
-				subOps, err := arrayDiff(append(path, PathFragment{FieldName: f1[i]}), types.As[types.Array](v1), types.As[types.Array](v2))
+				subOps, err := arrayDiff(path.ExtendField(f1[i]), types.As[types.Array](v1), types.As[types.Array](v2))
 				if err != nil {
 					return nil, err
 				}
@@ -133,13 +138,13 @@ func arrayDiff(path Path, a1, a2 types.Array) ([]Op, error) {
 
 		switch v1.Type() {
 		case types.DocumentValue:
-			subOps, err := diff(append(path, PathFragment{ArrayIndex: i}), types.As[types.Document](v1), types.As[types.Document](v2))
+			subOps, err := diff(path.ExtendIndex(i), types.As[types.Document](v1), types.As[types.Document](v2))
 			if err != nil {
 				return nil, err
 			}
 			ops = append(ops, subOps...)
 		case types.ArrayValue:
-			subOps, err := arrayDiff(append(path, PathFragment{ArrayIndex: i}), types.As[types.Array](v1), types.As[types.Array](v2))
+			subOps, err := arrayDiff(path.ExtendIndex(i), types.As[types.Array](v1), types.As[types.Array](v2))
 			if err != nil {
 				return nil, err
 			}
@@ -59,6 +59,18 @@ func TestDiff(t *testing.T) {
 				{"set", document.NewPath("a"), types.NewTextValue("hello")},
 			},
 		},
+		{
+			name: "add and remove fields",
+			d1:   `{"a": 1, "c": 3, "d": 4}`,
+			d2:   `{"b": 2, "d": 5, "e": 6}`,
+			want: []document.Op{
+				{"delete", document.NewPath("a"), types.NewIntegerValue(1)},
+				{"set", document.NewPath("b"), types.NewIntegerValue(2)},
+				{"delete", document.NewPath("c"), types.NewIntegerValue(3)},
+				{"set", document.NewPath("d"), types.NewIntegerValue(5)},
+				{"set", document.NewPath("e"), types.NewIntegerValue(6)},
+			},
+		},
 		{
 			name: "nested document: replace field",
 			d1:   `{"a": {"b": 1}}`,
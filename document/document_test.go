@@ -426,7 +426,7 @@ func TestNewFromStruct(t *testing.T) {
 			case 25:
 				require.EqualValues(t, types.IntegerValue, v.Type())
 			case 26:
-				require.EqualValues(t, types.TextValue, v.Type())
+				require.EqualValues(t, types.TimestampValue, v.Type())
 			default:
 				require.FailNowf(t, "", "unknown field %q", f)
 			}
@@ -337,7 +337,11 @@ func scanValue(v types.Value, ref reflect.Value) error {
 	// test with supported stdlib types
 	switch ref.Type().String() {
 	case "time.Time":
-		if v.Type() == types.TextValue {
+		switch v.Type() {
+		case types.TimestampValue:
+			ref.Set(reflect.ValueOf(types.As[time.Time](v)))
+			return nil
+		case types.TextValue:
 			parsed, err := time.Parse(time.RFC3339Nano, types.As[string](v))
 			if err != nil {
 				return err
@@ -346,6 +350,17 @@ func scanValue(v types.Value, ref reflect.Value) error {
 			ref.Set(reflect.ValueOf(parsed))
 			return nil
 		}
+	case "time.Duration":
+		// intervals made of months have no fixed duration
+		if v.Type() == types.IntervalValue {
+			i := types.As[types.Interval](v)
+			if i.Months != 0 {
+				return fmt.Errorf("cannot scan interval %q into a time.Duration", i)
+			}
+
+			ref.Set(reflect.ValueOf(i.Duration))
+			return nil
+		}
 	}
 
 	switch ref.Kind() {
@@ -292,6 +292,20 @@ func TestScan(t *testing.T) {
 		assert.NoError(t, err)
 		require.Equal(t, &foo{A: &bar{B: 10}}, &f)
 	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		type foo struct {
+			A time.Time
+		}
+
+		ts := time.Date(2020, time.November, 15, 16, 37, 10, 20, time.UTC)
+
+		d := document.NewFieldBuffer().Add("a", types.NewTimestampValue(ts))
+		var f foo
+		err := document.StructScan(d, &f)
+		assert.NoError(t, err)
+		require.Equal(t, ts, f.A)
+	})
 }
 
 type documentScanner struct {
@@ -197,19 +197,41 @@ func (s stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver
 	default:
 	}
 
-	return result{}, s.stmt.Exec(driverNamedValueToParams(args)...)
+	res, err := s.stmt.Query(driverNamedValueToParams(args)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = res.Iterate(func(d types.Document) error {
+		return nil
+	})
+	if err != nil {
+		res.Close()
+		return nil, err
+	}
+
+	r := result{
+		rowsAffected: res.RowsAffected(),
+		lastInsertID: res.LastInsertID(),
+	}
+
+	return r, res.Close()
 }
 
-type result struct{}
+type result struct {
+	rowsAffected int64
+	lastInsertID int64
+}
 
-// LastInsertId is not supported and returns an error.
+// LastInsertId returns the key of the last document inserted by the statement,
+// if it is an integer, i.e. a docid or an integer primary key.
 func (r result) LastInsertId() (int64, error) {
-	return 0, errors.New("not supported")
+	return r.lastInsertID, nil
 }
 
-// RowsAffected is not supported and returns an error.
+// RowsAffected returns the number of documents inserted, updated or deleted by the statement.
 func (r result) RowsAffected() (int64, error) {
-	return 0, errors.New("not supported")
+	return r.rowsAffected, nil
 }
 
 func (s stmt) Query(args []driver.Value) (driver.Rows, error) {
@@ -26,7 +26,7 @@ func TestDriver(t *testing.T) {
 	res, err := db.Exec("CREATE TABLE test")
 	assert.NoError(t, err)
 	n, err := res.RowsAffected()
-	assert.Error(t, err)
+	assert.NoError(t, err)
 	require.EqualValues(t, 0, n)
 
 	for i := 0; i < 10; i++ {
@@ -149,6 +149,53 @@ func TestDriver(t *testing.T) {
 		require.Equal(t, 10, count)
 	})
 
+	t.Run("Rows affected", func(t *testing.T) {
+		_, err := db.Exec("CREATE TABLE affected(a INT PRIMARY KEY, b INT)")
+		assert.NoError(t, err)
+		defer db.Exec("DROP TABLE affected")
+
+		res, err := db.Exec("INSERT INTO affected (a, b) VALUES (1, 1), (2, 1), (3, 2)")
+		assert.NoError(t, err)
+		n, err := res.RowsAffected()
+		assert.NoError(t, err)
+		require.EqualValues(t, 3, n)
+		id, err := res.LastInsertId()
+		assert.NoError(t, err)
+		require.EqualValues(t, 3, id)
+
+		res, err = db.Exec("UPDATE affected SET b = 3 WHERE b = 1")
+		assert.NoError(t, err)
+		n, err = res.RowsAffected()
+		assert.NoError(t, err)
+		require.EqualValues(t, 2, n)
+
+		res, err = db.Exec("DELETE FROM affected WHERE a > 10")
+		assert.NoError(t, err)
+		n, err = res.RowsAffected()
+		assert.NoError(t, err)
+		require.EqualValues(t, 0, n)
+
+		res, err = db.Exec("DELETE FROM affected")
+		assert.NoError(t, err)
+		n, err = res.RowsAffected()
+		assert.NoError(t, err)
+		require.EqualValues(t, 3, n)
+	})
+
+	t.Run("Last insert id", func(t *testing.T) {
+		_, err := db.Exec("CREATE TABLE docids")
+		assert.NoError(t, err)
+		defer db.Exec("DROP TABLE docids")
+
+		for i := 1; i <= 3; i++ {
+			res, err := db.Exec("INSERT INTO docids (a) VALUES (?)", i)
+			assert.NoError(t, err)
+			id, err := res.LastInsertId()
+			assert.NoError(t, err)
+			require.EqualValues(t, i, id)
+		}
+	})
+
 	t.Run("Params", func(t *testing.T) {
 		rows, err := db.Query("SELECT a FROM test WHERE a = ?", 5)
 		assert.NoError(t, err)
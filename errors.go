@@ -11,6 +11,17 @@ import (
 // doesn't exist.
 var IsNotFoundError = errs.IsNotFoundError
 
+// IsConflictError determines if the error is returned because a transaction conflicts
+// with another one, either because of a deadlock or because a document was modified
+// concurrently. The transaction must be rolled back and can be retried.
+var IsConflictError = errs.IsConflictError
+
+// IsSubscriptionClosedError determines if the error is returned because
+// a subscription or its database was closed.
+func IsSubscriptionClosedError(err error) bool {
+	return errors.Is(err, database.ErrSubscriptionClosed)
+}
+
 // IsAlreadyExistsError determines if the error is returned as a result of
 // a conflict when attempting to create a table, an index, a document or a sequence
 // with a name that is already used by another resource.
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// Backup writes a consistent copy of the database to w, as a tar archive
+// containing the files of a Pebble database. Write transactions
+// can run while the backup is created, only the changes of the transactions
+// committed before the files are copied are part of it.
+// If the database is encrypted, so is the backup, and the same key
+// must be used to open the restored database.
+func (db *Database) Backup(w io.Writer) error {
+	if db.fs == nil {
+		return errors.New("backup is not supported by this database")
+	}
+
+	// the files are created next to the database files, which allows Pebble
+	// to hard link the sstables instead of copying them.
+	dir := fmt.Sprintf("%s.backup-%d", db.path, time.Now().UnixNano())
+
+	// the files are read from the underlying file system,
+	// to copy encrypted files and their IV files without decrypting them.
+	fs := db.fs
+	efs, encrypted := fs.(*encryptedFS)
+	if encrypted {
+		fs = efs.FS
+	}
+	defer fs.RemoveAll(dir)
+
+	var err error
+	if encrypted {
+		// encrypted files are only written once closed, the manifest
+		// and the WAL files of the database cannot be copied while it is open.
+		err = db.copyTo(dir)
+	} else {
+		err = db.checkpoint(dir)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to copy database")
+	}
+
+	names, err := fs.List(dir)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+
+	for _, name := range names {
+		err = backupFile(tw, fs, fs.PathJoin(dir, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// checkpoint creates a Pebble checkpoint of the database in dir.
+// The changes of the write transactions that committed intermediary batches
+// are part of it: they are undone by the rollback segment when the copy is opened.
+func (db *Database) checkpoint(dir string) error {
+	return db.DB.Checkpoint(dir, pebble.WithFlushedWAL())
+}
+
+// copyTo creates a Pebble database in dir and copies the content of the database into it.
+func (db *Database) copyTo(dir string) error {
+	pdb, err := pebble.Open(dir, &pebble.Options{
+		Comparer: DefaultComparer,
+		FS:       db.fs,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Store.CopyTo(pdb)
+	if err != nil {
+		_ = pdb.Close()
+		return err
+	}
+
+	return pdb.Close()
+}
+
+func backupFile(tw *tar.Writer, fs vfs.FS, path string) error {
+	fi, err := fs.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return nil
+	}
+
+	f, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    fs.PathBase(path),
+		Mode:    0600,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Restore creates a database at the given path from a backup created by Backup.
+// The path must not exist or be an empty directory.
+// If the restoration fails, the restored files are removed.
+func Restore(r io.Reader, path string) (err error) {
+	if path == "" || path == ":memory:" {
+		return errors.New("a database path is required to restore a backup")
+	}
+
+	names, err := os.ReadDir(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(names) > 0 {
+		return errors.Errorf("cannot restore backup: %q is not empty", path)
+	}
+	created := err != nil
+
+	err = os.MkdirAll(path, 0700)
+	if err != nil {
+		return err
+	}
+
+	// don't leave a partially restored database behind
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if created {
+			_ = os.RemoveAll(path)
+			return
+		}
+
+		entries, _ := os.ReadDir(path)
+		for _, e := range entries {
+			_ = os.RemoveAll(filepath.Join(path, e.Name()))
+		}
+	}()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "invalid backup")
+		}
+
+		// backups only contain the files of a single directory
+		if hdr.Typeflag != tar.TypeReg || hdr.Name != filepath.Base(hdr.Name) || strings.HasPrefix(hdr.Name, ".") {
+			return errors.Errorf("invalid backup: unexpected entry %q", hdr.Name)
+		}
+
+		err = restoreFile(tr, filepath.Join(path, hdr.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func restoreFile(r io.Reader, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		return err
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
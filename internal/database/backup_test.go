@@ -0,0 +1,151 @@
+package database_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup(t *testing.T) {
+	key := []byte("12345678901234567890123456789012")
+
+	tests := []struct {
+		name string
+		path string
+		key  []byte
+	}{
+		{"memory", ":memory:", nil},
+		{"disk", "db", nil},
+		{"encrypted", "db", key},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			path := test.path
+			if path != ":memory:" {
+				path = filepath.Join(dir, path)
+			}
+
+			opts := &genji.Options{}
+			opts.Experimental.EncryptionKey = test.key
+
+			db, err := genji.OpenWith(path, opts)
+			require.NoError(t, err)
+			defer db.Close()
+
+			err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b TEXT UNIQUE); INSERT INTO test (a, b) VALUES (1, 'a'), (2, 'b')")
+			require.NoError(t, err)
+
+			// changes that are not committed are not part of the backup
+			tx, err := db.Begin(true)
+			require.NoError(t, err)
+			defer tx.Rollback()
+			err = tx.Exec("INSERT INTO test (a, b) VALUES (3, 'c')")
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			err = db.Backup(&buf)
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+
+			// the copy is removed once the backup is written
+			if test.path != ":memory:" {
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+			}
+
+			restored := filepath.Join(dir, "restored")
+			err = genji.RestoreBackup(bytes.NewReader(buf.Bytes()), restored)
+			require.NoError(t, err)
+
+			// the backup cannot be restored over an existing database
+			err = genji.RestoreBackup(bytes.NewReader(buf.Bytes()), restored)
+			require.Error(t, err)
+
+			rdb, err := genji.OpenWith(restored, opts)
+			require.NoError(t, err)
+			defer rdb.Close()
+
+			d, err := rdb.QueryDocument("SELECT COUNT(*) FROM test WHERE b = 'b'")
+			require.NoError(t, err)
+			var count int
+			require.NoError(t, document.Scan(d, &count))
+			require.Equal(t, 1, count)
+
+			d, err = rdb.QueryDocument("SELECT COUNT(*) FROM test")
+			require.NoError(t, err)
+			require.NoError(t, document.Scan(d, &count))
+			require.Equal(t, 2, count)
+
+			err = rdb.Exec("INSERT INTO test (a, b) VALUES (3, 'c')")
+			require.NoError(t, err)
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestBackupCleanup(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := genji.Open(filepath.Join(dir, "db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY); INSERT INTO test (a) VALUES (1)")
+	require.NoError(t, err)
+
+	err = db.Backup(failingWriter{})
+	require.Error(t, err)
+
+	// the checkpoint is removed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestRestoreCleanup(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := genji.Open(filepath.Join(dir, "db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY); INSERT INTO test (a) VALUES (1)")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = db.Backup(&buf)
+	require.NoError(t, err)
+
+	// a truncated backup fails after some files are restored
+	truncated := buf.Bytes()[:buf.Len()/2]
+
+	// the directory created for the database is removed
+	restored := filepath.Join(dir, "restored")
+	err = genji.RestoreBackup(bytes.NewReader(truncated), restored)
+	require.Error(t, err)
+	_, err = os.Stat(restored)
+	require.True(t, os.IsNotExist(err))
+
+	// an existing directory is emptied
+	require.NoError(t, os.Mkdir(restored, 0700))
+	err = genji.RestoreBackup(bytes.NewReader(truncated), restored)
+	require.Error(t, err)
+	entries, err := os.ReadDir(restored)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -27,6 +28,8 @@ const (
 	RelationTableType    = "table"
 	RelationIndexType    = "index"
 	RelationSequenceType = "sequence"
+	RelationViewType     = "view"
+	RelationTriggerType  = "trigger"
 )
 
 // System sequences
@@ -39,6 +42,7 @@ const (
 	CatalogTableNamespace    tree.Namespace = 1
 	SequenceTableNamespace   tree.Namespace = 2
 	RollbackSegmentNamespace tree.Namespace = 3
+	StatisticsTableNamespace tree.Namespace = 4
 	MinTransientNamespace    tree.Namespace = math.MaxInt64 - 1<<24
 	MaxTransientNamespace    tree.Namespace = math.MaxInt64
 )
@@ -51,6 +55,10 @@ type Catalog struct {
 	CatalogTable *CatalogStore
 	Locks        *lock.LockManager
 
+	// protects the state of the sequences,
+	// which are shared by concurrent write transactions.
+	sequencesMu sync.Mutex
+
 	TransientNamespaces *atomic.Counter
 }
 
@@ -142,6 +150,42 @@ func (c *Catalog) LockTable(tx *Transaction, tableName string, mode lock.LockMod
 	return nil
 }
 
+// LockDocument acquires a lock on a document of a table, or on an entry
+// of a unique index, identified by its encoded key. The lock is held until the end
+// of the transaction.
+// Writes use an exclusive lock, while a shared lock only prevents other transactions
+// from modifying the document.
+// It returns a conflict error if waiting for the lock would cause a deadlock
+// or if the document was modified by a transaction that committed after tx started.
+func (c *Catalog) LockDocument(tx *Transaction, name string, key []byte, mode lock.LockMode) error {
+	obj := lock.NewDocumentObject(name, key)
+	if c.Locks.HasLock(tx.ID, obj, lock.X) || c.Locks.HasLock(tx.ID, obj, mode) {
+		return nil
+	}
+
+	ok, err := c.Locks.Lock(context.Background(), tx.ID, obj, mode)
+	if !ok || err != nil {
+		return errors.Wrapf(err, "failed to lock document of %s", name)
+	}
+
+	fn := func() {
+		c.Locks.Unlock(tx.ID, obj)
+	}
+	tx.OnRollbackHooks = append(tx.OnRollbackHooks, fn)
+	tx.OnCommitHooks = append(tx.OnCommitHooks, fn)
+
+	if mode != lock.X {
+		return nil
+	}
+
+	tx.writeSet = append(tx.writeSet, *obj)
+	if tx.writes != nil {
+		return tx.writes.check(tx, obj)
+	}
+
+	return nil
+}
+
 func (c *Catalog) GetTable(tx *Transaction, tableName string) (*Table, error) {
 	err := c.LockTable(tx, tableName, lock.S)
 	if err != nil {
@@ -205,6 +249,17 @@ func (c *Catalog) CreateTable(tx *Transaction, tableName string, info *TableInfo
 		}
 	}
 
+	for _, tc := range info.TableConstraints {
+		if tc.ForeignKey == nil {
+			continue
+		}
+
+		err = c.resolveForeignKey(tx, info, tc)
+		if err != nil {
+			return err
+		}
+	}
+
 	if len(info.FieldConstraints.Ordered) != 0 {
 		// bind default values with catalog
 		for _, fc := range info.FieldConstraints.Ordered {
@@ -241,6 +296,12 @@ func (c *Catalog) DropTable(tx *Transaction, tableName string) error {
 		return errors.New("cannot write to read-only table")
 	}
 
+	for _, ref := range c.GetTableReferences(tableName) {
+		if ref.TableName != tableName {
+			return errors.Errorf("cannot drop table %q: it is referenced by foreign key %q of table %q", tableName, ref.Constraint.Name, ref.TableName)
+		}
+	}
+
 	for _, idx := range c.Cache.GetTableIndexes(tableName) {
 		_, err = c.Cache.Delete(tx, RelationIndexType, idx.IndexName)
 		if err != nil {
@@ -253,6 +314,18 @@ func (c *Catalog) DropTable(tx *Transaction, tableName string) error {
 		}
 	}
 
+	for _, trigger := range c.Cache.GetTableTriggers(tableName) {
+		err = c.DropTrigger(tx, trigger.TriggerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.dropStatistics(tx, tableName)
+	if err != nil {
+		return err
+	}
+
 	_, err = c.Cache.Delete(tx, RelationTableType, tableName)
 	if err != nil {
 		return err
@@ -286,6 +359,18 @@ func (c *Catalog) CreateIndex(tx *Transaction, info *IndexInfo) error {
 		if fc == nil {
 			return errors.Errorf("field %q does not exist for table %q", p, ti.TableName)
 		}
+
+		if info.Multikey && fc.Type != 0 && fc.Type != types.ArrayValue {
+			return errors.Errorf("cannot create a multikey index on field %q of type %s", p, fc.Type)
+		}
+	}
+
+	if info.Multikey && len(info.Paths) > 1 {
+		return errors.Errorf("cannot create a multikey index on (%s): composite multikey indexes are not supported", document.Paths(info.Paths))
+	}
+
+	if info.Predicate != nil {
+		info.Predicate.Bind(c)
 	}
 
 	info.StoreNamespace, err = c.generateStoreName(tx)
@@ -363,6 +448,11 @@ func (c *Catalog) DropIndex(tx *Transaction, name string) error {
 		return err
 	}
 
+	err = c.dropIndexStatistics(tx, info)
+	if err != nil {
+		return err
+	}
+
 	return c.dropIndex(tx, info)
 }
 
@@ -375,7 +465,8 @@ func (c *Catalog) dropIndex(tx *Transaction, info *IndexInfo) error {
 	return c.CatalogTable.Delete(tx, info.IndexName)
 }
 
-// AddFieldConstraint adds a field constraint to a table.
+// AddFieldConstraint adds a field constraint and table constraints to a table.
+// If fc is not nil, the existing documents are rewritten to include the new field.
 func (c *Catalog) AddFieldConstraint(tx *Transaction, tableName string, fc *FieldConstraint, tcs TableConstraints) error {
 	err := c.LockTable(tx, tableName, lock.X)
 	if err != nil {
@@ -401,6 +492,297 @@ func (c *Catalog) AddFieldConstraint(tx *Transaction, tableName string, fc *Fiel
 		if err != nil {
 			return err
 		}
+
+		if tc.ForeignKey != nil {
+			err = c.resolveForeignKey(tx, clone, tc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	err = c.CatalogTable.Replace(tx, tableName, cloneRel)
+	if err != nil || fc == nil {
+		return err
+	}
+
+	// existing documents must be encoded with the new field
+	return c.reencodeTable(tx, ti, clone, nil)
+}
+
+// AddPrimaryKey adds a PRIMARY KEY constraint to a table that doesn't have one
+// and rewrites all its documents under the new key.
+// It fails if a document is missing one of the key fields, has a NULL value for one of them,
+// or has the same key as another document.
+// The indexes of the table reference the old keys and must be rebuilt by the caller.
+func (c *Catalog) AddPrimaryKey(tx *Transaction, tableName string, tc *TableConstraint) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	if ti.ReadOnly {
+		return errors.New("cannot write to read-only table")
+	}
+
+	// copy the constraints of the key fields before they are made NOT NULL
+	clone := ti.Clone()
+	for _, p := range tc.Paths {
+		if clone.GetFieldConstraintForPath(p) == nil {
+			continue
+		}
+
+		clone.FieldConstraints, err = clone.FieldConstraints.alter(p, func(fc *FieldConstraint) *FieldConstraint {
+			return fc
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = clone.AddTableConstraint(tc)
+	if err != nil {
+		return err
+	}
+
+	seqName := clone.DocidSequenceName
+	clone.DocidSequenceName = ""
+
+	d, err := c.rewriteTable(tx, ti, clone)
+	if err != nil {
+		if d != nil {
+			return errors.Wrapf(err, "cannot add constraint %q on document %s", tc.Name, types.NewDocumentValue(d))
+		}
+		return err
+	}
+
+	if seqName != "" {
+		err = c.DropSequence(tx, seqName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// statistics are keyed by the primary key
+	return c.dropStatistics(tx, tableName)
+}
+
+// rewriteTable moves all the documents of the table described by ti
+// to a new store, generating their keys using the configuration of clone,
+// then replaces ti by clone in the catalog.
+// If a document cannot be inserted, it is returned alongside the error.
+func (c *Catalog) rewriteTable(tx *Transaction, ti, clone *TableInfo) (types.Document, error) {
+	var err error
+	clone.StoreNamespace, err = c.generateStoreName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.CatalogTable.Replace(tx, ti.TableName, cloneRel)
+	if err != nil {
+		return nil, err
+	}
+
+	src := Table{Tx: tx, Tree: tree.New(tx.Session, ti.StoreNamespace), Info: ti, Catalog: c}
+	dst := Table{Tx: tx, Tree: tree.New(tx.Session, clone.StoreNamespace), Info: clone, Catalog: c}
+
+	var failed types.Document
+	err = src.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		// copy the document to encode it using the new field constraints
+		fb := document.NewFieldBuffer()
+		err := fb.Copy(d)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = dst.Insert(fb)
+		if err != nil {
+			failed = fb
+		}
+		return err
+	})
+	if err != nil {
+		return failed, err
+	}
+
+	return nil, src.Truncate()
+}
+
+// DropTableConstraint removes a table constraint from a table.
+// If the constraint is a UNIQUE constraint, the index created for it is dropped as well.
+// If it is the PRIMARY KEY, the documents are rewritten using a docid
+// and the indexes of the table must be rebuilt by the caller.
+func (c *Catalog) DropTableConstraint(tx *Transaction, tableName string, name string) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	clone := ti.Clone()
+	clone.TableConstraints = nil
+
+	var dropped *TableConstraint
+	for _, tc := range ti.TableConstraints {
+		if tc.Name == name {
+			dropped = tc
+			continue
+		}
+		clone.TableConstraints = append(clone.TableConstraints, tc)
+	}
+
+	if dropped == nil {
+		return errors.Errorf("constraint %q does not exist for table %q", name, tableName)
+	}
+	if dropped.Unique || dropped.PrimaryKey {
+		for _, ref := range c.GetTableReferences(tableName) {
+			if ref.Constraint.ForeignKey.Paths.IsEqual(dropped.Paths) {
+				return errors.Errorf("cannot drop constraint %q: it is referenced by foreign key %q of table %q", name, ref.Constraint.Name, ref.TableName)
+			}
+		}
+	}
+
+	if dropped.PrimaryKey {
+		if ti.ReadOnly {
+			return errors.New("cannot write to read-only table")
+		}
+
+		// the documents are now identified by a docid
+		seq := SequenceInfo{
+			IncrementBy: 1,
+			Min:         1, Max: math.MaxInt64,
+			Start: 1,
+			Cache: 64,
+			Owner: Owner{
+				TableName: tableName,
+			},
+		}
+		err = c.CreateSequence(tx, &seq)
+		if err != nil {
+			return err
+		}
+		clone.DocidSequenceName = seq.Name
+
+		_, err = c.rewriteTable(tx, ti, clone)
+		if err != nil {
+			return err
+		}
+
+		// statistics are keyed by the primary key
+		return c.dropStatistics(tx, tableName)
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	err = c.CatalogTable.Replace(tx, tableName, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	if !dropped.Unique {
+		return nil
+	}
+
+	for _, idx := range c.Cache.GetTableIndexes(tableName) {
+		if !idx.Owner.Paths.IsEqual(dropped.Paths) {
+			continue
+		}
+
+		_, err = c.Cache.Delete(tx, RelationIndexType, idx.IndexName)
+		if err != nil {
+			return err
+		}
+
+		err = c.dropIndexStatistics(tx, idx)
+		if err != nil {
+			return err
+		}
+
+		return c.dropIndex(tx, idx)
+	}
+
+	return nil
+}
+
+// DropFieldConstraint removes the constraint of the field at the given path
+// and removes that field from all the documents of the table.
+func (c *Catalog) DropFieldConstraint(tx *Transaction, tableName string, path document.Path) error {
+	return c.alterFieldConstraint(tx, tableName, path, func(fc *FieldConstraint) *FieldConstraint {
+		return nil
+	}, func(fb *document.FieldBuffer) error {
+		err := fb.Delete(path)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+// AlterFieldConstraintType changes the type of the field at the given path
+// and converts the values of that field in all the documents of the table.
+func (c *Catalog) AlterFieldConstraintType(tx *Transaction, tableName string, path document.Path, tp types.ValueType) error {
+	return c.alterFieldConstraint(tx, tableName, path, func(fc *FieldConstraint) *FieldConstraint {
+		fc.Type = tp
+		fc.AnonymousType = nil
+		if tp == types.DocumentValue {
+			fc.AnonymousType = &AnonymousType{
+				FieldConstraints: FieldConstraints{AllowExtraFields: true},
+			}
+		}
+		return fc
+	}, nil)
+}
+
+// alterFieldConstraint replaces the constraint of the field at the given path by the one returned by alterFn,
+// or removes it if alterFn returns nil, then rewrites all the documents of the table.
+// Each document is passed to rewriteFn, if not nil, before being encoded using the new field constraints.
+func (c *Catalog) alterFieldConstraint(tx *Transaction, tableName string, path document.Path, alterFn func(fc *FieldConstraint) *FieldConstraint, rewriteFn func(fb *document.FieldBuffer) error) error {
+	err := c.LockTable(tx, tableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	if ti.GetFieldConstraintForPath(path) == nil {
+		return errors.Errorf("field %q does not exist for table %q", path, tableName)
+	}
+
+	clone := ti.Clone()
+	clone.FieldConstraints, err = ti.FieldConstraints.alter(path, alterFn)
+	if err != nil {
+		return err
 	}
 
 	cloneRel := &TableInfoRelation{Info: clone}
@@ -409,7 +791,39 @@ func (c *Catalog) AddFieldConstraint(tx *Transaction, tableName string, fc *Fiel
 		return err
 	}
 
-	return c.CatalogTable.Replace(tx, tableName, cloneRel)
+	err = c.CatalogTable.Replace(tx, tableName, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	return c.reencodeTable(tx, ti, clone, rewriteFn)
+}
+
+// reencodeTable rewrites all the documents of the table in place.
+// Documents are encoded differently depending on the field constraints:
+// they are decoded using the ones of ti and encoded using the ones of clone.
+// Each document is passed to rewriteFn, if not nil, before being encoded.
+func (c *Catalog) reencodeTable(tx *Transaction, ti, clone *TableInfo, rewriteFn func(fb *document.FieldBuffer) error) error {
+	src := Table{Tx: tx, Tree: tree.New(tx.Session, ti.StoreNamespace), Info: ti, Catalog: c}
+	dst := Table{Tx: tx, Tree: src.Tree, Info: clone, Catalog: c}
+
+	return src.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		fb := document.NewFieldBuffer()
+		err := fb.Copy(d)
+		if err != nil {
+			return err
+		}
+
+		if rewriteFn != nil {
+			err = rewriteFn(fb)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = dst.Replace(key, fb)
+		return err
+	})
 }
 
 // RenameTable renames a table.
@@ -474,6 +888,60 @@ func (c *Catalog) RenameTable(tx *Transaction, oldName, newName string) error {
 		}
 	}
 
+	for _, trigger := range c.Cache.GetTableTriggers(oldName) {
+		_, err := c.Cache.Delete(tx, RelationTriggerType, trigger.TriggerName)
+		if err != nil {
+			return err
+		}
+
+		triggerClone := trigger.Clone()
+		triggerClone.TableName = clone.TableName
+
+		cloneRel := &TriggerInfoRelation{Info: triggerClone}
+		err = c.Cache.Add(tx, cloneRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, trigger.TriggerName, cloneRel)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update the foreign keys referencing the table,
+	// including those of the renamed table itself
+	for _, ref := range c.GetTableReferences(oldName) {
+		r, err := c.Cache.Get(RelationTableType, ref.TableName)
+		if err != nil {
+			return err
+		}
+
+		refClone := r.(*TableInfoRelation).Info.Clone()
+		for i, tc := range refClone.TableConstraints {
+			if tc.ForeignKey == nil || tc.ForeignKey.Table != oldName {
+				continue
+			}
+
+			tcClone := *tc
+			fkClone := *tc.ForeignKey
+			fkClone.Table = newName
+			tcClone.ForeignKey = &fkClone
+			refClone.TableConstraints[i] = &tcClone
+		}
+
+		refRel := &TableInfoRelation{Info: refClone}
+		err = c.Cache.Replace(tx, refRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, refClone.TableName, refRel)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, seqName := range c.ListSequences() {
 		seq, err := c.GetSequence(seqName)
 		if err != nil {
@@ -502,7 +970,7 @@ func (c *Catalog) RenameTable(tx *Transaction, oldName, newName string) error {
 		}
 	}
 
-	return nil
+	return c.renameStatistics(tx, oldName, newName)
 }
 
 func (c *Catalog) GetSequence(name string) (*Sequence, error) {
@@ -562,6 +1030,108 @@ func (c *Catalog) ListSequences() []string {
 	return c.Cache.ListObjects(RelationSequenceType)
 }
 
+// GetViewInfo returns the view information of the given view.
+func (c *Catalog) GetViewInfo(name string) (*ViewInfo, error) {
+	r, err := c.Cache.Get(RelationViewType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*ViewInfoRelation).Info, nil
+}
+
+// CreateView creates a view with the given name.
+func (c *Catalog) CreateView(tx *Transaction, info *ViewInfo) error {
+	if info.ViewName == "" {
+		return errors.New("view name not provided")
+	}
+
+	rel := ViewInfoRelation{Info: info}
+	err := c.Cache.Add(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Insert(tx, &rel)
+}
+
+// DropView deletes a view from the catalog.
+func (c *Catalog) DropView(tx *Transaction, name string) error {
+	_, err := c.Cache.Delete(tx, RelationViewType, name)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, name)
+}
+
+// ListViews returns all view names sorted lexicographically.
+func (c *Catalog) ListViews() []string {
+	return c.Cache.ListObjects(RelationViewType)
+}
+
+// GetTriggerInfo returns the trigger information of the given trigger.
+func (c *Catalog) GetTriggerInfo(name string) (*TriggerInfo, error) {
+	r, err := c.Cache.Get(RelationTriggerType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*TriggerInfoRelation).Info, nil
+}
+
+// CreateTrigger creates a trigger with the given name.
+func (c *Catalog) CreateTrigger(tx *Transaction, info *TriggerInfo) error {
+	if info.TriggerName == "" {
+		return errors.New("trigger name not provided")
+	}
+
+	ti, err := c.GetTableInfo(info.TableName)
+	if err != nil {
+		return err
+	}
+
+	if ti.ReadOnly {
+		return errors.New("cannot create a trigger on a read-only table")
+	}
+
+	rel := TriggerInfoRelation{Info: info}
+	err = c.Cache.Add(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Insert(tx, &rel)
+}
+
+// DropTrigger deletes a trigger from the catalog.
+func (c *Catalog) DropTrigger(tx *Transaction, name string) error {
+	_, err := c.Cache.Delete(tx, RelationTriggerType, name)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, name)
+}
+
+// ListTriggers returns all trigger names sorted lexicographically.
+func (c *Catalog) ListTriggers() []string {
+	return c.Cache.ListObjects(RelationTriggerType)
+}
+
+// GetTableTriggers returns the triggers of the given table that
+// run at the given time for the given event, sorted by name.
+func (c *Catalog) GetTableTriggers(tableName string, timing TriggerTiming, event TriggerEvent) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, t := range c.Cache.GetTableTriggers(tableName) {
+		if t.Timing == timing && t.Event == event {
+			triggers = append(triggers, t)
+		}
+	}
+
+	return triggers
+}
+
 // GetFreeTransientNamespace returns the next available transient namespace.
 // Transient namespaces start from math.MaxInt64 - (2 << 24) to math.MaxInt64 (around 16 M).
 // The transient namespaces counter is not persisted and resets when the database is restarted.
@@ -620,6 +1190,46 @@ func (r *IndexInfoRelation) GenerateBaseName() string {
 	return fmt.Sprintf("%s_%s_idx", r.Info.Owner.TableName, pathsToIndexName(r.Info.Paths))
 }
 
+type ViewInfoRelation struct {
+	Info *ViewInfo
+}
+
+func (r *ViewInfoRelation) Type() string {
+	return RelationViewType
+}
+
+func (r *ViewInfoRelation) Name() string {
+	return r.Info.ViewName
+}
+
+func (r *ViewInfoRelation) SetName(name string) {
+	r.Info.ViewName = name
+}
+
+func (r *ViewInfoRelation) GenerateBaseName() string {
+	return r.Info.ViewName
+}
+
+type TriggerInfoRelation struct {
+	Info *TriggerInfo
+}
+
+func (r *TriggerInfoRelation) Type() string {
+	return RelationTriggerType
+}
+
+func (r *TriggerInfoRelation) Name() string {
+	return r.Info.TriggerName
+}
+
+func (r *TriggerInfoRelation) SetName(name string) {
+	r.Info.TriggerName = name
+}
+
+func (r *TriggerInfoRelation) GenerateBaseName() string {
+	return r.Info.TriggerName
+}
+
 func pathsToIndexName(paths []document.Path) string {
 	var s strings.Builder
 
@@ -634,10 +1244,19 @@ func pathsToIndexName(paths []document.Path) string {
 	return s.String()
 }
 
+// catalogCache is shared by all the transactions.
+// Its maps are protected by mu.
 type catalogCache struct {
+	mu sync.RWMutex
+
 	tables    map[string]Relation
 	indexes   map[string]Relation
 	sequences map[string]Relation
+	views     map[string]Relation
+	triggers  map[string]Relation
+
+	// statistics collected by ANALYZE, per table.
+	stats map[string][]*Statistics
 }
 
 func newCatalogCache() *catalogCache {
@@ -645,10 +1264,16 @@ func newCatalogCache() *catalogCache {
 		tables:    make(map[string]Relation),
 		indexes:   make(map[string]Relation),
 		sequences: make(map[string]Relation),
+		views:     make(map[string]Relation),
+		triggers:  make(map[string]Relation),
+		stats:     make(map[string][]*Statistics),
 	}
 }
 
-func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence) {
+func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence, views []ViewInfo, triggers []TriggerInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	for i := range tables {
 		c.tables[tables[i].TableName] = &TableInfoRelation{Info: &tables[i]}
 	}
@@ -660,10 +1285,21 @@ func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences [
 	for i := range sequences {
 		c.sequences[sequences[i].Info.Name] = &sequences[i]
 	}
+
+	for i := range views {
+		c.views[views[i].ViewName] = &ViewInfoRelation{Info: &views[i]}
+	}
+
+	for i := range triggers {
+		c.triggers[triggers[i].TriggerName] = &TriggerInfoRelation{Info: &triggers[i]}
+	}
 }
 
 // TODO put in tests
 func (c *catalogCache) Clone() *catalogCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	clone := newCatalogCache()
 
 	for k, v := range c.tables {
@@ -675,6 +1311,15 @@ func (c *catalogCache) Clone() *catalogCache {
 	for k, v := range c.sequences {
 		clone.sequences[k] = v
 	}
+	for k, v := range c.views {
+		clone.views[k] = v
+	}
+	for k, v := range c.triggers {
+		clone.triggers[k] = v
+	}
+	for k, v := range c.stats {
+		clone.stats[k] = v
+	}
 
 	return clone
 }
@@ -695,6 +1340,16 @@ func (c *catalogCache) objectExists(name string) bool {
 		return true
 	}
 
+	// checking if view exists with the same name
+	if _, ok := c.views[name]; ok {
+		return true
+	}
+
+	// checking if trigger exists with the same name
+	if _, ok := c.triggers[name]; ok {
+		return true
+	}
+
 	return false
 }
 
@@ -721,12 +1376,19 @@ func (c *catalogCache) getMapByType(tp string) map[string]Relation {
 		return c.indexes
 	case RelationSequenceType:
 		return c.sequences
+	case RelationViewType:
+		return c.views
+	case RelationTriggerType:
+		return c.triggers
 	}
 
 	panic(fmt.Sprintf("unknown catalog object type %q", tp))
 }
 
 func (c *catalogCache) Add(tx *Transaction, o Relation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	name := o.Name()
 
 	// if name is provided, ensure it's not duplicated
@@ -744,13 +1406,18 @@ func (c *catalogCache) Add(tx *Transaction, o Relation) error {
 	m[name] = o
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
+		c.mu.Lock()
 		delete(m, name)
+		c.mu.Unlock()
 	})
 
 	return nil
 }
 
 func (c *catalogCache) Replace(tx *Transaction, o Relation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	m := c.getMapByType(o.Type())
 
 	old, ok := m[o.Name()]
@@ -761,13 +1428,18 @@ func (c *catalogCache) Replace(tx *Transaction, o Relation) error {
 	m[o.Name()] = o
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
+		c.mu.Lock()
 		m[o.Name()] = old
+		c.mu.Unlock()
 	})
 
 	return nil
 }
 
 func (c *catalogCache) Delete(tx *Transaction, tp, name string) (Relation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	m := c.getMapByType(tp)
 
 	o, ok := m[name]
@@ -778,13 +1450,18 @@ func (c *catalogCache) Delete(tx *Transaction, tp, name string) (Relation, error
 	delete(m, name)
 
 	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
+		c.mu.Lock()
 		m[name] = o
+		c.mu.Unlock()
 	})
 
 	return o, nil
 }
 
 func (c *catalogCache) Get(tp, name string) (Relation, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	m := c.getMapByType(tp)
 
 	o, ok := m[name]
@@ -796,6 +1473,9 @@ func (c *catalogCache) Get(tp, name string) (Relation, error) {
 }
 
 func (c *catalogCache) ListObjects(tp string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	m := c.getMapByType(tp)
 
 	list := make([]string, 0, len(m))
@@ -808,6 +1488,9 @@ func (c *catalogCache) ListObjects(tp string) []string {
 }
 
 func (c *catalogCache) GetTableIndexes(tableName string) []*IndexInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	var indexes []*IndexInfo
 	for _, o := range c.indexes {
 		idx := o.(*IndexInfoRelation).Info
@@ -820,6 +1503,27 @@ func (c *catalogCache) GetTableIndexes(tableName string) []*IndexInfo {
 	return indexes
 }
 
+// GetTableTriggers returns the triggers of the given table, sorted by name.
+func (c *catalogCache) GetTableTriggers(tableName string) []*TriggerInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var triggers []*TriggerInfo
+	for _, o := range c.triggers {
+		t := o.(*TriggerInfoRelation).Info
+		if t.TableName != tableName {
+			continue
+		}
+		triggers = append(triggers, t)
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].TriggerName < triggers[j].TriggerName
+	})
+
+	return triggers
+}
+
 type CatalogStore struct {
 	Catalog *Catalog
 	info    *TableInfo
@@ -942,6 +1646,10 @@ func relationToDocument(r Relation) types.Document {
 		return indexInfoToDocument(t.Info)
 	case *Sequence:
 		return sequenceInfoToDocument(t.Info)
+	case *ViewInfoRelation:
+		return viewInfoToDocument(t.Info)
+	case *TriggerInfoRelation:
+		return triggerInfoToDocument(t.Info)
 	}
 
 	panic(fmt.Sprintf("objectToDocument: unknown type %q", r.Type()))
@@ -986,6 +1694,25 @@ func sequenceInfoToDocument(seq *SequenceInfo) types.Document {
 	return buf
 }
 
+func viewInfoToDocument(v *ViewInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(v.ViewName))
+	buf.Add("type", types.NewTextValue(RelationViewType))
+	buf.Add("sql", types.NewTextValue(v.String()))
+
+	return buf
+}
+
+func triggerInfoToDocument(t *TriggerInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(t.TriggerName))
+	buf.Add("type", types.NewTextValue(RelationTriggerType))
+	buf.Add("sql", types.NewTextValue(t.String()))
+	buf.Add("owner", types.NewDocumentValue(ownerToDocument(&Owner{TableName: t.TableName})))
+
+	return buf
+}
+
 func ownerToDocument(owner *Owner) types.Document {
 	buf := document.NewFieldBuffer().Add("table_name", types.NewTextValue(owner.TableName))
 	if owner.Paths != nil {
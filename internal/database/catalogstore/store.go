@@ -4,7 +4,10 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/encoding"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/tree"
@@ -19,7 +22,7 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 		return nil, err
 	}
 
-	tables, indexes, sequences, err := loadCatalogStore(tx, c.CatalogTable)
+	tables, indexes, sequences, views, triggers, err := loadCatalogStore(tx, c.CatalogTable)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load catalog store")
 	}
@@ -35,6 +38,13 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 		}
 	}
 
+	// bind the predicates of partial indexes with catalog
+	for i := range indexes {
+		if indexes[i].Predicate != nil {
+			indexes[i].Predicate.Bind(c)
+		}
+	}
+
 	// add the __genji_catalog table to the list of tables
 	// so that it can be queried
 	ti := c.CatalogTable.Info().Clone()
@@ -42,8 +52,8 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 	ti.ReadOnly = true
 	tables = append(tables, *ti)
 
-	// load tables and indexes first
-	c.Cache.Load(tables, indexes, nil)
+	// load tables, indexes, views and triggers first
+	c.Cache.Load(tables, indexes, nil, views, triggers)
 
 	if len(sequences) > 0 {
 		var seqList []database.Sequence
@@ -52,12 +62,41 @@ func LoadCatalog(tx *database.Transaction) (*database.Catalog, error) {
 			return nil, errors.Wrap(err, "failed to load sequences")
 		}
 
-		c.Cache.Load(nil, nil, seqList)
+		c.Cache.Load(nil, nil, seqList, nil, nil)
+	}
+
+	stats, err := loadStatistics(tx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load statistics")
 	}
+	c.LoadStatistics(stats)
 
 	return c, nil
 }
 
+func loadStatistics(tx *database.Transaction, c *database.Catalog) ([]*database.Statistics, error) {
+	tb, err := c.GetTable(tx, database.StatisticsTableName)
+	if errs.IsNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []*database.Statistics
+	err = tb.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+		s, err := statisticsFromDocument(d)
+		if err != nil {
+			return err
+		}
+
+		stats = append(stats, s)
+		return nil
+	})
+
+	return stats, err
+}
+
 func loadSequences(tx *database.Transaction, c *database.Catalog, info []database.SequenceInfo) ([]database.Sequence, error) {
 	tb, err := c.GetTable(tx, database.SequenceTableName)
 	if err != nil {
@@ -89,7 +128,7 @@ func loadSequences(tx *database.Transaction, c *database.Catalog, info []databas
 	return sequences, nil
 }
 
-func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, err error) {
+func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, views []database.ViewInfo, triggers []database.TriggerInfo, err error) {
 	tb := s.Table(tx)
 
 	err = tb.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
@@ -118,6 +157,18 @@ func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (table
 				return errors.Wrap(err, "failed to decode sequence info")
 			}
 			sequences = append(sequences, *i)
+		case database.RelationViewType:
+			v, err := viewInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode view info")
+			}
+			views = append(views, *v)
+		case database.RelationTriggerType:
+			t, err := triggerInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode trigger info")
+			}
+			triggers = append(triggers, *t)
 		}
 
 		return nil
@@ -228,6 +279,36 @@ func sequenceInfoFromDocument(d types.Document) (*database.SequenceInfo, error)
 	return &i, nil
 }
 
+func viewInfoFromDocument(d types.Document) (*database.ViewInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	v := stmt.(*statement.CreateViewStmt).Info
+	return &v, nil
+}
+
+func triggerInfoFromDocument(d types.Document) (*database.TriggerInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	t := stmt.(*statement.CreateTriggerStmt).Info
+	return &t, nil
+}
+
 func ownerFromDocument(d types.Document) (*database.Owner, error) {
 	var owner database.Owner
 
@@ -259,3 +340,109 @@ func ownerFromDocument(d types.Document) (*database.Owner, error) {
 
 	return &owner, nil
 }
+
+func statisticsFromDocument(d types.Document) (*database.Statistics, error) {
+	var s database.Statistics
+
+	v, err := d.GetByField("table_name")
+	if err != nil {
+		return nil, err
+	}
+	s.TableName = types.As[string](v)
+
+	v, err = d.GetByField("row_count")
+	if err != nil {
+		return nil, err
+	}
+	s.RowCount, err = statisticsCount(v)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err = d.GetByField("paths")
+	if err != nil {
+		return nil, err
+	}
+	err = types.As[types.Array](v).Iterate(func(i int, value types.Value) error {
+		pp, err := parser.ParsePath(types.As[string](value))
+		if err != nil {
+			return err
+		}
+
+		s.Paths = append(s.Paths, pp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v, err = d.GetByField("distinct_counts")
+	if err != nil {
+		return nil, err
+	}
+	err = types.As[types.Array](v).Iterate(func(i int, value types.Value) error {
+		n, err := statisticsCount(value)
+		if err != nil {
+			return err
+		}
+
+		s.DistinctCounts = append(s.DistinctCounts, n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v, err = d.GetByField("histogram")
+	if err != nil {
+		return nil, err
+	}
+	err = types.As[types.Array](v).Iterate(func(i int, value types.Value) error {
+		var b database.HistogramBucket
+
+		bd := types.As[types.Document](value)
+		upper, err := bd.GetByField("upper")
+		if err != nil {
+			return err
+		}
+		// the upper bound is stored encoded, to preserve its type
+		b.Upper, _ = encoding.DecodeValue(append([]byte(nil), types.As[[]byte](upper)...), false)
+
+		count, err := bd.GetByField("count")
+		if err != nil {
+			return err
+		}
+		b.Count, err = statisticsCount(count)
+		if err != nil {
+			return err
+		}
+
+		distinct, err := bd.GetByField("distinct")
+		if err != nil {
+			return err
+		}
+		b.Distinct, err = statisticsCount(distinct)
+		if err != nil {
+			return err
+		}
+
+		s.Histogram = append(s.Histogram, b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// statisticsCount returns the value of a count stored in the statistics table.
+// Numbers nested in arrays are decoded as doubles.
+func statisticsCount(v types.Value) (int64, error) {
+	v, err := document.CastAsInteger(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return types.As[int64](v), nil
+}
@@ -0,0 +1,245 @@
+package database
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// ErrSubscriptionClosed is returned when reading from a closed subscription.
+var ErrSubscriptionClosed = errors.New("subscription closed")
+
+// ChangeType is the kind of write described by a change.
+type ChangeType int
+
+const (
+	// ChangeInsert describes an inserted document.
+	ChangeInsert ChangeType = iota + 1
+
+	// ChangeReplace describes a replaced document.
+	ChangeReplace
+
+	// ChangeDelete describes a deleted document.
+	ChangeDelete
+)
+
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeInsert:
+		return "insert"
+	case ChangeReplace:
+		return "replace"
+	case ChangeDelete:
+		return "delete"
+	}
+
+	return ""
+}
+
+// A Change describes a document written by a transaction.
+type Change struct {
+	Type      ChangeType
+	TableName string
+	Key       *tree.Key
+	// Document is the inserted document, the new version of a replaced document
+	// or the deleted document.
+	Document types.Document
+	// Ops are the operations transforming the old version of a replaced document
+	// into the new one.
+	Ops []document.Op
+}
+
+// A ChangeSet holds the changes of a committed transaction,
+// in the order they were made.
+type ChangeSet struct {
+	// Sequence is the commit sequence number of the transaction.
+	Sequence uint64
+	Changes  []Change
+}
+
+// A changeFeed numbers the commits of write transactions
+// and delivers their changes to the subscribers.
+type changeFeed struct {
+	mu sync.Mutex
+
+	// incremented every time a write transaction commits.
+	sequence    uint64
+	subscribers map[*Subscriber]struct{}
+}
+
+func newChangeFeed() *changeFeed {
+	return &changeFeed{
+		subscribers: make(map[*Subscriber]struct{}),
+	}
+}
+
+// capturing returns whether the changes of new transactions must be recorded.
+func (f *changeFeed) capturing() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.subscribers) > 0
+}
+
+// Sequence returns the commit sequence number of the last committed write transaction.
+func (f *changeFeed) Sequence() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.sequence
+}
+
+// commit commits the transaction using commitFn, then assigns it a sequence number
+// and delivers its changes to the subscribers.
+// Transactions are committed one at a time, so that the sequence numbers
+// and the order of delivery match the order in which they were committed.
+func (f *changeFeed) commit(tx *Transaction, commitFn func() error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := commitFn()
+	if err != nil {
+		return err
+	}
+
+	f.sequence++
+
+	if len(tx.changes) == 0 {
+		return nil
+	}
+
+	for s := range f.subscribers {
+		cs := ChangeSet{Sequence: f.sequence}
+		for _, c := range tx.changes {
+			if s.tables == nil || s.tables[c.TableName] {
+				cs.Changes = append(cs.Changes, c)
+			}
+		}
+
+		if len(cs.Changes) > 0 {
+			s.push(&cs)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a subscriber receiving the changes made to the given tables
+// by the transactions started after the subscription, once they are committed.
+// If no table is given, it receives the changes made to all the tables.
+func (f *changeFeed) Subscribe(tables ...string) *Subscriber {
+	s := Subscriber{
+		feed:   f,
+		notify: make(chan struct{}, 1),
+	}
+
+	if len(tables) > 0 {
+		s.tables = make(map[string]bool)
+		for _, t := range tables {
+			s.tables[t] = true
+		}
+	}
+
+	f.mu.Lock()
+	f.subscribers[&s] = struct{}{}
+	f.mu.Unlock()
+
+	return &s
+}
+
+// close closes all the subscribers.
+func (f *changeFeed) close() {
+	f.mu.Lock()
+	subscribers := f.subscribers
+	f.subscribers = make(map[*Subscriber]struct{})
+	f.mu.Unlock()
+
+	for s := range subscribers {
+		s.close()
+	}
+}
+
+// A Subscriber receives the changes of committed transactions.
+// Change sets are queued until they are read, so commits
+// are never blocked by slow subscribers.
+// The queue is unbounded: the change sets of a subscriber that
+// stops reading are kept in memory until it is closed.
+type Subscriber struct {
+	feed   *changeFeed
+	tables map[string]bool
+
+	mu     sync.Mutex
+	queue  []*ChangeSet
+	closed bool
+	notify chan struct{}
+}
+
+func (s *Subscriber) push(cs *ChangeSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.queue = append(s.queue, cs)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Next returns the next change set, waiting for a transaction to commit
+// if none is available. Change sets are returned in commit order.
+// It returns ErrSubscriptionClosed once the subscriber is closed
+// and all the queued change sets were read.
+func (s *Subscriber) Next(ctx context.Context) (*ChangeSet, error) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			cs := s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return cs, nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+
+		if closed {
+			return nil, errors.WithStack(ErrSubscriptionClosed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.notify:
+		}
+	}
+}
+
+// Close stops the subscription. The change sets already queued
+// can still be read.
+func (s *Subscriber) Close() error {
+	s.feed.mu.Lock()
+	delete(s.feed.subscribers, s)
+	s.feed.mu.Unlock()
+
+	s.close()
+	return nil
+}
+
+func (s *Subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
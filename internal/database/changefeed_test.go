@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFeedCommitOrder(t *testing.T) {
+	f := newChangeFeed()
+	s := f.Subscribe()
+	defer s.Close()
+
+	// committed is not protected: the feed must run the commits one at a time
+	var committed []*Transaction
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		tx := Transaction{changes: []Change{{
+			Type:      ChangeInsert,
+			TableName: "test",
+			Key:       tree.NewKey(types.NewIntegerValue(int64(i))),
+		}}}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := f.commit(&tx, func() error {
+				committed = append(committed, &tx)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// failed commits are neither numbered nor delivered
+	err := f.commit(&Transaction{changes: committed[0].changes}, func() error {
+		return errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+	require.Equal(t, uint64(len(committed)), f.Sequence())
+
+	// change sets are numbered and delivered in commit order
+	for i, tx := range committed {
+		cs, err := s.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), cs.Sequence)
+		require.Len(t, cs.Changes, 1)
+		require.Same(t, tx.changes[0].Key, cs.Changes[0].Key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Empty(t, s.queue)
+}
@@ -120,6 +120,53 @@ func (f *FieldConstraints) Add(newFc *FieldConstraint) error {
 	return nil
 }
 
+// alter returns a copy of the field constraints where the constraint of the field
+// at the given path is replaced by the one returned by fn, which receives a copy of it.
+// If fn returns nil, the constraint is removed.
+func (f FieldConstraints) alter(path document.Path, fn func(fc *FieldConstraint) *FieldConstraint) (FieldConstraints, error) {
+	fc, ok := f.ByField[path[0].FieldName]
+	if !ok || path[0].FieldName == "" {
+		return FieldConstraints{}, errors.Errorf("field %q does not exist", path)
+	}
+
+	newFc := *fc
+	altered := &newFc
+	if len(path) == 1 {
+		altered = fn(altered)
+	} else {
+		if fc.AnonymousType == nil {
+			return FieldConstraints{}, errors.Errorf("field %q does not exist", path)
+		}
+
+		nested, err := fc.AnonymousType.FieldConstraints.alter(path[1:], fn)
+		if err != nil {
+			return FieldConstraints{}, err
+		}
+		newFc.AnonymousType = &AnonymousType{FieldConstraints: nested}
+	}
+
+	cp := FieldConstraints{AllowExtraFields: f.AllowExtraFields}
+	for _, c := range f.Ordered {
+		if c.Field == fc.Field {
+			if altered == nil {
+				continue
+			}
+			c = altered
+		} else {
+			// copy the constraint to avoid modifying its position
+			c2 := *c
+			c = &c2
+		}
+
+		err := cp.Add(c)
+		if err != nil {
+			return FieldConstraints{}, err
+		}
+	}
+
+	return cp, nil
+}
+
 // ConversionFunc is called when the type of a value is different than the expected type
 // and the value needs to be converted.
 type ConversionFunc func(v types.Value, path document.Path, targetType types.ValueType) (types.Value, error)
@@ -238,6 +285,7 @@ type TableConstraint struct {
 	Check      TableExpression
 	Unique     bool
 	PrimaryKey bool
+	ForeignKey *ForeignKey
 }
 
 func (t *TableConstraint) String() string {
@@ -259,6 +307,63 @@ func (t *TableConstraint) String() string {
 		sb.WriteString(" UNIQUE (")
 		sb.WriteString(t.Paths.String())
 		sb.WriteString(")")
+	case t.ForeignKey != nil:
+		sb.WriteString(" FOREIGN KEY (")
+		sb.WriteString(t.Paths.String())
+		sb.WriteString(") ")
+		sb.WriteString(t.ForeignKey.String())
+	}
+
+	return sb.String()
+}
+
+// ForeignKeyAction is the action taken on the referencing documents
+// when a referenced document is deleted.
+type ForeignKeyAction int
+
+const (
+	// ForeignKeyRestrict prevents the deletion of referenced documents.
+	ForeignKeyRestrict ForeignKeyAction = iota
+
+	// ForeignKeyCascade deletes the referencing documents.
+	ForeignKeyCascade
+
+	// ForeignKeySetNull sets the referencing paths to NULL.
+	ForeignKeySetNull
+)
+
+func (a ForeignKeyAction) String() string {
+	switch a {
+	case ForeignKeyCascade:
+		return "CASCADE"
+	case ForeignKeySetNull:
+		return "SET NULL"
+	}
+
+	return "RESTRICT"
+}
+
+// A ForeignKey references the paths of a primary key or of a unique constraint
+// of another table, or of the same table.
+type ForeignKey struct {
+	Table    string
+	Paths    document.Paths
+	OnDelete ForeignKeyAction
+}
+
+func (f *ForeignKey) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("REFERENCES ")
+	sb.WriteString(stringutil.NormalizeIdentifier(f.Table, '`'))
+	if len(f.Paths) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(f.Paths.String())
+		sb.WriteString(")")
+	}
+	if f.OnDelete != ForeignKeyRestrict {
+		sb.WriteString(" ON DELETE ")
+		sb.WriteString(f.OnDelete.String())
 	}
 
 	return sb.String()
@@ -27,8 +27,20 @@ type Database struct {
 	attachedTransaction *Transaction
 	attachedTxMu        sync.Mutex
 
-	// This limits the number of write transactions to 1.
-	writetxmu *sync.Mutex
+	// Write transactions hold a read lock,
+	// closing the database waits for all of them to be closed.
+	writetxmu *sync.RWMutex
+
+	// detects documents modified by concurrent write transactions.
+	writes *writeTracker
+
+	// numbers the commits and delivers the changes to subscribers.
+	feed *changeFeed
+
+	// location of the database files,
+	// only set if the database was created by Open.
+	path string
+	fs   vfs.FS
 
 	// TransactionIDs is used to assign transaction an ID at runtime.
 	// Since transaction IDs are not persisted and not used for concurrent
@@ -81,7 +93,17 @@ func Open(path string, opts *Options) (*Database, error) {
 		return nil, err
 	}
 
-	return New(pdb, opts)
+	db, err := New(pdb, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// OpenPebble sets the file system used by the database,
+	// which is required to create backups.
+	db.path = path
+	db.fs = popts.FS
+
+	return db, nil
 }
 
 // Open a database with a custom comparer.
@@ -122,7 +144,8 @@ var DefaultComparer = &pebble.Comparer{
 func New(pdb *pebble.DB, opts *Options) (*Database, error) {
 	db := Database{
 		DB:        pdb,
-		writetxmu: &sync.Mutex{},
+		writetxmu: &sync.RWMutex{},
+		writes:    newWriteTracker(),
 		Store: kv.NewStore(pdb, kv.Options{
 			RollbackSegmentNamespace: int64(RollbackSegmentNamespace),
 		}),
@@ -165,6 +188,9 @@ func New(pdb *pebble.DB, opts *Options) (*Database, error) {
 		return nil, err
 	}
 
+	// the commit sequence starts after the initialization of the database
+	db.feed = newChangeFeed()
+
 	return &db, nil
 }
 
@@ -212,9 +238,25 @@ func (db *Database) closeDatabase() error {
 		return err
 	}
 
+	db.feed.close()
+
 	return db.DB.Close()
 }
 
+// Subscribe returns a subscriber receiving the changes made to the given tables
+// by write transactions, once they are committed.
+// If no table is given, it receives the changes made to all the tables.
+// Only the transactions started after the subscription are observed.
+func (db *Database) Subscribe(tables ...string) *Subscriber {
+	return db.feed.Subscribe(tables...)
+}
+
+// CommitSequence returns the sequence number of the last committed write transaction.
+// Sequence numbers start at zero every time the database is opened.
+func (db *Database) CommitSequence() uint64 {
+	return db.feed.Sequence()
+}
+
 // GetAttachedTx returns the transaction attached to the database. It returns nil if there is no
 // such transaction.
 // The returned transaction is not thread safe.
@@ -244,18 +286,28 @@ func (db *Database) BeginTx(opts *TxOptions) (*Transaction, error) {
 		opts = new(TxOptions)
 	}
 
-	if !opts.ReadOnly {
-		db.writetxmu.Lock()
+	var sess kv.Session
+	if opts.ReadOnly {
+		sess = db.Store.NewSnapshotSession()
+	} else {
+		db.writetxmu.RLock()
+		// creating a write session may wait for another write transaction
+		// to be closed, so it must be done before locking the attached transaction.
+		sess = db.Store.NewBatchSession()
 	}
 
 	db.attachedTxMu.Lock()
 	defer db.attachedTxMu.Unlock()
 
 	if db.attachedTransaction != nil {
+		_ = sess.Close()
+		if !opts.ReadOnly {
+			db.writetxmu.RUnlock()
+		}
 		return nil, errors.New("cannot open a transaction within a transaction")
 	}
 
-	return db.beginTx(opts)
+	return db.newTransaction(sess, opts), nil
 }
 
 // beginTx creates a transaction without locks.
@@ -271,6 +323,10 @@ func (db *Database) beginTx(opts *TxOptions) (*Transaction, error) {
 		sess = db.Store.NewBatchSession()
 	}
 
+	return db.newTransaction(sess, opts), nil
+}
+
+func (db *Database) newTransaction(sess kv.Session, opts *TxOptions) *Transaction {
 	tx := Transaction{
 		Store:    db.Store,
 		Session:  sess,
@@ -280,6 +336,12 @@ func (db *Database) beginTx(opts *TxOptions) (*Transaction, error) {
 
 	if !opts.ReadOnly {
 		tx.WriteTxMu = db.writetxmu
+		tx.writes = db.writes
+		db.writes.begin(&tx)
+		if db.feed != nil {
+			tx.feed = db.feed
+			tx.captureChanges = db.feed.capturing()
+		}
 	}
 
 	if opts.Attached {
@@ -288,7 +350,7 @@ func (db *Database) beginTx(opts *TxOptions) (*Transaction, error) {
 		tx.OnCommitHooks = append(tx.OnCommitHooks, db.releaseAttachedTx)
 	}
 
-	return &tx, nil
+	return &tx
 }
 
 func (db *Database) releaseAttachedTx() {
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/lock"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A TableReference is a foreign key constraint of a table
+// referencing another table, or the same table.
+type TableReference struct {
+	TableName  string
+	Constraint *TableConstraint
+}
+
+// GetTableReferences returns the foreign key constraints referencing the given table,
+// sorted by table and constraint name.
+func (c *Catalog) GetTableReferences(tableName string) []TableReference {
+	c.Cache.mu.RLock()
+	defer c.Cache.mu.RUnlock()
+
+	var refs []TableReference
+	for _, o := range c.Cache.tables {
+		ti := o.(*TableInfoRelation).Info
+		for _, tc := range ti.TableConstraints {
+			if tc.ForeignKey != nil && tc.ForeignKey.Table == tableName {
+				refs = append(refs, TableReference{TableName: ti.TableName, Constraint: tc})
+			}
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].TableName != refs[j].TableName {
+			return refs[i].TableName < refs[j].TableName
+		}
+		return refs[i].Constraint.Name < refs[j].Constraint.Name
+	})
+
+	return refs
+}
+
+// resolveForeignKey ensures the table referenced by the foreign key constraint tc of ti exists
+// and that the referenced paths are those of its primary key or of one of its unique constraints.
+// If the referenced paths are omitted, the paths of the primary key are used.
+func (c *Catalog) resolveForeignKey(tx *Transaction, ti *TableInfo, tc *TableConstraint) error {
+	fk := tc.ForeignKey
+
+	ref := ti
+	if fk.Table != ti.TableName {
+		err := c.LockTable(tx, fk.Table, lock.S)
+		if err != nil {
+			return err
+		}
+
+		ref, err = c.GetTableInfo(fk.Table)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(fk.Paths) == 0 {
+		pk := ref.GetPrimaryKey()
+		if pk == nil {
+			return errors.Errorf("foreign key %q references table %q which has no primary key", tc.Name, fk.Table)
+		}
+
+		fk.Paths = pk.Paths
+	}
+
+	if len(fk.Paths) != len(tc.Paths) {
+		return errors.Errorf("foreign key references %d paths but declares %d", len(fk.Paths), len(tc.Paths))
+	}
+
+	for _, rtc := range ref.TableConstraints {
+		if (rtc.PrimaryKey || rtc.Unique) && rtc.Paths.IsEqual(fk.Paths) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("foreign key %q references (%s) of table %q which is neither a primary key nor unique", tc.Name, fk.Paths, fk.Table)
+}
+
+// ValidateForeignKey ensures that the document referenced by d
+// through the foreign key constraint tc exists.
+// Documents with a NULL or missing value in one of the referencing paths are not checked.
+// The referenced document is locked in shared mode until the end of the transaction,
+// to prevent concurrent transactions from deleting it.
+// A document of ti may reference itself.
+func (c *Catalog) ValidateForeignKey(tx *Transaction, ti *TableInfo, tc *TableConstraint, d types.Document) error {
+	fk := tc.ForeignKey
+	self := fk.Table == ti.TableName
+
+	ref, err := c.GetTable(tx, fk.Table)
+	if err != nil {
+		return err
+	}
+
+	vs := make([]types.Value, 0, len(tc.Paths))
+	for i, p := range tc.Paths {
+		v, err := p.GetValueFromDocument(d)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if v.Type() == types.NullValue {
+			return nil
+		}
+
+		// convert the value to the type of the referenced path
+		fc := ref.Info.GetFieldConstraintForPath(fk.Paths[i])
+		if fc != nil && !fc.Type.IsAny() {
+			v, err = document.CastAs(v, fc.Type)
+			if err != nil {
+				return errors.Errorf("document violates foreign key constraint %q", tc.Name)
+			}
+		}
+
+		vs = append(vs, v)
+
+		if self {
+			self, err = hasValue(d, fk.Paths[i], v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// the document references itself
+	if self {
+		return nil
+	}
+
+	found, err := c.lockReferencedDocument(tx, ref, fk.Paths, vs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("document violates foreign key constraint %q", tc.Name)
+	}
+
+	return nil
+}
+
+// hasValue returns whether the value of d at path p is equal to v.
+func hasValue(d types.Document, p document.Path, v types.Value) (bool, error) {
+	dv, err := p.GetValueFromDocument(d)
+	if errors.Is(err, types.ErrFieldNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return types.IsEqual(v, dv)
+}
+
+// lockReferencedDocument looks for the document of the table whose values at the given paths
+// are equal to vs, using the primary key or a unique index, and locks it in shared mode.
+func (c *Catalog) lockReferencedDocument(tx *Transaction, t *Table, paths document.Paths, vs []types.Value) (bool, error) {
+	if pk := t.Info.GetPrimaryKey(); pk != nil && pk.Paths.IsEqual(paths) {
+		key := tree.NewKey(vs...)
+		err := c.lockReferencedKey(tx, t, key)
+		if err != nil {
+			return false, err
+		}
+
+		_, err = t.GetDocument(key)
+		if errs.IsNotFoundError(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	for _, info := range c.Cache.GetTableIndexes(t.Info.TableName) {
+		// partial indexes don't reference all the documents
+		if !info.Unique || info.IsPartial() || !document.Paths(info.Paths).IsEqual(paths) {
+			continue
+		}
+
+		idx, err := c.GetIndex(tx, info.IndexName)
+		if err != nil {
+			return false, err
+		}
+
+		found, key, err := idx.Exists(vs)
+		if err != nil || !found {
+			return false, err
+		}
+
+		err = c.lockReferencedKey(tx, t, key)
+		if err != nil {
+			return false, err
+		}
+
+		// the document may have been modified while waiting for the lock
+		found, lockedKey, err := idx.Exists(vs)
+		if err != nil || !found {
+			return false, err
+		}
+
+		return bytes.Equal(lockedKey.Encoded, key.Encoded), nil
+	}
+
+	return false, errors.Errorf("no primary key or unique index on (%s) for table %q", paths, t.Info.TableName)
+}
+
+func (c *Catalog) lockReferencedKey(tx *Transaction, t *Table, key *tree.Key) error {
+	k, err := key.Encode(t.Tree.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.LockDocument(tx, t.Info.TableName, k, lock.S)
+}
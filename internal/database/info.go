@@ -127,6 +127,15 @@ func (ti *TableInfo) AddTableConstraint(newTc *TableConstraint) error {
 		if newTc.Name == "" {
 			newTc.Name = fmt.Sprintf("%s_%s_unique", ti.TableName, pathsToIndexName(newTc.Paths))
 		}
+	case newTc.ForeignKey != nil:
+		if len(newTc.ForeignKey.Paths) != 0 && len(newTc.ForeignKey.Paths) != len(newTc.Paths) {
+			return errors.Errorf("foreign key references %d paths but declares %d", len(newTc.ForeignKey.Paths), len(newTc.Paths))
+		}
+
+		// generate name if not provided
+		if newTc.Name == "" {
+			newTc.Name = fmt.Sprintf("%s_%s_fkey", ti.TableName, pathsToIndexName(newTc.Paths))
+		}
 	default:
 		return errors.New("invalid table constraint")
 	}
@@ -244,10 +253,20 @@ type IndexInfo struct {
 	// If set to true, values will be associated with at most one key. False by default.
 	Unique bool
 
+	// If set to true, the index contains one entry per element of the array
+	// located at the indexed path, instead of the array itself.
+	// i.e CREATE INDEX ON tbl(a[*])
+	// Multikey indexes can only index one path.
+	Multikey bool
+
 	// If set, this index has been created from a table constraint
 	// i.e CREATE TABLE tbl(a INT UNIQUE)
 	// The path refers to the path this index is related to.
 	Owner Owner
+
+	// If set, only the documents for which the predicate is true are indexed.
+	// i.e CREATE INDEX ON tbl(a) WHERE b > 10
+	Predicate TableExpression
 }
 
 // String returns a SQL representation.
@@ -261,20 +280,49 @@ func (i *IndexInfo) String() string {
 
 	fmt.Fprintf(&s, "INDEX %s ON %s (", stringutil.NormalizeIdentifier(i.IndexName, '`'), stringutil.NormalizeIdentifier(i.Owner.TableName, '`'))
 
-	for i, p := range i.Paths {
-		if i > 0 {
+	for j, p := range i.Paths {
+		if j > 0 {
 			s.WriteString(", ")
 		}
 
 		// Path
 		s.WriteString(p.String())
+		if i.Multikey {
+			s.WriteString("[*]")
+		}
 	}
 
 	s.WriteString(")")
 
+	if i.Predicate != nil {
+		s.WriteString(" WHERE ")
+		s.WriteString(i.Predicate.String())
+	}
+
 	return s.String()
 }
 
+// IsPartial returns whether the index only stores the documents matching a predicate.
+func (i *IndexInfo) IsPartial() bool {
+	return i.Predicate != nil
+}
+
+// Includes returns whether the document must be stored in the index.
+// It is always true unless the index is partial and the predicate
+// is not true for the document.
+func (i *IndexInfo) Includes(tx *Transaction, d types.Document) (bool, error) {
+	if i.Predicate == nil {
+		return true, nil
+	}
+
+	v, err := i.Predicate.Eval(tx, d)
+	if err != nil {
+		return false, err
+	}
+
+	return types.IsTruthy(v)
+}
+
 // Clone returns a copy of the index information.
 func (i IndexInfo) Clone() *IndexInfo {
 	c := i
@@ -339,6 +387,24 @@ func (s SequenceInfo) Clone() *SequenceInfo {
 	return &s
 }
 
+// ViewInfo holds the configuration of a view.
+// The query is stored as SQL and parsed every time
+// the view is used.
+type ViewInfo struct {
+	ViewName string
+	Query    string
+}
+
+// String returns a SQL representation.
+func (v *ViewInfo) String() string {
+	return fmt.Sprintf("CREATE VIEW %s AS %s", stringutil.NormalizeIdentifier(v.ViewName, '`'), v.Query)
+}
+
+// Clone returns a copy of the view information.
+func (v ViewInfo) Clone() *ViewInfo {
+	return &v
+}
+
 // Owner is used to determine who owns a relation.
 // If the relation has been created by a table (for docids for example),
 // only the TableName is filled.
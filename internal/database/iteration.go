@@ -68,6 +68,16 @@ func (r *Range) Convert(constraints *FieldConstraints, v types.Value, p document
 			return document.CastAsDouble(v)
 		}
 
+		// texts are compared to timestamps by parsing them,
+		// the index must do the same.
+		if v.Type() == types.TextValue && targetType == types.TimestampValue {
+			ts, err := types.ParseTimestamp(types.As[string](v))
+			if err != nil {
+				return nil, err
+			}
+			return types.NewTimestampValue(ts), nil
+		}
+
 		if v.Type() == types.DoubleValue && targetType == types.IntegerValue {
 			f := types.As[float64](v)
 			if float64(int64(f)) == f {
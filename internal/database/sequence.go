@@ -104,6 +104,9 @@ func (s *Sequence) Next(tx *Transaction, catalog *Catalog) (int64, error) {
 		return 0, errors.New("cannot increment sequence on read-only transaction")
 	}
 
+	catalog.sequencesMu.Lock()
+	defer catalog.sequencesMu.Unlock()
+
 	var newValue int64
 	if s.CurrentValue == nil {
 		newValue = s.Info.Start
@@ -128,18 +131,19 @@ func (s *Sequence) Next(tx *Transaction, catalog *Catalog) (int64, error) {
 
 	var newLease int64
 
-	s.Cached++
+	cached := s.Cached + 1
 
 	// if the number of cached values is less than or equal to the cache,
 	// we don't increase the lease.
-	if s.CurrentValue != nil && s.Cached <= s.Info.Cache {
+	if s.CurrentValue != nil && cached <= s.Info.Cache {
+		s.Cached = cached
 		s.CurrentValue = &newValue
 		return newValue, nil
 	}
 
 	// we need to reset the number of cached values to 1
 	if s.CurrentValue != nil {
-		s.Cached = 1
+		cached = 1
 	}
 
 	// calculate the new lease depending on the direction
@@ -156,16 +160,24 @@ func (s *Sequence) Next(tx *Transaction, catalog *Catalog) (int64, error) {
 		}
 	}
 
-	// store the new lease
+	// store the new lease, the state of the sequence
+	// is left untouched if it fails
 	err := s.SetLease(tx, catalog, s.Info.Name, newLease)
 	if err != nil {
 		return 0, err
 	}
 
+	s.Cached = cached
 	s.CurrentValue = &newValue
 	return newValue, nil
 }
 
+// SetLease stores v as the lease of the sequence.
+// The values of a sequence are shared by all the write transactions:
+// once the sequence is committed, the lease is written directly to the store,
+// outside of the transaction. Otherwise, a transaction could overwrite the greater lease
+// stored by another one, or lose it by rolling back, while the values it covers are still used
+// by other transactions.
 func (s *Sequence) SetLease(tx *Transaction, catalog *Catalog, name string, v int64) error {
 	tb, err := s.GetOrCreateTable(tx, catalog)
 	if err != nil {
@@ -173,13 +185,33 @@ func (s *Sequence) SetLease(tx *Transaction, catalog *Catalog, name string, v in
 	}
 
 	k := s.key()
+	d := document.NewFieldBuffer().
+		Add("name", types.NewTextValue(name)).
+		Add("seq", types.NewIntegerValue(v))
 
-	_, err = tb.Replace(k,
-		document.NewFieldBuffer().
-			Add("name", types.NewTextValue(name)).
-			Add("seq", types.NewIntegerValue(v)),
-	)
-	return err
+	enck, err := k.Encode(tb.Tree.Namespace)
+	if err != nil {
+		return err
+	}
+
+	committed, err := tx.Store.Exists(enck)
+	if err != nil {
+		return err
+	}
+
+	// the sequence was created by the transaction,
+	// the lease is committed alongside it.
+	if !committed {
+		_, err = tb.Replace(k, d)
+		return err
+	}
+
+	enc, err := tb.Info.EncodeDocument(tx, nil, d)
+	if err != nil {
+		return err
+	}
+
+	return tx.Store.Put(enck, enc)
 }
 
 func (s *Sequence) GetOrCreateTable(tx *Transaction, catalog *Catalog) (*Table, error) {
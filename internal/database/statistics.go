@@ -0,0 +1,583 @@
+package database
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/encoding"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/lock"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+const (
+	StatisticsTableName = InternalPrefix + "stats"
+
+	// maximum number of buckets of an histogram.
+	histogramBuckets = 32
+
+	// fraction of the documents assumed to be selected by a range
+	// for which no better estimation is available.
+	defaultRangeSelectivity = 1.0 / 3
+)
+
+var statisticsTableInfo = &TableInfo{
+	TableName:      StatisticsTableName,
+	StoreNamespace: StatisticsTableNamespace,
+	FieldConstraints: MustNewFieldConstraints(
+		&FieldConstraint{
+			Position:  0,
+			Field:     "table_name",
+			Type:      types.TextValue,
+			IsNotNull: true,
+		},
+		&FieldConstraint{
+			Position:  1,
+			Field:     "paths",
+			Type:      types.ArrayValue,
+			IsNotNull: true,
+		},
+		&FieldConstraint{
+			Position: 2,
+			Field:    "row_count",
+			Type:     types.IntegerValue,
+		},
+		&FieldConstraint{
+			Position: 3,
+			Field:    "distinct_counts",
+			Type:     types.ArrayValue,
+		},
+		&FieldConstraint{
+			Position: 4,
+			Field:    "histogram",
+			Type:     types.ArrayValue,
+		},
+	),
+	TableConstraints: []*TableConstraint{
+		{
+			Name: StatisticsTableName + "_pk",
+			Paths: []document.Path{
+				document.NewPath("table_name"),
+				document.NewPath("paths"),
+			},
+			PrimaryKey: true,
+		},
+	},
+}
+
+// Statistics describe the distribution of the values of the paths
+// of an index or of a primary key, as collected by ANALYZE.
+type Statistics struct {
+	TableName string
+	Paths     document.Paths
+	// number of entries of the index, or of documents of the table
+	// for a primary key.
+	RowCount int64
+	// number of distinct values of each prefix of the paths:
+	// DistinctCounts[i] is the number of distinct values of Paths[:i+1].
+	DistinctCounts []int64
+	// distribution of the values of the first path.
+	Histogram []HistogramBucket
+}
+
+// A HistogramBucket holds the number of values lesser than or equal to Upper
+// and greater than the upper bound of the previous bucket.
+type HistogramBucket struct {
+	Upper    types.Value
+	Count    int64
+	Distinct int64
+}
+
+// EstimateRange returns the estimated number of entries selected by rng.
+// If the range cannot be evaluated, because it depends on parameters for example,
+// rng is nil and the estimation is based on exact and the number of paths n
+// used by the range.
+func (s *Statistics) EstimateRange(info *TableInfo, rng *Range, exact bool, n int) float64 {
+	if rng != nil {
+		exact = rng.Exact
+		n = len(rng.Min)
+		if len(rng.Max) > n {
+			n = len(rng.Max)
+		}
+	}
+
+	if n == 0 || s.RowCount == 0 {
+		return float64(s.RowCount)
+	}
+	if n > len(s.DistinctCounts) {
+		n = len(s.DistinctCounts)
+	}
+
+	if exact {
+		if n == 1 && rng != nil {
+			if est, ok := s.estimateFirstPath(info, rng); ok {
+				return est
+			}
+		}
+
+		return float64(s.RowCount) / float64(max64(s.DistinctCounts[n-1], 1))
+	}
+
+	// only the first path of a range can be estimated using the histogram,
+	// the others are equal to the previous ones.
+	if n == 1 && rng != nil {
+		if est, ok := s.estimateFirstPath(info, rng); ok {
+			return est
+		}
+	}
+
+	rows := float64(s.RowCount)
+	if n > 1 {
+		rows /= float64(max64(s.DistinctCounts[n-2], 1))
+	}
+
+	return rows * defaultRangeSelectivity
+}
+
+// estimateFirstPath estimates the number of entries selected by a range
+// on the first path using the histogram.
+func (s *Statistics) estimateFirstPath(info *TableInfo, rng *Range) (float64, bool) {
+	if len(s.Histogram) == 0 {
+		return 0, false
+	}
+
+	// convert the boundaries to the type of the indexed path
+	r := Range{
+		Min:       append([]types.Value(nil), rng.Min...),
+		Max:       append([]types.Value(nil), rng.Max...),
+		Exclusive: rng.Exclusive,
+		Exact:     rng.Exact,
+	}
+	trng, err := r.ToTreeRange(&info.FieldConstraints, s.Paths)
+	if err != nil {
+		return 0, false
+	}
+
+	min, err := encodeBoundary(trng.Min)
+	if err != nil {
+		return 0, false
+	}
+	max, err := encodeBoundary(trng.Max)
+	if err != nil {
+		return 0, false
+	}
+
+	var est float64
+	var lower []byte
+	for _, b := range s.Histogram {
+		upper, err := encoding.EncodeValue(nil, b.Upper)
+		if err != nil {
+			return 0, false
+		}
+
+		// the bucket holds the values in (lower, upper]
+		prev := lower
+		lower = upper
+
+		if min != nil && encoding.Compare(upper, min) < 0 {
+			continue
+		}
+		if max != nil && prev != nil && encoding.Compare(prev, max) >= 0 {
+			break
+		}
+
+		if rng.Exact {
+			return float64(b.Count) / float64(max64(b.Distinct, 1)), true
+		}
+
+		// the bucket is entirely selected if the range contains its bounds
+		if (min == nil || (prev != nil && encoding.Compare(prev, min) >= 0)) && (max == nil || encoding.Compare(upper, max) <= 0) {
+			est += float64(b.Count)
+		} else {
+			est += float64(b.Count) / 2
+		}
+	}
+
+	return est, true
+}
+
+func encodeBoundary(k *tree.Key) ([]byte, error) {
+	if k == nil {
+		return nil, nil
+	}
+
+	return k.Encode(0)
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// statisticsBuilder computes the statistics of a list of paths
+// from their values, which must be added in order.
+type statisticsBuilder struct {
+	stats *Statistics
+	// number of entries per bucket.
+	depth int64
+	// encoded values of the previous entry.
+	prev    [][]byte
+	current *HistogramBucket
+}
+
+func newStatisticsBuilder(tableName string, paths document.Paths, rowCount int64) *statisticsBuilder {
+	depth := rowCount / histogramBuckets
+	if rowCount%histogramBuckets != 0 {
+		depth++
+	}
+
+	return &statisticsBuilder{
+		stats: &Statistics{
+			TableName:      tableName,
+			Paths:          paths,
+			DistinctCounts: make([]int64, len(paths)),
+		},
+		depth: depth,
+	}
+}
+
+func (b *statisticsBuilder) add(vs []types.Value) error {
+	// look for the first value that differs from the previous entry
+	n := len(b.stats.Paths)
+	diff := 0
+	encoded := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		enc, err := encoding.EncodeValue(nil, vs[i])
+		if err != nil {
+			return err
+		}
+		encoded[i] = enc
+
+		if b.prev != nil && diff == i && encoding.Equal(enc, b.prev[i]) {
+			diff++
+		}
+	}
+	b.prev = encoded
+
+	for i := diff; i < n; i++ {
+		b.stats.DistinctCounts[i]++
+	}
+
+	// a value is never split across buckets
+	if diff == 0 {
+		if b.current != nil && b.current.Count >= b.depth {
+			b.stats.Histogram = append(b.stats.Histogram, *b.current)
+			b.current = nil
+		}
+		if b.current == nil {
+			b.current = new(HistogramBucket)
+		}
+
+		v, err := document.CloneValue(vs[0])
+		if err != nil {
+			return err
+		}
+		b.current.Upper = v
+		b.current.Distinct++
+	}
+
+	b.current.Count++
+	b.stats.RowCount++
+	return nil
+}
+
+func (b *statisticsBuilder) build() *Statistics {
+	if b.current != nil {
+		b.stats.Histogram = append(b.stats.Histogram, *b.current)
+	}
+
+	return b.stats
+}
+
+// AnalyzeTable collects statistics about the distribution of the values
+// of the primary key and of the indexes of the given table, and stores them
+// in the statistics table. They are used to estimate the cost of reading from each index.
+// Partial indexes are ignored, as they don't contain all the documents of the table,
+// and so are indexes on the same paths as the primary key or as another index.
+func (c *Catalog) AnalyzeTable(tx *Transaction, tableName string) error {
+	t, err := c.GetTable(tx, tableName)
+	if err != nil {
+		return err
+	}
+
+	var stats []*Statistics
+
+	if pk := t.Info.GetPrimaryKey(); pk != nil {
+		s, err := analyzeTree(t.Tree, tableName, pk.Paths, len(pk.Paths))
+		if err != nil {
+			return err
+		}
+		stats = append(stats, s)
+	}
+
+	for _, info := range c.Cache.GetTableIndexes(tableName) {
+		if info.IsPartial() || hasStatistics(stats, info.Paths) {
+			continue
+		}
+
+		idx, err := c.GetIndex(tx, info.IndexName)
+		if err != nil {
+			return err
+		}
+
+		s, err := analyzeTree(idx.Tree, tableName, info.Paths, idx.Arity)
+		if err != nil {
+			return err
+		}
+		stats = append(stats, s)
+	}
+
+	return c.setStatistics(tx, tableName, stats)
+}
+
+// hasStatistics returns whether stats contains the statistics of the given paths.
+func hasStatistics(stats []*Statistics, paths document.Paths) bool {
+	for _, s := range stats {
+		if s.Paths.IsEqual(paths) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// analyzeTree computes the statistics of the first n values of the keys of a tree.
+func analyzeTree(tr *tree.Tree, tableName string, paths document.Paths, n int) (*Statistics, error) {
+	var rowCount int64
+	err := tr.IterateOnRange(nil, false, func(*tree.Key, []byte) error {
+		rowCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b := newStatisticsBuilder(tableName, paths[:n], rowCount)
+	err = tr.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		vs, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		return b.add(vs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.build(), nil
+}
+
+// GetStatistics returns the statistics collected for the given paths of a table,
+// or nil if the table was not analyzed since an index was created on these paths.
+func (c *Catalog) GetStatistics(tableName string, paths document.Paths) *Statistics {
+	c.Cache.mu.RLock()
+	defer c.Cache.mu.RUnlock()
+
+	for _, s := range c.Cache.stats[tableName] {
+		if s.Paths.IsEqual(paths) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// dropIndexStatistics removes the statistics of the paths of a dropped index,
+// unless they are shared with the primary key or with another index of the table.
+func (c *Catalog) dropIndexStatistics(tx *Transaction, info *IndexInfo) error {
+	c.Cache.mu.RLock()
+	stats := c.Cache.stats[info.Owner.TableName]
+	c.Cache.mu.RUnlock()
+
+	if !hasStatistics(stats, info.Paths) {
+		return nil
+	}
+
+	ti, err := c.GetTableInfo(info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+	if pk := ti.GetPrimaryKey(); pk != nil && pk.Paths.IsEqual(info.Paths) {
+		return nil
+	}
+	for _, idx := range c.Cache.GetTableIndexes(info.Owner.TableName) {
+		if idx.IndexName != info.IndexName && !idx.IsPartial() && document.Paths(idx.Paths).IsEqual(info.Paths) {
+			return nil
+		}
+	}
+
+	kept := make([]*Statistics, 0, len(stats)-1)
+	for _, s := range stats {
+		if !s.Paths.IsEqual(info.Paths) {
+			kept = append(kept, s)
+		}
+	}
+
+	return c.setStatistics(tx, info.Owner.TableName, kept)
+}
+
+// HasStatistics returns whether the given table was analyzed.
+func (c *Catalog) HasStatistics(tableName string) bool {
+	c.Cache.mu.RLock()
+	defer c.Cache.mu.RUnlock()
+
+	return len(c.Cache.stats[tableName]) > 0
+}
+
+// LoadStatistics loads the content of the statistics table into the cache.
+func (c *Catalog) LoadStatistics(stats []*Statistics) {
+	c.Cache.mu.Lock()
+	defer c.Cache.mu.Unlock()
+
+	for _, s := range stats {
+		c.Cache.stats[s.TableName] = append(c.Cache.stats[s.TableName], s)
+	}
+}
+
+// setStatistics replaces the statistics of a table, in the cache and in the statistics table.
+// If stats is empty, the statistics of the table are removed.
+func (c *Catalog) setStatistics(tx *Transaction, tableName string, stats []*Statistics) error {
+	tb, err := c.GetTable(tx, StatisticsTableName)
+	if errs.IsNotFoundError(err) {
+		if len(stats) == 0 {
+			return nil
+		}
+
+		err = c.CreateTable(tx, StatisticsTableName, statisticsTableInfo)
+		if err != nil {
+			return err
+		}
+		tb, err = c.GetTable(tx, StatisticsTableName)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the statistics table is shared by all the tables
+	err = c.LockTable(tx, StatisticsTableName, lock.X)
+	if err != nil {
+		return err
+	}
+
+	// remove the previous statistics of the table
+	var keys []*tree.Key
+	rng := Range{Min: []types.Value{types.NewTextValue(tableName)}, Exact: true}
+	err = tb.IterateOnRange(&rng, false, func(key *tree.Key, _ types.Document) error {
+		keys = append(keys, tree.NewEncodedKey(append([]byte(nil), key.Encoded...)))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		err = tb.Delete(k)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range stats {
+		d, err := statisticsToDocument(s)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = tb.Insert(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Cache.mu.Lock()
+	old, ok := c.Cache.stats[tableName]
+	if len(stats) > 0 {
+		c.Cache.stats[tableName] = stats
+	} else {
+		delete(c.Cache.stats, tableName)
+	}
+	c.Cache.mu.Unlock()
+
+	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
+		c.Cache.mu.Lock()
+		if ok {
+			c.Cache.stats[tableName] = old
+		} else {
+			delete(c.Cache.stats, tableName)
+		}
+		c.Cache.mu.Unlock()
+	})
+
+	return nil
+}
+
+// dropStatistics removes the statistics of a table.
+func (c *Catalog) dropStatistics(tx *Transaction, tableName string) error {
+	if !c.HasStatistics(tableName) {
+		return nil
+	}
+
+	return c.setStatistics(tx, tableName, nil)
+}
+
+// renameStatistics moves the statistics of a table to its new name.
+func (c *Catalog) renameStatistics(tx *Transaction, oldName, newName string) error {
+	c.Cache.mu.RLock()
+	stats := c.Cache.stats[oldName]
+	c.Cache.mu.RUnlock()
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	renamed := make([]*Statistics, len(stats))
+	for i, s := range stats {
+		clone := *s
+		clone.TableName = newName
+		renamed[i] = &clone
+	}
+
+	err := c.setStatistics(tx, oldName, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.setStatistics(tx, newName, renamed)
+}
+
+// statisticsToDocument returns the document stored in the statistics table.
+// The upper bounds of the histogram are stored encoded, as numbers nested
+// in arrays are decoded as doubles.
+func statisticsToDocument(s *Statistics) (types.Document, error) {
+	paths := document.NewValueBuffer()
+	for _, p := range s.Paths {
+		paths.Append(types.NewTextValue(p.String()))
+	}
+
+	distinct := document.NewValueBuffer()
+	for _, d := range s.DistinctCounts {
+		distinct.Append(types.NewIntegerValue(d))
+	}
+
+	histogram := document.NewValueBuffer()
+	for _, b := range s.Histogram {
+		upper, err := encoding.EncodeValue(nil, b.Upper)
+		if err != nil {
+			return nil, err
+		}
+
+		histogram.Append(types.NewDocumentValue(document.NewFieldBuffer().
+			Add("upper", types.NewBlobValue(upper)).
+			Add("count", types.NewIntegerValue(b.Count)).
+			Add("distinct", types.NewIntegerValue(b.Distinct)),
+		))
+	}
+
+	return document.NewFieldBuffer().
+		Add("table_name", types.NewTextValue(s.TableName)).
+		Add("paths", types.NewArrayValue(paths)).
+		Add("row_count", types.NewIntegerValue(s.RowCount)).
+		Add("distinct_counts", types.NewArrayValue(distinct)).
+		Add("histogram", types.NewArrayValue(histogram)), nil
+}
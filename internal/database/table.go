@@ -2,11 +2,13 @@ package database
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/kv"
+	"github.com/genjidb/genji/internal/lock"
 	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
 )
@@ -43,6 +45,11 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 		return nil, nil, err
 	}
 
+	err = t.lock(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	d, enc, err := t.encodeDocument(d)
 	if err != nil {
 		return nil, nil, err
@@ -52,9 +59,15 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 	err = t.Tree.Insert(key, enc)
 	if err != nil {
 		if errors.Is(err, kv.ErrKeyAlreadyExists) {
+			// tables without primary key use a docid
+			var paths []document.Path
+			if pk := t.Info.GetPrimaryKey(); pk != nil {
+				paths = pk.Paths
+			}
+
 			return nil, nil, &ConstraintViolationError{
 				Constraint: "PRIMARY KEY",
-				Paths:      t.Info.GetPrimaryKey().Paths,
+				Paths:      paths,
 				Key:        key,
 			}
 		}
@@ -62,6 +75,11 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 		return nil, nil, errors.Wrapf(err, "failed to insert document %q", key)
 	}
 
+	err = t.recordChange(ChangeInsert, key, d, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return key, d, nil
 }
 
@@ -84,12 +102,28 @@ func (t *Table) Delete(key *tree.Key) error {
 		return errors.New("cannot write to read-only table")
 	}
 
-	err := t.Tree.Delete(key)
+	err := t.lock(key)
+	if err != nil {
+		return err
+	}
+
+	var old types.Document
+	if t.capturing() {
+		old, err = t.GetDocument(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.Tree.Delete(key)
 	if errors.Is(err, kv.ErrKeyNotFound) {
 		return errors.WithStack(errs.NewNotFoundError(key.String()))
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	return t.recordChange(ChangeDelete, key, old, nil)
 }
 
 // Replace a document by key.
@@ -99,13 +133,29 @@ func (t *Table) Replace(key *tree.Key, d types.Document) (types.Document, error)
 		return nil, errors.New("cannot write to read-only table")
 	}
 
-	// make sure key exists
-	ok, err := t.Tree.Exists(key)
+	err := t.lock(key)
 	if err != nil {
 		return nil, err
 	}
-	if !ok {
-		return nil, errors.Wrapf(errs.NewNotFoundError(key.String()), "can't replace key %q", key)
+
+	// make sure key exists
+	var old types.Document
+	if t.capturing() {
+		old, err = t.GetDocument(key)
+		if errs.IsNotFoundError(err) {
+			return nil, errors.Wrapf(err, "can't replace key %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ok, err := t.Tree.Exists(key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.Wrapf(errs.NewNotFoundError(key.String()), "can't replace key %q", key)
+		}
 	}
 
 	d, enc, err := t.encodeDocument(d)
@@ -115,9 +165,81 @@ func (t *Table) Replace(key *tree.Key, d types.Document) (types.Document, error)
 
 	// replace old document with new document
 	err = t.Tree.Put(key, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.recordChange(ChangeReplace, key, d, old)
 	return d, err
 }
 
+// capturing returns whether the changes made to the table
+// must be recorded for the subscribers of the database.
+func (t *Table) capturing() bool {
+	return t.Tx.captureChanges && !strings.HasPrefix(t.Info.TableName, InternalPrefix)
+}
+
+// recordChange records a change made to the table by the transaction.
+// The key and the document are copied, as they may be reused by the caller.
+// For replaced documents, old is the previous version of d.
+func (t *Table) recordChange(typ ChangeType, key *tree.Key, d, old types.Document) error {
+	if !t.capturing() {
+		return nil
+	}
+
+	k, err := key.Encode(t.Tree.Namespace)
+	if err != nil {
+		return err
+	}
+
+	fb := document.NewFieldBuffer()
+	err = fb.Copy(d)
+	if err != nil {
+		return err
+	}
+
+	c := Change{
+		Type:      typ,
+		TableName: t.Info.TableName,
+		Key:       tree.NewEncodedKey(append([]byte(nil), k...)),
+		Document:  fb,
+	}
+
+	if old != nil {
+		// delete operations reference the values of the old document
+		ofb := document.NewFieldBuffer()
+		err = ofb.Copy(old)
+		if err != nil {
+			return err
+		}
+
+		c.Ops, err = document.Diff(ofb, fb)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Tx.changes = append(t.Tx.changes, c)
+	return nil
+}
+
+// lock the document with the given key until the end of the transaction,
+// to prevent concurrent write transactions from modifying it.
+func (t *Table) lock(key *tree.Key) error {
+	// internal tables are managed by the catalog,
+	// which synchronizes their modifications.
+	if strings.HasPrefix(t.Info.TableName, InternalPrefix) {
+		return nil
+	}
+
+	k, err := key.Encode(t.Tree.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return t.Catalog.LockDocument(t.Tx, t.Info.TableName, k, lock.X)
+}
+
 func (t *Table) IterateOnRange(rng *Range, reverse bool, fn func(key *tree.Key, d types.Document) error) error {
 	var paths []document.Path
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/kv"
+	"github.com/genjidb/genji/internal/lock"
 )
 
 // Transaction represents a database transaction. It provides methods for managing the
@@ -16,12 +17,35 @@ type Transaction struct {
 	Store     *kv.Store
 	ID        uint64
 	Writable  bool
-	WriteTxMu *sync.Mutex
+	WriteTxMu *sync.RWMutex
 
 	// these functions are run after a successful rollback.
 	OnRollbackHooks []func()
 	// these functions are run after a successful commit.
 	OnCommitHooks []func()
+
+	savepoints []txSavepoint
+
+	// used by write transactions to detect concurrent modifications.
+	writes       *writeTracker
+	startVersion uint64
+	// objects locked exclusively by the transaction.
+	writeSet []lock.Object
+
+	// delivers the changes of the transaction once committed.
+	feed *changeFeed
+	// changes made by the transaction, only recorded if there are subscribers.
+	changes        []Change
+	captureChanges bool
+}
+
+// txSavepoint records the number of hooks registered
+// and of changes recorded when a savepoint was created.
+type txSavepoint struct {
+	name          string
+	rollbackHooks int
+	commitHooks   int
+	changes       int
 }
 
 // Rollback the transaction. Can be used safely after commit.
@@ -32,13 +56,12 @@ func (tx *Transaction) Rollback() error {
 	}
 
 	if tx.Writable {
-		err = tx.Store.Rollback()
-		if err != nil {
-			return err
+		if tx.writes != nil {
+			tx.writes.rollback(tx)
 		}
 
 		defer func() {
-			tx.WriteTxMu.Unlock()
+			tx.WriteTxMu.RUnlock()
 		}()
 	}
 
@@ -56,15 +79,27 @@ func (tx *Transaction) Commit() error {
 		return errors.New("cannot commit read-only transaction")
 	}
 
-	err := tx.Session.Commit()
+	var err error
+	if tx.feed != nil {
+		// the feed numbers and publishes the changes
+		// while committing, so that they are delivered in commit order
+		err = tx.feed.commit(tx, tx.Session.Commit)
+	} else {
+		err = tx.Session.Commit()
+	}
 	if err != nil {
 		return err
 	}
 
 	_ = tx.Session.Close()
 
+	// record the modified objects before releasing the locks
+	if tx.writes != nil {
+		tx.writes.commit(tx)
+	}
+
 	defer func() {
-		tx.WriteTxMu.Unlock()
+		tx.WriteTxMu.RUnlock()
 	}()
 
 	for i := len(tx.OnCommitHooks) - 1; i >= 0; i-- {
@@ -73,3 +108,78 @@ func (tx *Transaction) Commit() error {
 
 	return nil
 }
+
+// Savepoint creates a savepoint with the given name.
+// Rolling back to a savepoint undoes all the changes made after its creation,
+// without ending the transaction.
+func (tx *Transaction) Savepoint(name string) error {
+	if tx.Writable {
+		tx.Session.(*kv.BatchSession).Savepoint(name)
+	}
+
+	tx.savepoints = append(tx.savepoints, txSavepoint{
+		name:          name,
+		rollbackHooks: len(tx.OnRollbackHooks),
+		commitHooks:   len(tx.OnCommitHooks),
+		changes:       len(tx.changes),
+	})
+
+	return nil
+}
+
+// RollbackToSavepoint undoes all the changes made after the most recent savepoint
+// with the given name. The savepoints created after it are removed
+// but the savepoint itself remains active.
+func (tx *Transaction) RollbackToSavepoint(name string) error {
+	idx, err := tx.savepointIndex(name)
+	if err != nil {
+		return err
+	}
+
+	if tx.Writable {
+		err = tx.Session.(*kv.BatchSession).RollbackToSavepoint(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	sp := tx.savepoints[idx]
+	for i := len(tx.OnRollbackHooks) - 1; i >= sp.rollbackHooks; i-- {
+		tx.OnRollbackHooks[i]()
+	}
+	tx.OnRollbackHooks = tx.OnRollbackHooks[:sp.rollbackHooks]
+	tx.OnCommitHooks = tx.OnCommitHooks[:sp.commitHooks]
+	tx.changes = tx.changes[:sp.changes]
+	tx.savepoints = tx.savepoints[:idx+1]
+
+	return nil
+}
+
+// ReleaseSavepoint removes the most recent savepoint with the given name
+// and all the savepoints created after it. The changes are kept.
+func (tx *Transaction) ReleaseSavepoint(name string) error {
+	idx, err := tx.savepointIndex(name)
+	if err != nil {
+		return err
+	}
+
+	if tx.Writable {
+		err = tx.Session.(*kv.BatchSession).ReleaseSavepoint(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx.savepoints = tx.savepoints[:idx]
+	return nil
+}
+
+func (tx *Transaction) savepointIndex(name string) (int, error) {
+	for i := len(tx.savepoints) - 1; i >= 0; i-- {
+		if tx.savepoints[i].name == name {
+			return i, nil
+		}
+	}
+
+	return -1, errors.Errorf("savepoint %q does not exist", name)
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/internal/stringutil"
+)
+
+// TriggerTiming determines if a trigger runs before or after the event.
+type TriggerTiming int
+
+const (
+	// TriggerBefore runs the trigger before the document is written.
+	TriggerBefore TriggerTiming = iota + 1
+
+	// TriggerAfter runs the trigger after the document is written.
+	TriggerAfter
+)
+
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerBefore:
+		return "BEFORE"
+	case TriggerAfter:
+		return "AFTER"
+	}
+
+	return ""
+}
+
+// TriggerEvent is the kind of write that fires a trigger.
+type TriggerEvent int
+
+const (
+	// TriggerInsert fires the trigger when a document is inserted.
+	TriggerInsert TriggerEvent = iota + 1
+
+	// TriggerUpdate fires the trigger when a document is replaced.
+	TriggerUpdate
+
+	// TriggerDelete fires the trigger when a document is deleted.
+	TriggerDelete
+)
+
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerInsert:
+		return "INSERT"
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	}
+
+	return ""
+}
+
+// TriggerInfo holds the configuration of a trigger.
+// The statement is stored as SQL and parsed every time
+// the trigger runs.
+type TriggerInfo struct {
+	TriggerName string
+	TableName   string
+	Timing      TriggerTiming
+	Event       TriggerEvent
+	Statement   string
+}
+
+// String returns a SQL representation.
+func (t *TriggerInfo) String() string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW DO %s",
+		stringutil.NormalizeIdentifier(t.TriggerName, '`'),
+		t.Timing,
+		t.Event,
+		stringutil.NormalizeIdentifier(t.TableName, '`'),
+		t.Statement,
+	)
+}
+
+// Clone returns a copy of the trigger information.
+func (t TriggerInfo) Clone() *TriggerInfo {
+	return &t
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/lock"
+)
+
+// A writeTracker remembers which objects were modified by committed
+// write transactions.
+// Write transactions read the latest committed version of the documents
+// before locking them, so a document may be modified by another transaction
+// between the moment it is read and the moment it is locked.
+// To prevent lost updates, a transaction locking an object modified by a transaction
+// that committed after it started gets a conflict error and must be retried.
+type writeTracker struct {
+	mu sync.Mutex
+
+	// incremented every time a write transaction commits.
+	version uint64
+	// version at which each running write transaction started.
+	active map[uint64]uint64
+	// version of the last commit that modified each object.
+	objects map[lock.Object]uint64
+	// size of objects that triggers the removal of old entries.
+	pruneAt int
+}
+
+const minPruneSize = 1024
+
+func newWriteTracker() *writeTracker {
+	return &writeTracker{
+		active:  make(map[uint64]uint64),
+		objects: make(map[lock.Object]uint64),
+		pruneAt: minPruneSize,
+	}
+}
+
+// begin registers a new write transaction.
+func (w *writeTracker) begin(tx *Transaction) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tx.startVersion = w.version
+	w.active[tx.ID] = w.version
+}
+
+// check returns a conflict error if the object was modified by a transaction
+// that committed after tx started.
+func (w *writeTracker) check(tx *Transaction, obj *lock.Object) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.objects[*obj] > tx.startVersion {
+		return errors.WithStack(errs.NewConflictError("document modified by a concurrent transaction"))
+	}
+
+	return nil
+}
+
+// commit records the objects modified by tx.
+func (w *writeTracker) commit(tx *Transaction) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(tx.writeSet) > 0 {
+		w.version++
+		for _, obj := range tx.writeSet {
+			w.objects[obj] = w.version
+		}
+	}
+
+	w.end(tx)
+}
+
+// rollback unregisters tx.
+func (w *writeTracker) rollback(tx *Transaction) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.end(tx)
+}
+
+func (w *writeTracker) end(tx *Transaction) {
+	delete(w.active, tx.ID)
+
+	// without running transactions, no conflict can happen
+	if len(w.active) == 0 {
+		if len(w.objects) > 0 {
+			w.objects = make(map[lock.Object]uint64)
+		}
+		w.pruneAt = minPruneSize
+		return
+	}
+
+	if len(w.objects) < w.pruneAt {
+		return
+	}
+
+	// remove the objects modified before the oldest running transaction started
+	oldest := w.version
+	for _, v := range w.active {
+		if v < oldest {
+			oldest = v
+		}
+	}
+
+	for obj, v := range w.objects {
+		if v <= oldest {
+			delete(w.objects, obj)
+		}
+	}
+
+	w.pruneAt = 2 * len(w.objects)
+	if w.pruneAt < minPruneSize {
+		w.pruneAt = minPruneSize
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/encoding"
@@ -175,6 +176,83 @@ func TestEncodeDecodeBooleans(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeTimestamps(t *testing.T) {
+	tests := []time.Time{
+		time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1677, time.September, 21, 0, 12, 43, 145224191, time.UTC),
+		time.Date(1969, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Unix(0, 0).UTC(),
+		time.Date(1970, time.January, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(2023, time.January, 15, 10, 30, 0, 123456789, time.UTC),
+		time.Date(2023, time.January, 15, 10, 30, 1, 0, time.UTC),
+		time.Date(2262, time.April, 11, 23, 47, 16, 854775808, time.UTC),
+		time.Date(9999, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+	}
+
+	var prev []byte
+	for _, test := range tests {
+		t.Run(test.String(), func(t *testing.T) {
+			got := encoding.EncodeTimestamp(nil, test)
+			require.Len(t, got, 13)
+			require.Equal(t, 13, encoding.Skip(got))
+
+			x := encoding.DecodeTimestamp(got[1:])
+			require.Equal(t, test, x)
+
+			v, n := encoding.DecodeValue(got, false)
+			require.Equal(t, 13, n)
+			require.Equal(t, types.TimestampValue, v.Type())
+			require.Equal(t, test, types.As[time.Time](v))
+
+			if prev != nil {
+				require.Less(t, encoding.Compare(prev, got), 0)
+				require.Less(t, bytes.Compare(prev, got), 0)
+			}
+			prev = got
+		})
+	}
+}
+
+func TestEncodeDecodeIntervals(t *testing.T) {
+	tests := []types.Interval{
+		{Months: math.MinInt64, Duration: math.MinInt64},
+		{Months: math.MinInt64},
+		{Duration: -time.Hour},
+		{},
+		{Duration: time.Nanosecond},
+		{Months: 1, Duration: -time.Hour},
+		{Duration: 30 * 24 * time.Hour},
+		{Months: 1},
+		{Duration: 40 * 24 * time.Hour},
+		{Months: 14, Duration: time.Minute},
+		{Months: -1, Duration: math.MaxInt64},
+		{Months: math.MaxInt64, Duration: math.MaxInt64},
+	}
+
+	var prev []byte
+	for _, test := range tests {
+		t.Run(test.String(), func(t *testing.T) {
+			got := encoding.EncodeInterval(nil, test)
+			require.Len(t, got, 25)
+			require.Equal(t, 25, encoding.Skip(got))
+
+			x := encoding.DecodeInterval(got[1:])
+			require.Equal(t, test, x)
+
+			v, n := encoding.DecodeValue(got, false)
+			require.Equal(t, 25, n)
+			require.Equal(t, types.IntervalValue, v.Type())
+			require.Equal(t, test, types.As[types.Interval](v))
+
+			if prev != nil {
+				require.Less(t, encoding.Compare(prev, got), 0)
+				require.Less(t, bytes.Compare(prev, got), 0)
+			}
+			prev = got
+		})
+	}
+}
+
 func TestEncodeDecodeNull(t *testing.T) {
 	got := encoding.EncodeNull(nil)
 	require.Equal(t, []byte{0x05}, got)
@@ -57,6 +57,10 @@ func Skip(b []byte) int {
 		return 5
 	case Int64Value, Uint64Value, Float64Value:
 		return 9
+	case TimestampValue:
+		return 13
+	case IntervalValue:
+		return 25
 	case TextValue, BlobValue:
 		l, n := binary.Uvarint(b[1:])
 		return n + int(l) + 1
@@ -168,6 +172,10 @@ func compareNextValue(a, b []byte) (cmp int, n int) {
 	switch a[0] {
 	case Int64Value, Uint64Value, Float64Value:
 		return bytes.Compare(a[1:9], b[1:9]), 9
+	case TimestampValue:
+		return bytes.Compare(a[1:13], b[1:13]), 13
+	case IntervalValue:
+		return bytes.Compare(a[1:25], b[1:25]), 25
 	case Int32Value, Uint32Value, Float32Value:
 		return bytes.Compare(a[1:5], b[1:5]), 5
 	case Int16Value, Uint16Value:
@@ -320,7 +328,7 @@ func abbreviatedValue(key []byte) uint64 {
 	case Uint32Value, Int32Value:
 		x := DecodeUint32(key[1:])
 		return uint64(x)
-	case Uint64Value, Int64Value, Float64Value:
+	case Uint64Value, Int64Value, Float64Value, TimestampValue, IntervalValue:
 		x := DecodeUint64(key[1:])
 		return uint64(x) >> 24
 	case TextValue, BlobValue:
@@ -0,0 +1,37 @@
+package encoding
+
+import (
+	"math"
+	"time"
+
+	"github.com/genjidb/genji/types"
+)
+
+// EncodeInterval encodes the length of the interval, as returned by Interval.Length,
+// followed by its number of months, so that intervals are sorted by length
+// then by number of months.
+// Signed integers use the same order-preserving representation as EncodeInt64.
+func EncodeInterval(dst []byte, i types.Interval) []byte {
+	hi, lo := i.Length()
+	dst = write8(dst, byte(IntervalValue), uint64(hi)+math.MaxInt64+1)
+	dst = appendUint64(dst, lo)
+	return appendUint64(dst, uint64(i.Months)+math.MaxInt64+1)
+}
+
+func appendUint64(dst []byte, n uint64) []byte {
+	return append(dst, byte(n>>56), byte(n>>48), byte(n>>40), byte(n>>32), byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+// DecodeInterval decodes an interval encoded with EncodeInterval.
+// The type byte must have been skipped.
+func DecodeInterval(b []byte) types.Interval {
+	months := DecodeInt64(b[16:])
+
+	// the duration fits in an int64: it can be computed
+	// from the lower half of the length, modulo 2^64.
+	lo := DecodeUint64(b[8:])
+	return types.Interval{
+		Months:   months,
+		Duration: time.Duration(lo - uint64(months)*uint64(types.MonthDuration)),
+	}
+}
@@ -0,0 +1,22 @@
+package encoding
+
+import (
+	"math"
+	"time"
+)
+
+// EncodeTimestamp encodes the number of seconds elapsed since
+// January 1, 1970 UTC, using the same order-preserving representation
+// as EncodeInt64, followed by the nanoseconds within that second
+// on 4 bytes.
+func EncodeTimestamp(dst []byte, t time.Time) []byte {
+	dst = write8(dst, byte(TimestampValue), uint64(t.Unix())+math.MaxInt64+1)
+	n := uint32(t.Nanosecond())
+	return append(dst, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+// DecodeTimestamp decodes a timestamp encoded with EncodeTimestamp.
+// The type byte must have been skipped.
+func DecodeTimestamp(b []byte) time.Time {
+	return time.Unix(DecodeInt64(b), int64(DecodeUint32(b[8:]))).UTC()
+}
@@ -11,6 +11,15 @@ import (
 
 var (
 	TableKey = document.Path{document.PathFragment{FieldName: "$table"}}
+	// AliasKey holds the name under which the current document
+	// can be referenced by qualified paths, i.e. the table name or its alias.
+	AliasKey = document.Path{document.PathFragment{FieldName: "$alias"}}
+	// WindowsKey holds the values computed by the window functions
+	// for the current document, indexed by the string representation of the function.
+	WindowsKey = document.Path{document.PathFragment{FieldName: "$windows"}}
+	// TriggersKey holds the names of the triggers being run,
+	// used to prevent a trigger from firing itself.
+	TriggersKey = document.Path{document.PathFragment{FieldName: "$triggers"}}
 )
 
 // A Param represents a parameter passed by the user to the statement.
@@ -22,6 +31,13 @@ type Param struct {
 	Value interface{}
 }
 
+// Changes holds the number of documents written by a statement
+// and the last integer key generated by its insertions.
+type Changes struct {
+	RowsAffected int64
+	LastInsertID int64
+}
+
 // Environment contains information about the context in which
 // the expression is evaluated.
 type Environment struct {
@@ -32,6 +48,7 @@ type Environment struct {
 	DB      *database.Database
 	Catalog *database.Catalog
 	Tx      *database.Transaction
+	Changes *Changes
 
 	Outer *Environment
 }
@@ -59,6 +76,15 @@ func (e *Environment) Get(path document.Path) (v types.Value, ok bool) {
 		if err == nil {
 			return v, true
 		}
+
+		// if the variable exists but not the rest of the path,
+		// don't look it up in the outer environments, as this variable
+		// shadows any variable with the same name.
+		if len(path) > 1 {
+			if _, err := e.Vars.GetByField(path[0].FieldName); err == nil {
+				return types.NewNullValue(), false
+			}
+		}
 	}
 
 	if e.Outer != nil {
@@ -177,3 +203,17 @@ func (e *Environment) GetCatalog() *database.Catalog {
 
 	return nil
 }
+
+// GetChanges returns the changes of the statement being run,
+// or nil if they are not tracked.
+func (e *Environment) GetChanges() *Changes {
+	if e.Changes != nil {
+		return e.Changes
+	}
+
+	if outer := e.GetOuter(); outer != nil {
+		return outer.GetChanges()
+	}
+
+	return nil
+}
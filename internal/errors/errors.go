@@ -61,3 +61,31 @@ func IsNotFoundError(err error) bool {
 
 	return false
 }
+
+// ConflictError is returned when a transaction conflicts with another one,
+// for example when waiting for a lock would cause a deadlock or when a document
+// was modified by a transaction that committed after it started.
+// The transaction must be rolled back and can be retried.
+type ConflictError struct {
+	Reason string
+}
+
+func NewConflictError(reason string) error {
+	return &ConflictError{Reason: reason}
+}
+
+func (c ConflictError) Error() string {
+	return "transaction conflict: " + c.Reason
+}
+
+func IsConflictError(err error) bool {
+	for err != nil {
+		switch err.(type) {
+		case *ConflictError, ConflictError:
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+
+	return false
+}
@@ -149,25 +149,42 @@ func In(a, b Expr) Expr {
 }
 
 func (op *InOperator) Eval(env *environment.Environment) (types.Value, error) {
-	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
-		if a.Type() == types.NullValue || b.Type() == types.NullValue {
-			return NullLiteral, nil
-		}
-
-		if b.Type() != types.ArrayValue {
-			return FalseLiteral, nil
+	// a IN (SELECT ...) compares a with every value returned by the subquery
+	if sq, ok := op.b.(*Subquery); ok {
+		a, err := op.a.Eval(env)
+		if err != nil {
+			return NullLiteral, err
 		}
 
-		ok, err := document.ArrayContains(types.As[types.Array](b), a)
+		b, err := sq.EvalAll(env)
 		if err != nil {
 			return NullLiteral, err
 		}
 
-		if ok {
-			return TrueLiteral, nil
-		}
+		return in(a, b)
+	}
+
+	return op.simpleOperator.eval(env, in)
+}
+
+func in(a, b types.Value) (types.Value, error) {
+	if a.Type() == types.NullValue || b.Type() == types.NullValue {
+		return NullLiteral, nil
+	}
+
+	if b.Type() != types.ArrayValue {
 		return FalseLiteral, nil
-	})
+	}
+
+	ok, err := document.ArrayContains(types.As[types.Array](b), a)
+	if err != nil {
+		return NullLiteral, err
+	}
+
+	if ok {
+		return TrueLiteral, nil
+	}
+	return FalseLiteral, nil
 }
 
 type NotInOperator struct {
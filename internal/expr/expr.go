@@ -136,14 +136,23 @@ func Walk(e Expr, fn func(Expr) bool) bool {
 
 	switch t := e.(type) {
 	case Operator:
+		if b, ok := t.(*BetweenOperator); ok {
+			if !Walk(b.X, fn) {
+				return false
+			}
+		}
 		if !Walk(t.LeftHand(), fn) {
 			return false
 		}
 		if !Walk(t.RightHand(), fn) {
 			return false
 		}
+	case Parentheses:
+		return Walk(t.E, fn)
 	case *NamedExpr:
 		return Walk(t.Expr, fn)
+	case Exists:
+		return Walk(t.Subquery, fn)
 	case Function:
 		for _, p := range t.Params() {
 			if !Walk(p, fn) {
@@ -25,6 +25,13 @@ var builtinFunctions = Definitions{
 			return &PK{}, nil
 		},
 	},
+	"commit_sequence": &definition{
+		name:  "commit_sequence",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &CommitSequence{}, nil
+		},
+	},
 	"count": &definition{
 		name:  "count",
 		arity: 1,
@@ -67,6 +74,72 @@ var builtinFunctions = Definitions{
 			return &Len{Expr: args[0]}, nil
 		},
 	},
+	"now": &definition{
+		name:  "now",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Now{}, nil
+		},
+	},
+	"date_trunc": &definition{
+		name:  "date_trunc",
+		arity: 2,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &DateTrunc{Field: args[0], Expr: args[1]}, nil
+		},
+	},
+	"extract": &definition{
+		name:  "extract",
+		arity: 2,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Extract{Field: args[0], Expr: args[1]}, nil
+		},
+	},
+	"array_append":   arrayAppend,
+	"array_remove":   arrayRemove,
+	"array_contains": arrayContains,
+	"array_slice":    arraySlice,
+	"array_concat":   arrayConcat,
+	"document_keys":  documentKeys,
+	"document_merge": documentMerge,
+	"json_extract":   jsonExtract,
+	"row_number": &definition{
+		name:  "row_number",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &RowNumber{}, nil
+		},
+	},
+	"rank": &definition{
+		name:  "rank",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Rank{}, nil
+		},
+	},
+	"dense_rank": &definition{
+		name:  "dense_rank",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &DenseRank{}, nil
+		},
+	},
+	"lag": &optionalArgsDefinition{
+		name:     "lag",
+		minArity: 1,
+		arity:    3,
+		constructorFn: newOffsetFunction(func(e, offset, def expr.Expr) expr.Function {
+			return &Lag{Expr: e, Offset: offset, Default: def}
+		}),
+	},
+	"lead": &optionalArgsDefinition{
+		name:     "lead",
+		minArity: 1,
+		arity:    3,
+		constructorFn: newOffsetFunction(func(e, offset, def expr.Expr) expr.Function {
+			return &Lead{Expr: e, Offset: offset, Default: def}
+		}),
+	},
 }
 
 // BuiltinDefinitions returns a map of builtin functions.
@@ -168,6 +241,33 @@ func (k *PK) String() string {
 	return "pk()"
 }
 
+// CommitSequence represents the commit_sequence() function.
+// It returns the sequence number of the last committed write transaction.
+type CommitSequence struct{}
+
+// Eval returns the sequence number of the last committed write transaction.
+func (c *CommitSequence) Eval(env *environment.Environment) (types.Value, error) {
+	db := env.GetDB()
+	if db == nil {
+		return expr.NullLiteral, nil
+	}
+
+	return types.NewIntegerValue(int64(db.CommitSequence())), nil
+}
+
+func (*CommitSequence) Params() []expr.Expr { return nil }
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (c *CommitSequence) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*CommitSequence)
+	return ok
+}
+
+func (c *CommitSequence) String() string {
+	return "commit_sequence()"
+}
+
 var _ expr.AggregatorBuilder = (*Count)(nil)
 
 // Count is the COUNT aggregator function. It counts the number of documents
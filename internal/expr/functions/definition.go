@@ -23,8 +23,9 @@ type Packages map[string]Definitions
 
 func DefaultPackages() Packages {
 	return Packages{
-		"":     BuiltinDefinitions(),
-		"math": MathFunctions(),
+		"":        BuiltinDefinitions(),
+		"math":    MathFunctions(),
+		"strings": StringsFunctions(),
 	}
 }
 
@@ -0,0 +1,289 @@
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+var arrayAppend = &ScalarDefinition{
+	name:  "array_append",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_append(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		vb := document.NewValueBuffer()
+		err = vb.ScanArray(a)
+		if err != nil {
+			return nil, err
+		}
+		vb.Append(args[1])
+		return types.NewArrayValue(vb), nil
+	},
+}
+
+var arrayRemove = &ScalarDefinition{
+	name:  "array_remove",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_remove(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		vb := document.NewValueBuffer()
+		err = a.Iterate(func(i int, v types.Value) error {
+			ok, err := types.IsEqual(v, args[1])
+			if err != nil || ok {
+				return err
+			}
+			vb.Append(v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArrayValue(vb), nil
+	},
+}
+
+var arrayContains = &ScalarDefinition{
+	name:  "array_contains",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_contains(arg1, arg2)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+
+		ok, err := document.ArrayContains(a, args[1])
+		if err != nil {
+			return nil, err
+		}
+		return types.NewBoolValue(ok), nil
+	},
+}
+
+var arraySlice = &ScalarDefinition{
+	name:     "array_slice",
+	arity:    3,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		a, err := arrayArg("array_slice(arg1, arg2, arg3)", 1, args[0])
+		if err != nil || a == nil {
+			return types.NewNullValue(), err
+		}
+		n, ok, err := integerArgs(args[1:]...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		start, end := n[0], int64(-1)
+		if len(n) > 1 {
+			end = n[1]
+		}
+
+		vb := document.NewValueBuffer()
+		err = a.Iterate(func(i int, v types.Value) error {
+			if int64(i) >= start && (end < 0 || int64(i) < end) {
+				vb.Append(v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArrayValue(vb), nil
+	},
+}
+
+var arrayConcat = &ScalarDefinition{
+	name:     "array_concat",
+	arity:    2,
+	variadic: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		vb := document.NewValueBuffer()
+		for i := range args {
+			a, err := arrayArg("array_concat(arg1, arg2, ...)", i+1, args[i])
+			if err != nil || a == nil {
+				return types.NewNullValue(), err
+			}
+
+			err = vb.ScanArray(a)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return types.NewArrayValue(vb), nil
+	},
+}
+
+var documentKeys = &ScalarDefinition{
+	name:  "document_keys",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		d, err := documentArg("document_keys(arg1)", 1, args[0])
+		if err != nil || d == nil {
+			return types.NewNullValue(), err
+		}
+
+		vb := document.NewValueBuffer()
+		err = d.Iterate(func(field string, _ types.Value) error {
+			vb.Append(types.NewTextValue(field))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArrayValue(vb), nil
+	},
+}
+
+var documentMerge = &ScalarDefinition{
+	name:     "document_merge",
+	arity:    2,
+	variadic: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		fb := document.NewFieldBuffer()
+		for i := range args {
+			d, err := documentArg("document_merge(arg1, arg2, ...)", i+1, args[i])
+			if err != nil || d == nil {
+				return types.NewNullValue(), err
+			}
+
+			// fields of the following documents replace the existing ones
+			err = d.Iterate(func(field string, v types.Value) error {
+				err := fb.Replace(field, v)
+				if errors.Is(err, types.ErrFieldNotFound) {
+					fb.Add(field, v)
+					return nil
+				}
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return types.NewDocumentValue(fb), nil
+	},
+}
+
+var jsonExtract = &ScalarDefinition{
+	name:  "json_extract",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args[1])
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+		p, err := parseJSONPath(s[0])
+		if err != nil {
+			return nil, err
+		}
+
+		v := args[0]
+		if v.Type() == types.TextValue {
+			if strings.HasPrefix(strings.TrimSpace(types.As[string](v)), "[") {
+				v, err = document.CastAsArray(v)
+			} else {
+				v, err = document.CastAsDocument(v)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(p) == 0 {
+			return v, nil
+		}
+
+		switch v.Type() {
+		case types.NullValue:
+			return v, nil
+		case types.DocumentValue:
+			v, err = p.GetValueFromDocument(types.As[types.Document](v))
+		case types.ArrayValue:
+			v, err = p.GetValueFromArray(types.As[types.Array](v))
+		default:
+			return nil, fmt.Errorf("json_extract(arg1, arg2) expects arg1 to be a document or an array")
+		}
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return types.NewNullValue(), nil
+		}
+		return v, err
+	},
+}
+
+// parseJSONPath parses paths of the form $.a.b[1].c,
+// where the leading $ is optional.
+func parseJSONPath(s string) (document.Path, error) {
+	var p document.Path
+
+	rest := strings.TrimPrefix(s, "$")
+	for i := 0; rest != ""; i++ {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+			idx, err := strconv.Atoi(rest[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+			p = append(p, document.PathFragment{ArrayIndex: idx})
+			rest = rest[end+1:]
+		default:
+			// the first field of paths without $ doesn't start with a dot
+			if rest[0] == '.' {
+				rest = rest[1:]
+			} else if i > 0 || len(rest) != len(s) {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+			p = append(p, document.PathFragment{FieldName: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+
+	return p, nil
+}
+
+// arrayArg casts v, the argument of fn at position pos, to an array.
+// It returns nil if v is NULL.
+func arrayArg(fn string, pos int, v types.Value) (types.Array, error) {
+	if v.Type() == types.NullValue {
+		return nil, nil
+	}
+	a, err := document.CastAsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s expects arg%d to be an array", fn, pos)
+	}
+	return types.As[types.Array](a), nil
+}
+
+// documentArg casts v, the argument of fn at position pos, to a document.
+// It returns nil if v is NULL.
+func documentArg(fn string, pos int, v types.Value) (types.Document, error) {
+	if v.Type() == types.NullValue {
+		return nil, nil
+	}
+	d, err := document.CastAsDocument(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s expects arg%d to be a document", fn, pos)
+	}
+	return types.As[types.Document](d), nil
+}
@@ -15,9 +15,13 @@ import (
 // This difference allows to simply define them with a CallFn function that takes multiple document.Value and
 // return another types.Value, rather than having to manually evaluate expressions (see Definition).
 type ScalarDefinition struct {
-	name   string
-	arity  int
-	callFn func(...types.Value) (types.Value, error)
+	name  string
+	arity int
+	// number of trailing arguments that can be omitted.
+	optional int
+	// if true, the last argument can be repeated any number of times.
+	variadic bool
+	callFn   func(...types.Value) (types.Value, error)
 }
 
 func NewScalarDefinition(name string, arity int, callFn func(...types.Value) (types.Value, error)) *ScalarDefinition {
@@ -35,12 +39,24 @@ func (fd *ScalarDefinition) String() string {
 	for i := 0; i < fd.arity; i++ {
 		args = append(args, fmt.Sprintf("arg%d", i+1))
 	}
+	if fd.variadic {
+		args = append(args, "...")
+	}
 	return fmt.Sprintf("%s(%s)", fd.name, strings.Join(args, ", "))
 }
 
 // Function returns a Function expr node.
 func (fd *ScalarDefinition) Function(args ...expr.Expr) (expr.Function, error) {
-	if len(args) != fd.arity {
+	switch {
+	case fd.variadic:
+		if len(args) < fd.arity {
+			return nil, fmt.Errorf("%s takes at least %d argument(s), not %d", fd.String(), fd.arity, len(args))
+		}
+	case fd.optional > 0:
+		if len(args) < fd.arity-fd.optional || len(args) > fd.arity {
+			return nil, fmt.Errorf("%s takes between %d and %d arguments, not %d", fd.String(), fd.arity-fd.optional, fd.arity, len(args))
+		}
+	case len(args) != fd.arity:
 		return nil, fmt.Errorf("%s takes %d argument(s), not %d", fd.String(), fd.arity, len(args))
 	}
 	return &ScalarFunction{
@@ -50,6 +66,8 @@ func (fd *ScalarDefinition) Function(args ...expr.Expr) (expr.Function, error) {
 }
 
 // Arity returns the arity of the defined function.
+// For functions with optional arguments, it is the maximum number of arguments,
+// and for variadic functions, the minimum number of arguments.
 func (fd *ScalarDefinition) Arity() int {
 	return fd.arity
 }
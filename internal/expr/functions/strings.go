@@ -0,0 +1,340 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// StringsFunctions returns all strings package functions.
+func StringsFunctions() Definitions {
+	return stringsFunctions
+}
+
+var stringsFunctions = Definitions{
+	"lower":          lower,
+	"upper":          upper,
+	"trim":           trim,
+	"ltrim":          ltrim,
+	"rtrim":          rtrim,
+	"substr":         substr,
+	"replace":        replace,
+	"split":          split,
+	"concat_ws":      concatWS,
+	"position":       position,
+	"lpad":           lpad,
+	"rpad":           rpad,
+	"starts_with":    startsWith,
+	"regexp_replace": regexpReplace,
+}
+
+var lower = &ScalarDefinition{
+	name:  "lower",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		v, err := document.CastAsText(args[0])
+		if err != nil || v.Type() == types.NullValue {
+			return v, err
+		}
+		return types.NewTextValue(strings.ToLower(types.As[string](v))), nil
+	},
+}
+
+var upper = &ScalarDefinition{
+	name:  "upper",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		v, err := document.CastAsText(args[0])
+		if err != nil || v.Type() == types.NullValue {
+			return v, err
+		}
+		return types.NewTextValue(strings.ToUpper(types.As[string](v))), nil
+	},
+}
+
+var trim = &ScalarDefinition{
+	name:     "trim",
+	arity:    2,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return trimFunc(strings.Trim, args)
+	},
+}
+
+var ltrim = &ScalarDefinition{
+	name:     "ltrim",
+	arity:    2,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return trimFunc(strings.TrimLeft, args)
+	},
+}
+
+var rtrim = &ScalarDefinition{
+	name:     "rtrim",
+	arity:    2,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return trimFunc(strings.TrimRight, args)
+	},
+}
+
+// trimFunc removes the characters of the optional second argument,
+// or spaces, from the first argument using the given function.
+func trimFunc(fn func(string, string) string, args []types.Value) (types.Value, error) {
+	s, ok, err := textArgs(args...)
+	if err != nil || !ok {
+		return types.NewNullValue(), err
+	}
+
+	cutset := " "
+	if len(s) > 1 {
+		cutset = s[1]
+	}
+
+	return types.NewTextValue(fn(s[0], cutset)), nil
+}
+
+var substr = &ScalarDefinition{
+	name:     "substr",
+	arity:    3,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args[0])
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+		n, ok, err := integerArgs(args[1:]...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		r := []rune(s[0])
+
+		// positions start at 1, and characters before the first one
+		// count towards the length, as in PostgreSQL.
+		start, end := n[0], int64(len(r))+1
+		if len(n) > 1 {
+			if n[1] < 0 {
+				return nil, fmt.Errorf("substr(arg1, arg2, arg3) expects arg3 to be positive")
+			}
+			if start+n[1] < end {
+				end = start + n[1]
+			}
+		}
+		if start < 1 {
+			start = 1
+		}
+		if start >= end {
+			return types.NewTextValue(""), nil
+		}
+
+		return types.NewTextValue(string(r[start-1 : end-1])), nil
+	},
+}
+
+var replace = &ScalarDefinition{
+	name:  "replace",
+	arity: 3,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+		return types.NewTextValue(strings.ReplaceAll(s[0], s[1], s[2])), nil
+	},
+}
+
+var split = &ScalarDefinition{
+	name:  "split",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		var vb document.ValueBuffer
+		for _, part := range strings.Split(s[0], s[1]) {
+			vb.Append(types.NewTextValue(part))
+		}
+		return types.NewArrayValue(&vb), nil
+	},
+}
+
+var concatWS = &ScalarDefinition{
+	name:     "concat_ws",
+	arity:    2,
+	variadic: true,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		sep, ok, err := textArgs(args[0])
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		// NULL values are ignored
+		parts := make([]string, 0, len(args)-1)
+		for _, a := range args[1:] {
+			s, ok, err := textArgs(a)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				parts = append(parts, s[0])
+			}
+		}
+
+		return types.NewTextValue(strings.Join(parts, sep[0])), nil
+	},
+}
+
+var position = &ScalarDefinition{
+	name:  "position",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		i := strings.Index(s[0], s[1])
+		if i < 0 {
+			return types.NewIntegerValue(0), nil
+		}
+		return types.NewIntegerValue(int64(utf8.RuneCountInString(s[0][:i]) + 1)), nil
+	},
+}
+
+var lpad = &ScalarDefinition{
+	name:     "lpad",
+	arity:    3,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return padFunc(true, args)
+	},
+}
+
+var rpad = &ScalarDefinition{
+	name:     "rpad",
+	arity:    3,
+	optional: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		return padFunc(false, args)
+	},
+}
+
+// maxPadLength is the maximum length of the text returned by lpad and rpad,
+// which prevents allocating arbitrarily large texts.
+const maxPadLength = 10 * 1024 * 1024
+
+// padFunc fills the first argument up to the length given by the second argument,
+// with the characters of the optional third argument, or spaces.
+// If the text is longer than the length, it is truncated.
+func padFunc(left bool, args []types.Value) (types.Value, error) {
+	s, ok, err := textArgs(args[0])
+	if err != nil || !ok {
+		return types.NewNullValue(), err
+	}
+	n, ok, err := integerArgs(args[1])
+	if err != nil || !ok {
+		return types.NewNullValue(), err
+	}
+	fill := " "
+	if len(args) > 2 {
+		f, ok, err := textArgs(args[2])
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+		fill = f[0]
+	}
+
+	if n[0] > maxPadLength {
+		name := "rpad"
+		if left {
+			name = "lpad"
+		}
+		return nil, fmt.Errorf("%s(arg1, arg2, arg3) expects arg2 to be at most %d", name, maxPadLength)
+	}
+
+	r := []rune(s[0])
+	length := int(n[0])
+	if length < 0 {
+		length = 0
+	}
+	if len(r) >= length {
+		return types.NewTextValue(string(r[:length])), nil
+	}
+	if fill == "" {
+		return types.NewTextValue(s[0]), nil
+	}
+
+	// repeat the fill characters just enough to cover the missing length
+	missing := length - len(r)
+	count := utf8.RuneCountInString(fill)
+	pad := []rune(strings.Repeat(fill, (missing+count-1)/count))[:missing]
+	if left {
+		return types.NewTextValue(string(pad) + s[0]), nil
+	}
+	return types.NewTextValue(s[0] + string(pad)), nil
+}
+
+var startsWith = &ScalarDefinition{
+	name:  "starts_with",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+		return types.NewBoolValue(strings.HasPrefix(s[0], s[1])), nil
+	},
+}
+
+var regexpReplace = &ScalarDefinition{
+	name:  "regexp_replace",
+	arity: 3,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		s, ok, err := textArgs(args...)
+		if err != nil || !ok {
+			return types.NewNullValue(), err
+		}
+
+		re, err := regexp.Compile(s[1])
+		if err != nil {
+			return nil, fmt.Errorf("regexp_replace(arg1, arg2, arg3): invalid regular expression %q", s[1])
+		}
+		return types.NewTextValue(re.ReplaceAllString(s[0], s[2])), nil
+	},
+}
+
+// textArgs casts all the arguments to texts.
+// It returns false if any of them is NULL.
+func textArgs(args ...types.Value) ([]string, bool, error) {
+	s := make([]string, len(args))
+	for i, a := range args {
+		v, err := document.CastAsText(a)
+		if err != nil || v.Type() == types.NullValue {
+			return nil, false, err
+		}
+		s[i] = types.As[string](v)
+	}
+	return s, true, nil
+}
+
+// integerArgs casts all the arguments to integers.
+// It returns false if any of them is NULL.
+func integerArgs(args ...types.Value) ([]int64, bool, error) {
+	n := make([]int64, len(args))
+	for i, a := range args {
+		v, err := document.CastAsInteger(a)
+		if err != nil || v.Type() == types.NullValue {
+			return nil, false, err
+		}
+		n[i] = types.As[int64](v)
+	}
+	return n, true, nil
+}
@@ -33,3 +33,108 @@
 
 > typeof(NULL)
 'null'
+
+> typeof(CAST('2023-01-15' AS TIMESTAMP))
+'timestamp'
+
+-- test: now
+> typeof(now())
+'timestamp'
+
+> now() > '2023-01-01'
+true
+
+! now(1)
+'now() takes 0 argument(s), not 1'
+
+-- test: date_trunc
+> date_trunc('year', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-01-01T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('quarter', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-04-01T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('month', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-01T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('week', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-08T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('day', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-15T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('hour', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-15T10:00:00Z' AS TIMESTAMP)
+
+> date_trunc('MINUTE', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-15T10:30:00Z' AS TIMESTAMP)
+
+> date_trunc('second', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-15T10:30:45Z' AS TIMESTAMP)
+
+> date_trunc('millisecond', CAST('2023-05-15T10:30:45.123456Z' AS TIMESTAMP))
+CAST('2023-05-15T10:30:45.123Z' AS TIMESTAMP)
+
+> date_trunc('day', '2023-05-15T10:30:45Z')
+CAST('2023-05-15T00:00:00Z' AS TIMESTAMP)
+
+> date_trunc('day', NULL)
+NULL
+
+! date_trunc('fortnight', '2023-05-15T10:30:45Z')
+'unsupported precision "fortnight"'
+
+! date_trunc(1, '2023-05-15T10:30:45Z')
+'date_trunc(arg1, arg2) expects arg1 to be a text'
+
+! date_trunc('day', 1)
+'date_trunc(arg1, arg2) expects arg2 to be a timestamp'
+
+-- test: extract
+> extract('year', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+2023
+
+> extract('quarter', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+2
+
+> extract('month', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+5
+
+> extract('week', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+19
+
+> extract('day', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+14
+
+> extract('dow', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+0
+
+> extract('doy', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+134
+
+> extract('hour', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+10
+
+> extract('minute', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+30
+
+> extract('second', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+45
+
+> extract('millisecond', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+123
+
+> extract('microsecond', CAST('2023-05-14T10:30:45.123456Z' AS TIMESTAMP))
+123456
+
+> extract('epoch', CAST('2023-05-14T10:30:45.5Z' AS TIMESTAMP))
+1684060245.5
+
+> extract('year', '2023-05-14')
+2023
+
+> extract('year', NULL)
+NULL
+
+! extract('century', '2023-05-14')
+'unsupported field "century"'
@@ -0,0 +1,117 @@
+-- test: array_append
+> array_append(NULL, 1)
+NULL
+> array_append([1, 2], 3)
+[1, 2, 3]
+> array_append([], 'a')
+['a']
+> array_append([1], [2])
+[1, [2]]
+> array_append('[1, 2]', 3)
+[1, 2, 3]
+! array_append(1, 2)
+'array_append(arg1, arg2) expects arg1 to be an array'
+
+-- test: array_remove
+> array_remove(NULL, 1)
+NULL
+> array_remove([1, 2, 1, 3], 1)
+[2, 3]
+> array_remove([1, 2.0, 3], 2)
+[1, 3]
+> array_remove([1, 2], 4)
+[1, 2]
+! array_remove({a: 1}, 1)
+'array_remove(arg1, arg2) expects arg1 to be an array'
+
+-- test: array_contains
+> array_contains(NULL, 1)
+NULL
+> array_contains([1, 2, 3], 2)
+true
+> array_contains([1, 2, 3], 2.0)
+true
+> array_contains([1, 2, 3], 4)
+false
+> array_contains([[1], {a: 1}], {a: 1})
+true
+
+-- test: array_slice
+> array_slice(NULL, 1)
+NULL
+> array_slice([1, 2, 3, 4], NULL)
+NULL
+> array_slice([1, 2, 3, 4], 1)
+[2, 3, 4]
+> array_slice([1, 2, 3, 4], 1, 3)
+[2, 3]
+> array_slice([1, 2, 3, 4], 0, 10)
+[1, 2, 3, 4]
+> array_slice([1, 2, 3, 4], 3, 1)
+[]
+! array_slice([1, 2], 'a')
+'cannot cast "a" as integer'
+
+-- test: array_concat
+> array_concat([1, 2], [3])
+[1, 2, 3]
+> array_concat([1], [], [2, 3], [4])
+[1, 2, 3, 4]
+> array_concat([1], NULL)
+NULL
+! array_concat([1], 2)
+'array_concat(arg1, arg2, ...) expects arg2 to be an array'
+! array_concat([1])
+'takes at least 2 argument(s), not 1'
+
+-- test: document_keys
+> document_keys(NULL)
+NULL
+> document_keys({a: 1, b: {c: 2}})
+['a', 'b']
+> document_keys({})
+[]
+> document_keys('{"a": 1}')
+['a']
+! document_keys([1])
+'document_keys(arg1) expects arg1 to be a document'
+
+-- test: document_merge
+> document_merge({a: 1}, {b: 2})
+{a: 1, b: 2}
+> document_merge({a: 1, b: 2}, {b: 3}, {c: 4})
+{a: 1, b: 3, c: 4}
+> document_merge({a: {b: 1}}, {a: {c: 2}})
+{a: {c: 2}}
+> document_merge({a: 1}, NULL)
+NULL
+! document_merge({a: 1}, 1)
+'document_merge(arg1, arg2, ...) expects arg2 to be a document'
+
+-- test: json_extract
+> json_extract(NULL, '$.a')
+NULL
+> json_extract({a: 1}, NULL)
+NULL
+> json_extract({a: {b: [1, 2, {c: 3}]}}, '$.a.b[2].c')
+3
+> json_extract({a: {b: [1, 2, {c: 3}]}}, 'a.b[1]')
+2
+> json_extract({a: {b: 1}}, '$.a')
+{b: 1}
+> json_extract({a: 1}, '$')
+{a: 1}
+> json_extract({a: 1}, '$.b')
+NULL
+> json_extract([1, [2, 3]], '$[1][0]')
+2
+> json_extract('{"a": {"b": "c"}}', '$.a.b')
+'c'
+> json_extract('[1, 2]', '$[1]')
+2
+! json_extract({a: 1}, '$a')
+'invalid path "$a"'
+! json_extract({a: 1}, '$.a[x]')
+'invalid path "$.a[x]"'
+! json_extract(1, '$.a')
+'json_extract(arg1, arg2) expects arg1 to be a document or an array'
@@ -0,0 +1,153 @@
+-- test: strings.lower
+> strings.lower(NULL)
+NULL
+> strings.lower('Hello World')
+'hello world'
+> strings.lower(1)
+'1'
+
+-- test: strings.upper
+> strings.upper(NULL)
+NULL
+> strings.upper('Hello World')
+'HELLO WORLD'
+
+-- test: strings.trim
+> strings.trim(NULL)
+NULL
+> strings.trim('  foo  ')
+'foo'
+> strings.trim('xxfooxx', 'x')
+'foo'
+> strings.trim('foo', NULL)
+NULL
+! strings.trim()
+'takes between 1 and 2 arguments, not 0'
+! strings.trim('a', 'b', 'c')
+'takes between 1 and 2 arguments, not 3'
+
+-- test: strings.ltrim
+> strings.ltrim('  foo  ')
+'foo  '
+> strings.ltrim('xyfoo', 'yx')
+'foo'
+
+-- test: strings.rtrim
+> strings.rtrim('  foo  ')
+'  foo'
+> strings.rtrim('fooxy', 'yx')
+'foo'
+
+-- test: strings.substr
+> strings.substr(NULL, 1)
+NULL
+> strings.substr('hello', NULL)
+NULL
+> strings.substr('hello', 2)
+'ello'
+> strings.substr('hello', 2, 3)
+'ell'
+> strings.substr('hello', 0, 3)
+'he'
+> strings.substr('hello', 10)
+''
+> strings.substr('héllo', 2, 2)
+'él'
+> strings.substr('hello', '2', 3)
+'ell'
+! strings.substr('hello', 1, -1)
+'expects arg3 to be positive'
+! strings.substr('hello', 'a')
+'cannot cast "a" as integer'
+
+-- test: strings.replace
+> strings.replace(NULL, 'a', 'b')
+NULL
+> strings.replace('banana', 'an', 'AN')
+'bANANa'
+> strings.replace('banana', 'x', 'y')
+'banana'
+
+-- test: strings.split
+> strings.split(NULL, ',')
+NULL
+> strings.split('a,b,c', ',')
+['a', 'b', 'c']
+> strings.split('abc', ',')
+['abc']
+> strings.split('a,,b', ',')
+['a', '', 'b']
+
+-- test: strings.concat_ws
+> strings.concat_ws(',', 'a')
+'a'
+> strings.concat_ws(', ', 'a', 'b', 'c')
+'a, b, c'
+> strings.concat_ws(', ', 'a', NULL, 'c')
+'a, c'
+> strings.concat_ws('-', 1, true, 2.5)
+'1-true-2.5'
+> strings.concat_ws(NULL, 'a', 'b')
+NULL
+! strings.concat_ws(',')
+'takes at least 2 argument(s), not 1'
+
+-- test: strings.position
+> strings.position(NULL, 'a')
+NULL
+> strings.position('hello', 'l')
+3
+> strings.position('héllo', 'l')
+3
+> strings.position('hello', 'z')
+0
+
+-- test: strings.lpad
+> strings.lpad(NULL, 5)
+NULL
+> strings.lpad('hi', 5)
+'   hi'
+> strings.lpad('hi', 5, 'xy')
+'xyxhi'
+> strings.lpad('hello', 2)
+'he'
+> strings.lpad('hi', -1)
+''
+> strings.lpad('hi', 5, '')
+'hi'
+> strings.lpad('hi', 6, 'éà')
+'éàéàhi'
+! strings.lpad('a', 1000000000, 'x')
+'expects arg2 to be at most 10485760'
+
+-- test: strings.rpad
+> strings.rpad(NULL, 5)
+NULL
+> strings.rpad('hi', 5)
+'hi   '
+> strings.rpad('hi', 5, 'xy')
+'hixyx'
+> strings.rpad('hello', 2)
+'he'
+> strings.rpad('hi', 5, 'éàè')
+'hiéàè'
+! strings.rpad('a', 1000000000)
+'expects arg2 to be at most 10485760'
+
+-- test: strings.starts_with
+> strings.starts_with(NULL, 'a')
+NULL
+> strings.starts_with('hello', 'he')
+true
+> strings.starts_with('hello', 'lo')
+false
+
+-- test: strings.regexp_replace
+> strings.regexp_replace(NULL, 'a', 'b')
+NULL
+> strings.regexp_replace('foo123bar45', '[0-9]+', '#')
+'foo#bar#'
+> strings.regexp_replace('john smith', '([a-z]+) ([a-z]+)', '$2 $1')
+'smith john'
+! strings.regexp_replace('foo', '[', 'b')
+'invalid regular expression'
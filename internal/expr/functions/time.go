@@ -0,0 +1,212 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/types"
+)
+
+// Now represents the now() function.
+// It returns the current timestamp.
+type Now struct{}
+
+// Eval returns the current timestamp.
+func (n *Now) Eval(env *environment.Environment) (types.Value, error) {
+	return types.NewTimestampValue(time.Now()), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (n *Now) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*Now)
+	return ok
+}
+
+func (*Now) Params() []expr.Expr { return nil }
+
+func (n *Now) String() string {
+	return "NOW()"
+}
+
+// DateTrunc represents the date_trunc() function.
+// It truncates a timestamp to the given precision, which must be one of
+// microsecond, millisecond, second, minute, hour, day, week, month, quarter or year.
+// Weeks start on monday.
+type DateTrunc struct {
+	Field expr.Expr
+	Expr  expr.Expr
+}
+
+// Eval truncates the timestamp.
+func (d *DateTrunc) Eval(env *environment.Environment) (types.Value, error) {
+	field, ts, err := evalTimestampParams("date_trunc", env, d.Field, d.Expr)
+	if err != nil || ts.Type() == types.NullValue {
+		return ts, err
+	}
+
+	t := types.As[time.Time](ts)
+
+	switch field {
+	case "microsecond":
+		t = t.Truncate(time.Microsecond)
+	case "millisecond":
+		t = t.Truncate(time.Millisecond)
+	case "second":
+		t = t.Truncate(time.Second)
+	case "minute":
+		t = t.Truncate(time.Minute)
+	case "hour":
+		t = t.Truncate(time.Hour)
+	case "day":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "week":
+		// time.Weekday starts on sunday
+		offset := (int(t.Weekday()) + 6) % 7
+		t = time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "quarter":
+		t = time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		t = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return nil, fmt.Errorf("date_trunc(arg1, arg2): unsupported precision %q", field)
+	}
+
+	return types.NewTimestampValue(t), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (d *DateTrunc) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*DateTrunc)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(d.Field, o.Field) && expr.Equal(d.Expr, o.Expr)
+}
+
+func (d *DateTrunc) Params() []expr.Expr { return []expr.Expr{d.Field, d.Expr} }
+
+func (d *DateTrunc) String() string {
+	return fmt.Sprintf("DATE_TRUNC(%v, %v)", d.Field, d.Expr)
+}
+
+// Extract represents the extract() function.
+// It returns a part of a timestamp, which must be one of
+// year, quarter, month, week, day, dow, doy, hour, minute, second,
+// millisecond, microsecond or epoch.
+// Weeks are ISO 8601 weeks, dow goes from 0 (sunday) to 6 (saturday),
+// millisecond and microsecond return the fractional part of the seconds
+// and epoch returns the number of seconds since January 1, 1970 UTC as a double.
+type Extract struct {
+	Field expr.Expr
+	Expr  expr.Expr
+}
+
+// Eval extracts the field from the timestamp.
+func (e *Extract) Eval(env *environment.Environment) (types.Value, error) {
+	field, ts, err := evalTimestampParams("extract", env, e.Field, e.Expr)
+	if err != nil || ts.Type() == types.NullValue {
+		return ts, err
+	}
+
+	t := types.As[time.Time](ts)
+
+	var n int
+	switch field {
+	case "year":
+		n = t.Year()
+	case "quarter":
+		n = (int(t.Month())-1)/3 + 1
+	case "month":
+		n = int(t.Month())
+	case "week":
+		_, n = t.ISOWeek()
+	case "day":
+		n = t.Day()
+	case "dow":
+		n = int(t.Weekday())
+	case "doy":
+		n = t.YearDay()
+	case "hour":
+		n = t.Hour()
+	case "minute":
+		n = t.Minute()
+	case "second":
+		n = t.Second()
+	case "millisecond":
+		n = t.Nanosecond() / int(time.Millisecond)
+	case "microsecond":
+		n = t.Nanosecond() / int(time.Microsecond)
+	case "epoch":
+		return types.NewDoubleValue(float64(t.UnixNano()) / float64(time.Second)), nil
+	default:
+		return nil, fmt.Errorf("extract(arg1, arg2): unsupported field %q", field)
+	}
+
+	return types.NewIntegerValue(int64(n)), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (e *Extract) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Extract)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(e.Field, o.Field) && expr.Equal(e.Expr, o.Expr)
+}
+
+func (e *Extract) Params() []expr.Expr { return []expr.Expr{e.Field, e.Expr} }
+
+func (e *Extract) String() string {
+	return fmt.Sprintf("EXTRACT(%v, %v)", e.Field, e.Expr)
+}
+
+// evalTimestampParams evaluates the parameters of functions taking
+// the name of a field as a text and a timestamp.
+// Texts are converted to timestamps.
+// If the timestamp is NULL, it returns a NULL value.
+func evalTimestampParams(name string, env *environment.Environment, fieldExpr, tsExpr expr.Expr) (string, types.Value, error) {
+	f, err := fieldExpr.Eval(env)
+	if err != nil {
+		return "", nil, err
+	}
+	if f.Type() != types.TextValue {
+		return "", nil, fmt.Errorf("%s(arg1, arg2) expects arg1 to be a text", name)
+	}
+
+	ts, err := tsExpr.Eval(env)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch ts.Type() {
+	case types.NullValue, types.TimestampValue:
+	case types.TextValue:
+		ts, err = document.CastAsTimestamp(ts)
+		if err != nil {
+			return "", nil, err
+		}
+	default:
+		return "", nil, fmt.Errorf("%s(arg1, arg2) expects arg2 to be a timestamp", name)
+	}
+
+	return strings.ToLower(types.As[string](f)), ts, nil
+}
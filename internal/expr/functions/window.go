@@ -0,0 +1,286 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/types"
+)
+
+// An optionalArgsDefinition is the definition of a function
+// whose last arguments are optional.
+type optionalArgsDefinition struct {
+	name          string
+	minArity      int
+	arity         int
+	constructorFn func(...expr.Expr) (expr.Function, error)
+}
+
+func (fd *optionalArgsDefinition) Name() string {
+	return fd.name
+}
+
+func (fd *optionalArgsDefinition) Function(args ...expr.Expr) (expr.Function, error) {
+	if len(args) < fd.minArity || len(args) > fd.arity {
+		return nil, fmt.Errorf("%s() takes between %d and %d arguments, not %d", fd.name, fd.minArity, fd.arity, len(args))
+	}
+	return fd.constructorFn(args...)
+}
+
+func (fd *optionalArgsDefinition) String() string {
+	args := make([]string, 0, fd.arity)
+	for i := 0; i < fd.arity; i++ {
+		args = append(args, fmt.Sprintf("arg%d", i+1))
+	}
+	return fmt.Sprintf("%s(%s)", fd.name, strings.Join(args, ", "))
+}
+
+// Arity returns the maximum number of arguments.
+func (fd *optionalArgsDefinition) Arity() int {
+	return fd.arity
+}
+
+// evalOutsideWindow returns the error returned when a window function
+// is evaluated without an OVER clause.
+func evalOutsideWindow(fn expr.Expr) error {
+	return errors.Errorf("misuse of window function %s", fn)
+}
+
+var (
+	_ expr.WindowFunction = (*RowNumber)(nil)
+	_ expr.WindowFunction = (*Rank)(nil)
+	_ expr.WindowFunction = (*DenseRank)(nil)
+	_ expr.WindowFunction = (*Lag)(nil)
+	_ expr.WindowFunction = (*Lead)(nil)
+)
+
+// RowNumber is the ROW_NUMBER window function.
+// It returns the position of the current document within its partition, starting at 1.
+type RowNumber struct{}
+
+func (r *RowNumber) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, evalOutsideWindow(r)
+}
+
+// EvalWindow implements the expr.WindowFunction interface.
+func (r *RowNumber) EvalWindow(pos *expr.WindowPosition) (types.Value, error) {
+	return types.NewIntegerValue(int64(pos.Index) + 1), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *RowNumber) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*RowNumber)
+	return ok
+}
+
+func (r *RowNumber) Params() []expr.Expr { return nil }
+
+func (r *RowNumber) String() string {
+	return "ROW_NUMBER()"
+}
+
+// Rank is the RANK window function.
+// It returns the position of the first document having the same ORDER BY values
+// as the current document, starting at 1. Documents with the same values
+// have the same rank, leaving gaps in the sequence.
+type Rank struct{}
+
+func (r *Rank) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, evalOutsideWindow(r)
+}
+
+// EvalWindow implements the expr.WindowFunction interface.
+func (r *Rank) EvalWindow(pos *expr.WindowPosition) (types.Value, error) {
+	return types.NewIntegerValue(int64(pos.PeerIndex) + 1), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *Rank) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*Rank)
+	return ok
+}
+
+func (r *Rank) Params() []expr.Expr { return nil }
+
+func (r *Rank) String() string {
+	return "RANK()"
+}
+
+// DenseRank is the DENSE_RANK window function.
+// It works like RANK but without gaps in the sequence.
+type DenseRank struct{}
+
+func (r *DenseRank) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, evalOutsideWindow(r)
+}
+
+// EvalWindow implements the expr.WindowFunction interface.
+func (r *DenseRank) EvalWindow(pos *expr.WindowPosition) (types.Value, error) {
+	return types.NewIntegerValue(int64(pos.PeerGroup) + 1), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (r *DenseRank) IsEqual(other expr.Expr) bool {
+	_, ok := other.(*DenseRank)
+	return ok
+}
+
+func (r *DenseRank) Params() []expr.Expr { return nil }
+
+func (r *DenseRank) String() string {
+	return "DENSE_RANK()"
+}
+
+// Lag is the LAG window function.
+// It evaluates Expr on the document located Offset documents before the current one
+// in the partition. If there is no such document, it returns Default.
+// Offset defaults to 1 and Default to NULL.
+type Lag struct {
+	Expr    expr.Expr
+	Offset  expr.Expr
+	Default expr.Expr
+}
+
+func (l *Lag) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, evalOutsideWindow(l)
+}
+
+// EvalWindow implements the expr.WindowFunction interface.
+func (l *Lag) EvalWindow(pos *expr.WindowPosition) (types.Value, error) {
+	return evalOffset(pos, l.Expr, l.Offset, l.Default, -1)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (l *Lag) IsEqual(other expr.Expr) bool {
+	o, ok := other.(*Lag)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(l.Expr, o.Expr) && expr.Equal(l.Offset, o.Offset) && expr.Equal(l.Default, o.Default)
+}
+
+func (l *Lag) Params() []expr.Expr { return offsetParams(l.Expr, l.Offset, l.Default) }
+
+func (l *Lag) String() string {
+	return offsetString("LAG", l.Expr, l.Offset, l.Default)
+}
+
+// Lead is the LEAD window function.
+// It evaluates Expr on the document located Offset documents after the current one
+// in the partition. If there is no such document, it returns Default.
+// Offset defaults to 1 and Default to NULL.
+type Lead struct {
+	Expr    expr.Expr
+	Offset  expr.Expr
+	Default expr.Expr
+}
+
+func (l *Lead) Eval(env *environment.Environment) (types.Value, error) {
+	return nil, evalOutsideWindow(l)
+}
+
+// EvalWindow implements the expr.WindowFunction interface.
+func (l *Lead) EvalWindow(pos *expr.WindowPosition) (types.Value, error) {
+	return evalOffset(pos, l.Expr, l.Offset, l.Default, 1)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (l *Lead) IsEqual(other expr.Expr) bool {
+	o, ok := other.(*Lead)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(l.Expr, o.Expr) && expr.Equal(l.Offset, o.Offset) && expr.Equal(l.Default, o.Default)
+}
+
+func (l *Lead) Params() []expr.Expr { return offsetParams(l.Expr, l.Offset, l.Default) }
+
+func (l *Lead) String() string {
+	return offsetString("LEAD", l.Expr, l.Offset, l.Default)
+}
+
+// evalOffset evaluates e on the document located offset documents away from the current one,
+// in the given direction.
+func evalOffset(pos *expr.WindowPosition, e, offset, def expr.Expr, direction int) (types.Value, error) {
+	cur := pos.Rows[pos.Index]
+
+	n := int64(1)
+	if offset != nil {
+		v, err := offset.Eval(cur)
+		if err != nil {
+			return nil, err
+		}
+		if v.Type() != types.IntegerValue || types.As[int64](v) < 0 {
+			return nil, errors.Errorf("offset must be a positive integer, got %s", v)
+		}
+		n = types.As[int64](v)
+	}
+
+	i := int64(pos.Index) + n*int64(direction)
+	if i < 0 || i >= int64(len(pos.Rows)) {
+		if def == nil {
+			return types.NewNullValue(), nil
+		}
+
+		return def.Eval(cur)
+	}
+
+	v, err := e.Eval(pos.Rows[i])
+	if errors.Is(err, types.ErrFieldNotFound) {
+		return types.NewNullValue(), nil
+	}
+	return v, err
+}
+
+func offsetParams(e, offset, def expr.Expr) []expr.Expr {
+	params := []expr.Expr{e}
+	if offset != nil {
+		params = append(params, offset)
+	}
+	if def != nil {
+		params = append(params, def)
+	}
+
+	return params
+}
+
+func offsetString(name string, e, offset, def expr.Expr) string {
+	var s strings.Builder
+
+	s.WriteString(name)
+	s.WriteRune('(')
+	for i, p := range offsetParams(e, offset, def) {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(p.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
+
+// newOffsetFunction returns a constructor for the lag and lead functions.
+func newOffsetFunction(fn func(e, offset, def expr.Expr) expr.Function) func(args ...expr.Expr) (expr.Function, error) {
+	return func(args ...expr.Expr) (expr.Function, error) {
+		var offset, def expr.Expr
+		if len(args) > 1 {
+			offset = args[1]
+		}
+		if len(args) > 2 {
+			def = args[2]
+		}
+
+		return fn(args[0], offset, def), nil
+	}
+}
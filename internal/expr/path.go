@@ -17,17 +17,39 @@ func (p Path) Eval(env *environment.Environment) (types.Value, error) {
 		return NullLiteral, nil
 	}
 
+	dp := document.Path(p)
+
 	d, ok := env.GetDocument()
 	if !ok {
+		// without a document, paths can only refer to variables,
+		// like the OLD and NEW documents of triggers.
+		if v, ok := env.Get(dp); ok {
+			return v, nil
+		}
+
 		return NullLiteral, types.ErrFieldNotFound
 	}
-	dp := document.Path(p)
 
+	// a path made of a single field name always refers to a field of the current document
+	// if it exists, even if a table or an alias has the same name.
+	if len(dp) == 1 {
+		v, err := dp.GetValueFromDocument(d)
+		if err == nil || !errors.Is(err, types.ErrFieldNotFound) {
+			return v, err
+		}
+	}
+
+	// qualified paths, like "a.b", are looked up in the variables first.
+	// Tables and aliases are stored there by the operators that read from them.
 	v, ok := env.Get(dp)
 	if ok {
 		return v, nil
 	}
 
+	if len(dp) == 1 {
+		return NullLiteral, nil
+	}
+
 	v, err := dp.GetValueFromDocument(d)
 	if errors.Is(err, types.ErrFieldNotFound) {
 		return NullLiteral, nil
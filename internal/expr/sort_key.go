@@ -0,0 +1,45 @@
+package expr
+
+import "strings"
+
+// A SortKey is an expression used to sort documents,
+// along with the direction of the sort.
+// By default, NULL values are considered lower than any other value:
+// they are returned first in ascending order and last in descending order.
+type SortKey struct {
+	Expr       Expr
+	Desc       bool
+	NullsFirst bool
+	NullsLast  bool
+}
+
+// SortsNullsFirst reports whether NULL values must be returned before the other values.
+func (k SortKey) SortsNullsFirst() bool {
+	if k.NullsFirst || k.NullsLast {
+		return k.NullsFirst
+	}
+
+	return !k.Desc
+}
+
+// IsEqual returns true if both keys sort documents the same way.
+func (k SortKey) IsEqual(other SortKey) bool {
+	return Equal(k.Expr, other.Expr) && k.Desc == other.Desc && k.SortsNullsFirst() == other.SortsNullsFirst()
+}
+
+func (k SortKey) String() string {
+	var s strings.Builder
+
+	s.WriteString(k.Expr.String())
+	if k.Desc {
+		s.WriteString(" DESC")
+	}
+	if k.NullsFirst {
+		s.WriteString(" NULLS FIRST")
+	}
+	if k.NullsLast {
+		s.WriteString(" NULLS LAST")
+	}
+
+	return s.String()
+}
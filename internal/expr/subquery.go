@@ -0,0 +1,169 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// errStopIteration is used to stop the iteration of a subquery early.
+var errStopIteration = errors.New("stop")
+
+// A SubqueryStream is the prepared stream of a subquery.
+type SubqueryStream interface {
+	Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error
+}
+
+// A Subquery is a SELECT statement used as an expression.
+// The statement must be prepared and its stream stored in Stream
+// before the subquery can be evaluated.
+// The stream is evaluated using the environment of the outer query, which allows
+// the subquery to reference the tables of the outer query, using qualified paths.
+type Subquery struct {
+	// Stmt is the parsed SELECT statement.
+	Stmt fmt.Stringer
+	// Stream is the prepared stream of the statement.
+	Stream SubqueryStream
+}
+
+// Eval returns the first field of the only document returned by the subquery.
+// If the subquery doesn't return any document, it returns NULL.
+// It returns an error if the subquery returns more than one document,
+// or if the documents have more than one field.
+func (s *Subquery) Eval(env *environment.Environment) (types.Value, error) {
+	var v types.Value
+
+	err := s.iterate(env, func(fv types.Value) error {
+		if v != nil {
+			return errors.New("more than one row returned by a subquery used as an expression")
+		}
+
+		v = fv
+		return nil
+	})
+	if err != nil {
+		return NullLiteral, err
+	}
+
+	if v == nil {
+		return NullLiteral, nil
+	}
+
+	return v, nil
+}
+
+// EvalAll returns the first field of every document returned by the subquery, as an array.
+func (s *Subquery) EvalAll(env *environment.Environment) (types.Value, error) {
+	vb := document.NewValueBuffer()
+
+	err := s.iterate(env, func(fv types.Value) error {
+		vb.Append(fv)
+		return nil
+	})
+	if err != nil {
+		return NullLiteral, err
+	}
+
+	return types.NewArrayValue(vb), nil
+}
+
+// iterate calls fn with the value of the only field of every document returned by the subquery.
+func (s *Subquery) iterate(env *environment.Environment, fn func(v types.Value) error) error {
+	if s.Stream == nil {
+		return errors.New("subquery not prepared")
+	}
+
+	return s.Stream.Iterate(env, func(out *environment.Environment) error {
+		if out.Doc == nil {
+			return nil
+		}
+
+		var v types.Value
+		err := out.Doc.Iterate(func(field string, value types.Value) error {
+			if v != nil {
+				return errors.New("subquery must return only one column")
+			}
+
+			v = value
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return errors.New("subquery must return one column")
+		}
+
+		// the value may be reused by the stream
+		v, err = document.CloneValue(v)
+		if err != nil {
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (s *Subquery) IsEqual(other Expr) bool {
+	o, ok := other.(*Subquery)
+	if !ok {
+		return false
+	}
+
+	return s.Stmt.String() == o.Stmt.String()
+}
+
+func (s *Subquery) String() string {
+	return fmt.Sprintf("(%s)", s.Stmt)
+}
+
+// Exists is an expression that returns true if a subquery returns at least one document.
+type Exists struct {
+	Subquery *Subquery
+}
+
+// Eval runs the subquery and returns true as soon as a document is returned.
+func (e Exists) Eval(env *environment.Environment) (types.Value, error) {
+	if e.Subquery.Stream == nil {
+		return NullLiteral, errors.New("subquery not prepared")
+	}
+
+	var found bool
+	err := e.Subquery.Stream.Iterate(env, func(out *environment.Environment) error {
+		if out.Doc == nil {
+			return nil
+		}
+
+		found = true
+		return errStopIteration
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return NullLiteral, err
+	}
+
+	if found {
+		return TrueLiteral, nil
+	}
+
+	return FalseLiteral, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (e Exists) IsEqual(other Expr) bool {
+	o, ok := other.(Exists)
+	if !ok {
+		return false
+	}
+
+	return e.Subquery.IsEqual(o.Subquery)
+}
+
+func (e Exists) String() string {
+	return fmt.Sprintf("EXISTS %s", e.Subquery)
+}
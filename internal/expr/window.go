@@ -0,0 +1,125 @@
+package expr
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// A WindowFunction is a function whose value depends on the position
+// of the current document within its window partition, like ROW_NUMBER() or LAG().
+// It can only be used with an OVER clause.
+type WindowFunction interface {
+	Function
+
+	// EvalWindow returns the value of the function for the current document of the partition.
+	EvalWindow(pos *WindowPosition) (types.Value, error)
+}
+
+// A WindowPosition describes the position of a document within its window partition.
+type WindowPosition struct {
+	// Rows contains the environments of every document of the partition, in order.
+	Rows []*environment.Environment
+	// Index is the position of the current document in Rows.
+	Index int
+	// PeerIndex is the position of the first document having the same
+	// ORDER BY values as the current one.
+	PeerIndex int
+	// PeerGroup is the number of distinct ORDER BY values found in the partition
+	// before the ones of the current document.
+	PeerGroup int
+}
+
+// A Window is a function evaluated over a set of documents related to the current one,
+// as described by its OVER clause:
+//   fn OVER (PARTITION BY expr, ... ORDER BY expr [ASC|DESC], ...)
+// Unlike aggregators, which produce one document per group, window functions are computed
+// for every document of the stream.
+// The value of the function must be computed beforehand by a window operator,
+// which stores it in the environment under WindowsKey.
+type Window struct {
+	// Func is either a WindowFunction or an AggregatorBuilder.
+	Func        Expr
+	PartitionBy []Expr
+	OrderBy     []SortKey
+}
+
+// Eval returns the value computed for the current document by the window operator.
+func (w *Window) Eval(env *environment.Environment) (types.Value, error) {
+	p := document.Path{environment.WindowsKey[0], document.PathFragment{FieldName: w.String()}}
+
+	v, ok := env.Get(p)
+	if !ok {
+		return NullLiteral, errors.Errorf("window function %s cannot be evaluated here", w)
+	}
+
+	return v, nil
+}
+
+// HasSameWindow returns true if both windows partition and order the documents
+// the same way.
+func (w *Window) HasSameWindow(other *Window) bool {
+	if len(w.PartitionBy) != len(other.PartitionBy) || len(w.OrderBy) != len(other.OrderBy) {
+		return false
+	}
+
+	for i := range w.PartitionBy {
+		if !Equal(w.PartitionBy[i], other.PartitionBy[i]) {
+			return false
+		}
+	}
+
+	for i := range w.OrderBy {
+		if !w.OrderBy[i].IsEqual(other.OrderBy[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (w *Window) IsEqual(other Expr) bool {
+	o, ok := other.(*Window)
+	if !ok {
+		return false
+	}
+
+	return Equal(w.Func, o.Func) && w.HasSameWindow(o)
+}
+
+func (w *Window) String() string {
+	var s strings.Builder
+
+	s.WriteString(w.Func.String())
+	s.WriteString(" OVER (")
+
+	for i, e := range w.PartitionBy {
+		if i == 0 {
+			s.WriteString("PARTITION BY ")
+		} else {
+			s.WriteString(", ")
+		}
+		s.WriteString(e.String())
+	}
+
+	for i, k := range w.OrderBy {
+		if i == 0 {
+			if len(w.PartitionBy) > 0 {
+				s.WriteRune(' ')
+			}
+			s.WriteString("ORDER BY ")
+		} else {
+			s.WriteString(", ")
+		}
+		s.WriteString(k.String())
+	}
+
+	s.WriteRune(')')
+
+	return s.String()
+}
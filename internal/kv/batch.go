@@ -21,8 +21,10 @@ type BatchSession struct {
 	DB              *pebble.DB
 	Batch           *pebble.Batch
 	closed          bool
+	flushed         bool
 	rollbackSegment *RollbackSegment
 	maxBatchSize    int
+	bytesRead       int64
 }
 
 func (s *BatchSession) Commit() error {
@@ -45,20 +47,29 @@ func (s *BatchSession) Commit() error {
 	return s.Close()
 }
 
+// Close the session. If the session wasn't committed,
+// the intermediary batches are rolled back.
 func (s *BatchSession) Close() error {
 	if s.closed {
 		return errors.New("already closed")
 	}
 	s.closed = true
 
-	s.Store.UnlockSharedSnapshot()
+	err := s.rollbackSegment.Rollback()
+
+	s.Store.releaseBatchSession(s.flushed)
+
+	if err != nil {
+		_ = s.Batch.Close()
+		return err
+	}
 
 	return s.Batch.Close()
 }
 
 // Get returns a value associated with the given key. If not found, returns ErrKeyNotFound.
 func (s *BatchSession) Get(k []byte) ([]byte, error) {
-	return get(s.Batch, k)
+	return get(s.Batch, k, &s.bytesRead)
 }
 
 // Exists returns whether a key exists and is visible by the current session.
@@ -66,12 +77,26 @@ func (s *BatchSession) Exists(k []byte) (bool, error) {
 	return exists(s.Batch, k)
 }
 
+// BytesRead returns the number of bytes of keys and values read from the session.
+func (s *BatchSession) BytesRead() int64 {
+	return s.bytesRead
+}
+
 func (s *BatchSession) ensureBatchSize() error {
 	if s.Batch.Len() < s.maxBatchSize {
 		return nil
 	}
 
-	// The batch is too large. Insert the rollback segments and commit the batch.
+	// The batch is too large. If other write sessions are open,
+	// keep it in memory: they must not see uncommitted changes.
+	if !s.flushed {
+		if !s.Store.startFlushing() {
+			return nil
+		}
+		s.flushed = true
+	}
+
+	// Insert the rollback segments and commit the batch.
 	err := s.rollbackSegment.Apply(s.Batch)
 	if err != nil {
 		return err
@@ -108,6 +133,10 @@ func (s *BatchSession) Insert(k, v []byte) error {
 		return ErrKeyAlreadyExists
 	}
 
+	if s.rollbackSegment.savepointNeedsValue(k) {
+		s.rollbackSegment.recordSavepointValue(k, nil)
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpInsert)
 
 	err = s.Batch.Set(k, v, nil)
@@ -128,9 +157,14 @@ func (s *BatchSession) Put(k, v []byte) error {
 		return errors.New("cannot store empty value")
 	}
 
+	err := s.recordSavepointValue(k)
+	if err != nil {
+		return err
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpSet)
 
-	err := s.Batch.Set(k, v, nil)
+	err = s.Batch.Set(k, v, nil)
 	if err != nil {
 		return err
 	}
@@ -140,9 +174,14 @@ func (s *BatchSession) Put(k, v []byte) error {
 
 // Delete a record by key. If the key doesn't exist, it doesn't do anything.
 func (s *BatchSession) Delete(k []byte) error {
+	err := s.recordSavepointValue(k)
+	if err != nil {
+		return err
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpDel)
 
-	err := s.Batch.Delete(k, nil)
+	err = s.Batch.Delete(k, nil)
 	if err != nil {
 		return err
 	}
@@ -169,6 +208,64 @@ func (s *BatchSession) DeleteRange(start []byte, end []byte) error {
 	return nil
 }
 
-func (s *BatchSession) Iterator(opts *pebble.IterOptions) *pebble.Iterator {
-	return s.Batch.NewIter(opts)
+// recordSavepointValue stores the current value of the key in the last savepoint
+// before it gets modified.
+func (s *BatchSession) recordSavepointValue(k []byte) error {
+	if !s.rollbackSegment.savepointNeedsValue(k) {
+		return nil
+	}
+
+	v, err := get(s.Batch, k, nil)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return err
+	}
+
+	s.rollbackSegment.recordSavepointValue(k, v)
+	return nil
+}
+
+// Savepoint creates a savepoint with the given name.
+// Savepoints can be nested and names don't need to be unique.
+func (s *BatchSession) Savepoint(name string) {
+	s.rollbackSegment.Savepoint(name)
+}
+
+// RollbackToSavepoint undoes all the modifications made since the most recent
+// savepoint with the given name. Savepoints created after it are removed,
+// the savepoint itself remains active.
+func (s *BatchSession) RollbackToSavepoint(name string) error {
+	values, err := s.rollbackSegment.rollbackToSavepoint(name)
+	if err != nil {
+		return err
+	}
+
+	for _, sv := range values {
+		if sv.value == nil {
+			s.rollbackSegment.EnqueueOp(sv.key, kvOpDel)
+			err = s.Batch.Delete(sv.key, nil)
+		} else {
+			s.rollbackSegment.EnqueueOp(sv.key, kvOpSet)
+			err = s.Batch.Set(sv.key, sv.value, nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.ensureBatchSize()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReleaseSavepoint removes the most recent savepoint with the given name
+// and all the savepoints created after it, keeping the modifications.
+func (s *BatchSession) ReleaseSavepoint(name string) error {
+	return s.rollbackSegment.ReleaseSavepoint(name)
+}
+
+func (s *BatchSession) Iterator(opts *pebble.IterOptions) *Iterator {
+	return newIterator(s.Batch.NewIter(opts), &s.bytesRead)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 	"github.com/genjidb/genji/internal/encoding"
 )
@@ -22,6 +23,20 @@ type RollbackSegment struct {
 	buf              []byte
 	seen             map[string]struct{}
 	segmentCommitted bool
+	savepoints       []*savepoint
+}
+
+// A savepoint records the value of every key modified after it was created,
+// as it was before the first modification. A nil value means the key didn't exist.
+type savepoint struct {
+	name   string
+	values []savepointValue
+	seen   map[string]struct{}
+}
+
+type savepointValue struct {
+	key   []byte
+	value []byte
 }
 
 type operation struct {
@@ -102,6 +117,7 @@ func (s *RollbackSegment) Apply(b *pebble.Batch) error {
 
 func (s *RollbackSegment) Rollback() error {
 	if !s.segmentCommitted {
+		s.reset()
 		return nil
 	}
 
@@ -143,7 +159,13 @@ func (s *RollbackSegment) Rollback() error {
 	// we don't need to sync here.
 	// in case of a crash, the rollback segment will be rolled back
 	// during the next recovery.
-	return b.Commit(pebble.NoSync)
+	err = b.Commit(pebble.NoSync)
+	if err != nil {
+		return err
+	}
+
+	s.reset()
+	return nil
 }
 
 func (s *RollbackSegment) Clear(b *pebble.Batch) error {
@@ -166,4 +188,91 @@ func (s *RollbackSegment) reset() {
 	for k := range s.seen {
 		delete(s.seen, k)
 	}
+	s.savepoints = nil
+}
+
+// Savepoint adds a marker to the segment. Modifications made after the marker
+// can be undone without undoing the rest of the transaction.
+func (s *RollbackSegment) Savepoint(name string) {
+	s.savepoints = append(s.savepoints, &savepoint{
+		name: name,
+		seen: make(map[string]struct{}),
+	})
+}
+
+// savepointNeedsValue returns whether the value of the key must be recorded
+// before modifying it. Only the first modification following the last savepoint is recorded.
+func (s *RollbackSegment) savepointNeedsValue(k []byte) bool {
+	if len(s.savepoints) == 0 {
+		return false
+	}
+
+	_, ok := s.savepoints[len(s.savepoints)-1].seen[string(k)]
+	return !ok
+}
+
+// recordSavepointValue records the current value of the key in the last savepoint.
+func (s *RollbackSegment) recordSavepointValue(k, v []byte) {
+	sp := s.savepoints[len(s.savepoints)-1]
+	key := append([]byte{}, k...)
+	sp.seen[string(key)] = struct{}{}
+	sp.values = append(sp.values, savepointValue{key: key, value: v})
+}
+
+// savepointIndex returns the position of the most recent savepoint with the given name.
+func (s *RollbackSegment) savepointIndex(name string) (int, error) {
+	for i := len(s.savepoints) - 1; i >= 0; i-- {
+		if s.savepoints[i].name == name {
+			return i, nil
+		}
+	}
+
+	return -1, errors.Errorf("savepoint %q does not exist", name)
+}
+
+// rollbackToSavepoint removes the savepoints created after the given one
+// and returns the values to write to undo the modifications made since then.
+// The values must be written in order.
+// The savepoint itself is kept, so it can be rolled back to again.
+func (s *RollbackSegment) rollbackToSavepoint(name string) ([]savepointValue, error) {
+	idx, err := s.savepointIndex(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []savepointValue
+	for i := len(s.savepoints) - 1; i >= idx; i-- {
+		values = append(values, s.savepoints[i].values...)
+	}
+
+	s.savepoints = s.savepoints[:idx+1]
+	s.savepoints[idx].values = nil
+	s.savepoints[idx].seen = make(map[string]struct{})
+
+	return values, nil
+}
+
+// ReleaseSavepoint removes the given savepoint and the ones created after it.
+// The modifications they recorded are kept by the previous savepoint, if any.
+func (s *RollbackSegment) ReleaseSavepoint(name string) error {
+	idx, err := s.savepointIndex(name)
+	if err != nil {
+		return err
+	}
+
+	if idx > 0 {
+		parent := s.savepoints[idx-1]
+		for _, sp := range s.savepoints[idx:] {
+			for _, sv := range sp.values {
+				if _, ok := parent.seen[string(sv.key)]; ok {
+					continue
+				}
+				parent.seen[string(sv.key)] = struct{}{}
+				parent.values = append(parent.values, sv)
+			}
+		}
+	}
+
+	s.savepoints = s.savepoints[:idx]
+	return nil
 }
@@ -28,11 +28,40 @@ type Session interface {
 	// Delete a record by key. If not found, returns ErrKeyNotFound.
 	Delete(k []byte) error
 	DeleteRange(start []byte, end []byte) error
-	Iterator(opts *pebble.IterOptions) *pebble.Iterator
+	Iterator(opts *pebble.IterOptions) *Iterator
+	// BytesRead returns the number of bytes of keys and values read from the session.
+	BytesRead() int64
+}
+
+// Iterator is a pebble iterator counting the bytes of the keys
+// and values it returns.
+type Iterator struct {
+	*pebble.Iterator
+
+	read *int64
+}
+
+func newIterator(it *pebble.Iterator, read *int64) *Iterator {
+	return &Iterator{Iterator: it, read: read}
+}
+
+// Key returns the key of the current key-value pair.
+func (it *Iterator) Key() []byte {
+	k := it.Iterator.Key()
+	*it.read += int64(len(k))
+	return k
+}
+
+// Value returns the value of the current key-value pair.
+func (it *Iterator) Value() []byte {
+	v := it.Iterator.Value()
+	*it.read += int64(len(v))
+	return v
 }
 
 // Get returns a value associated with the given key. If not found, returns ErrKeyNotFound.
-func get(r pebble.Reader, k []byte) ([]byte, error) {
+// If read is not nil, the size of the value is added to it.
+func get(r pebble.Reader, k []byte, read *int64) ([]byte, error) {
 	value, closer, err := r.Get(k)
 	if err != nil {
 		if errors.Is(err, pebble.ErrNotFound) {
@@ -44,6 +73,9 @@ func get(r pebble.Reader, k []byte) ([]byte, error) {
 
 	cp := make([]byte, len(value))
 	copy(cp, value)
+	if read != nil {
+		*read += int64(len(value))
+	}
 
 	err = closer.Close()
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/genjidb/genji"
@@ -141,6 +142,154 @@ func TestRollback(t *testing.T) {
 	}
 }
 
+func TestConcurrentBatchSessions(t *testing.T) {
+	pdb := testutil.NewPebble(t)
+
+	store := kv.NewStore(pdb, kv.Options{
+		RollbackSegmentNamespace: int64(database.RollbackSegmentNamespace),
+		MaxBatchSize:             1 << 7,
+	})
+
+	s1 := store.NewBatchSession()
+	s2 := store.NewBatchSession()
+
+	key := func(i int64) []byte {
+		return encoding.EncodeInt(encoding.EncodeInt(nil, 10), i)
+	}
+
+	// while another session is open, large batches are kept in memory
+	for i := int64(0); i < 30; i++ {
+		err := s1.Put(key(i), encoding.EncodeInt(nil, i))
+		require.NoError(t, err)
+	}
+	_, _, err := pdb.Get(key(0))
+	require.Equal(t, pebble.ErrNotFound, err)
+
+	_, err = s2.Get(key(0))
+	require.ErrorIs(t, err, kv.ErrKeyNotFound)
+	err = s2.Close()
+	require.NoError(t, err)
+
+	// the session is alone, intermediary batches can be committed
+	err = s1.Put(key(30), encoding.EncodeInt(nil, 30))
+	require.NoError(t, err)
+	v, closer, err := pdb.Get(key(0))
+	require.NoError(t, err)
+	require.Equal(t, encoding.EncodeInt(nil, 0), v)
+	closer.Close()
+
+	// new sessions wait for s1 to be closed
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		s3 := store.NewBatchSession()
+		defer s3.Close()
+
+		_, err := s3.Get(key(0))
+		require.ErrorIs(t, err, kv.ErrKeyNotFound)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("session opened while uncommitted changes were flushed")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// closing s1 rolls back its changes
+	err = s1.Close()
+	require.NoError(t, err)
+	<-done
+}
+
+func TestSavepoint(t *testing.T) {
+	pdb := testutil.NewPebble(t)
+
+	store := kv.NewStore(pdb, kv.Options{
+		RollbackSegmentNamespace: int64(database.RollbackSegmentNamespace),
+		MaxBatchSize:             1 << 7,
+	})
+	s := store.NewBatchSession()
+	defer s.Close()
+
+	key := func(i int64) []byte {
+		return encoding.EncodeInt(encoding.EncodeInt(nil, 10), i)
+	}
+
+	for i := int64(0); i < 10; i++ {
+		err := s.Put(key(i), encoding.EncodeInt(nil, i))
+		require.NoError(t, err)
+	}
+
+	s.Savepoint("a")
+
+	// modify, delete and insert keys, enough to flush the batch
+	for i := int64(0); i < 5; i++ {
+		err := s.Put(key(i), encoding.EncodeInt(nil, i*100))
+		require.NoError(t, err)
+	}
+	err := s.Delete(key(5))
+	require.NoError(t, err)
+	err = s.Insert(key(10), encoding.EncodeInt(nil, 10))
+	require.NoError(t, err)
+
+	s.Savepoint("b")
+
+	err = s.Delete(key(6))
+	require.NoError(t, err)
+
+	err = s.RollbackToSavepoint("b")
+	require.NoError(t, err)
+	require.Equal(t, encoding.EncodeInt(nil, 6), getValue(t, s, key(6)))
+	require.Equal(t, encoding.EncodeInt(nil, 100), getValue(t, s, key(1)))
+
+	// b has been removed by the rollback to a
+	err = s.RollbackToSavepoint("a")
+	require.NoError(t, err)
+	err = s.RollbackToSavepoint("b")
+	require.Error(t, err)
+
+	for i := int64(0); i < 10; i++ {
+		require.Equal(t, encoding.EncodeInt(nil, i), getValue(t, s, key(i)))
+	}
+	_, err = s.Get(key(10))
+	require.ErrorIs(t, err, kv.ErrKeyNotFound)
+
+	// a is still active
+	err = s.Put(key(0), encoding.EncodeInt(nil, 42))
+	require.NoError(t, err)
+	err = s.RollbackToSavepoint("a")
+	require.NoError(t, err)
+	require.Equal(t, encoding.EncodeInt(nil, 0), getValue(t, s, key(0)))
+
+	// released changes are kept by the parent savepoint
+	s.Savepoint("c")
+	err = s.Put(key(1), encoding.EncodeInt(nil, 42))
+	require.NoError(t, err)
+	err = s.ReleaseSavepoint("c")
+	require.NoError(t, err)
+	require.Equal(t, encoding.EncodeInt(nil, 42), getValue(t, s, key(1)))
+	err = s.RollbackToSavepoint("a")
+	require.NoError(t, err)
+	require.Equal(t, encoding.EncodeInt(nil, 1), getValue(t, s, key(1)))
+
+	err = s.ReleaseSavepoint("a")
+	require.NoError(t, err)
+	err = s.RollbackToSavepoint("a")
+	require.Error(t, err)
+
+	// rolling back the transaction undoes everything
+	err = s.Close()
+	require.NoError(t, err)
+	err = store.Rollback()
+	require.NoError(t, err)
+
+	for i := int64(0); i <= 10; i++ {
+		_, _, err = pdb.Get(key(i))
+		require.Equal(t, pebble.ErrNotFound, err)
+	}
+}
+
 func TestStorePut(t *testing.T) {
 	t.Run("Should insert data", func(t *testing.T) {
 		st := kvBuilder(t)
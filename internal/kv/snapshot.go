@@ -24,9 +24,10 @@ func (s *snapshot) Done() error {
 }
 
 type SnapshotSession struct {
-	Store    *Store
-	Snapshot *snapshot
-	closed   bool
+	Store     *Store
+	Snapshot  *snapshot
+	closed    bool
+	bytesRead int64
 }
 
 var _ Session = (*SnapshotSession)(nil)
@@ -54,7 +55,7 @@ func (s *SnapshotSession) Put(k, v []byte) error {
 
 // Get returns a value associated with the given key. If not found, returns ErrKeyNotFound.
 func (s *SnapshotSession) Get(k []byte) ([]byte, error) {
-	return get(s.Snapshot.snapshot, k)
+	return get(s.Snapshot.snapshot, k, &s.bytesRead)
 }
 
 // Exists returns whether a key exists and is visible by the current session.
@@ -62,6 +63,11 @@ func (s *SnapshotSession) Exists(k []byte) (bool, error) {
 	return exists(s.Snapshot.snapshot, k)
 }
 
+// BytesRead returns the number of bytes of keys and values read from the session.
+func (s *SnapshotSession) BytesRead() int64 {
+	return s.bytesRead
+}
+
 // Delete a record by key. If not found, returns ErrKeyNotFound.
 func (s *SnapshotSession) Delete(k []byte) error {
 	return errors.New("cannot delete in read-only mode")
@@ -71,6 +77,6 @@ func (s *SnapshotSession) DeleteRange(start []byte, end []byte) error {
 	return errors.New("cannot delete range in read-only mode")
 }
 
-func (s *SnapshotSession) Iterator(opts *pebble.IterOptions) *pebble.Iterator {
-	return s.Snapshot.snapshot.NewIter(opts)
+func (s *SnapshotSession) Iterator(opts *pebble.IterOptions) *Iterator {
+	return newIterator(s.Snapshot.snapshot.NewIter(opts), &s.bytesRead)
 }
@@ -3,17 +3,34 @@ package kv
 import (
 	"sync"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 )
 
 type Store struct {
-	db              *pebble.DB
-	opts            Options
+	db   *pebble.DB
+	opts Options
+	// used to recover from a crash.
+	// each write session has its own rollback segment.
 	rollbackSegment *RollbackSegment
 
+	// tracks the open write sessions.
+	// multiple write sessions can be open at the same time
+	// as long as none of them commits intermediary batches,
+	// otherwise the others would read uncommitted changes.
+	writers struct {
+		sync.Mutex
+
+		cond  *sync.Cond
+		count int
+		// true when a write session has committed intermediary batches.
+		// no other write session can be opened until it is closed.
+		flushing bool
+	}
+
 	// holds the shared snapshot read by all the read sessions
-	// when a write session is open.
-	// when no write session is open, the snapshot is nil
+	// when a write session has committed intermediary batches.
+	// otherwise, the snapshot is nil
 	// and every read session will use db.NewSnapshot()
 	sharedSnapshot struct {
 		sync.RWMutex
@@ -36,11 +53,14 @@ func NewStore(db *pebble.DB, opts Options) *Store {
 		opts.MaxTransientBatchSize = defaultMaxTransientBatchSize
 	}
 
-	return &Store{
+	s := Store{
 		db:              db,
 		opts:            opts,
 		rollbackSegment: NewRollbackSegment(db, opts.RollbackSegmentNamespace),
 	}
+	s.writers.cond = sync.NewCond(&s.writers.Mutex)
+
+	return &s
 }
 
 func (s *Store) NewSnapshotSession() *SnapshotSession {
@@ -69,10 +89,16 @@ func (s *Store) NewSnapshotSession() *SnapshotSession {
 	}
 }
 
+// NewBatchSession creates a write session.
+// If another write session has committed intermediary batches,
+// it waits for that session to be closed.
 func (s *Store) NewBatchSession() *BatchSession {
-	// before creating a batch session, create a shared snapshot
-	// at this point-in-time.
-	s.LockSharedSnapshot()
+	s.writers.Lock()
+	for s.writers.flushing {
+		s.writers.cond.Wait()
+	}
+	s.writers.count++
+	s.writers.Unlock()
 
 	b := s.db.NewIndexedBatch()
 
@@ -80,11 +106,101 @@ func (s *Store) NewBatchSession() *BatchSession {
 		Store:           s,
 		DB:              s.db,
 		Batch:           b,
-		rollbackSegment: s.rollbackSegment,
+		rollbackSegment: NewRollbackSegment(s.db, s.opts.RollbackSegmentNamespace),
 		maxBatchSize:    s.opts.MaxBatchSize,
 	}
 }
 
+// startFlushing is called by a write session before committing its first
+// intermediary batch. It returns false if other write sessions are open.
+// Otherwise, read sessions will use a snapshot created at this point-in-time
+// until the write session is closed.
+func (s *Store) startFlushing() bool {
+	s.writers.Lock()
+	defer s.writers.Unlock()
+
+	if s.writers.count > 1 {
+		return false
+	}
+
+	s.writers.flushing = true
+	s.LockSharedSnapshot()
+	return true
+}
+
+// releaseBatchSession is called when a write session is closed.
+func (s *Store) releaseBatchSession(flushed bool) {
+	s.writers.Lock()
+	defer s.writers.Unlock()
+
+	s.writers.count--
+	if flushed {
+		s.writers.flushing = false
+		s.UnlockSharedSnapshot()
+	}
+
+	s.writers.cond.Broadcast()
+}
+
+// CopyTo writes the committed content of the store to db.
+// It reads a snapshot session, which ignores the intermediary batches
+// committed by write sessions, so that write sessions can run in the meantime.
+func (s *Store) CopyTo(db *pebble.DB) error {
+	sess := s.NewSnapshotSession()
+	defer sess.Close()
+
+	it := sess.Iterator(nil)
+	defer it.Close()
+
+	b := db.NewBatch()
+	for it.First(); it.Valid(); it.Next() {
+		err := b.Set(it.Key(), it.Value(), nil)
+		if err != nil {
+			_ = b.Close()
+			return err
+		}
+
+		if b.Len() < s.opts.MaxBatchSize {
+			continue
+		}
+
+		err = b.Commit(pebble.NoSync)
+		if err != nil {
+			_ = b.Close()
+			return err
+		}
+		_ = b.Close()
+		b = db.NewBatch()
+	}
+	defer b.Close()
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	return b.Commit(pebble.Sync)
+}
+
+// Exists returns whether the key has been committed to the database,
+// ignoring the changes kept in the batches of the write sessions.
+func (s *Store) Exists(k []byte) (bool, error) {
+	return exists(s.db, k)
+}
+
+// Put stores a key value pair directly in the database, outside of any write session.
+// The write is durable once Put returns and cannot be rolled back.
+func (s *Store) Put(k, v []byte) error {
+	if len(k) == 0 {
+		return errors.New("cannot store empty key")
+	}
+
+	if len(v) == 0 {
+		return errors.New("cannot store empty value")
+	}
+
+	return s.db.Set(k, v, pebble.Sync)
+}
+
 func (s *Store) NewTransientSession() *TransientSession {
 	return &TransientSession{
 		db:           s.db,
@@ -16,6 +16,7 @@ type TransientSession struct {
 	batch        *pebble.Batch
 	maxBatchSize int
 	closed       bool
+	bytesRead    int64
 }
 
 func (s *TransientSession) Commit() error {
@@ -67,7 +68,7 @@ func (s *TransientSession) Get(k []byte) ([]byte, error) {
 		return nil, errors.WithStack(ErrKeyNotFound)
 	}
 
-	return get(s.batch, k)
+	return get(s.batch, k, &s.bytesRead)
 }
 
 // Exists returns whether a key exists and is visible by the current session.
@@ -79,6 +80,11 @@ func (s *TransientSession) Exists(k []byte) (bool, error) {
 	return exists(s.batch, k)
 }
 
+// BytesRead returns the number of bytes of keys and values read from the session.
+func (s *TransientSession) BytesRead() int64 {
+	return s.bytesRead
+}
+
 // Delete a record by key. If not found, returns ErrKeyNotFound.
 func (s *TransientSession) Delete(k []byte) error {
 	if s.batch == nil {
@@ -109,10 +115,10 @@ func (s *TransientSession) DeleteRange(start []byte, end []byte) error {
 	return s.batch.DeleteRange(start, end, nil)
 }
 
-func (s *TransientSession) Iterator(opts *pebble.IterOptions) *pebble.Iterator {
+func (s *TransientSession) Iterator(opts *pebble.IterOptions) *Iterator {
 	if s.batch == nil {
-		return s.db.NewIter(opts)
+		return newIterator(s.db.NewIter(opts), &s.bytesRead)
 	}
 
-	return s.batch.NewIter(opts)
+	return newIterator(s.batch.NewIter(opts), &s.bytesRead)
 }
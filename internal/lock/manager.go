@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/cockroachdb/errors"
+	errs "github.com/genjidb/genji/internal/errors"
 )
 
 // A LockManager is used to acquire locks on database objects.
@@ -14,12 +15,16 @@ type LockManager struct {
 	mu sync.Mutex
 
 	locks map[Object]*LockHeader
+
+	// requests transactions are waiting for, used to detect deadlocks.
+	waiting map[uint64]*LockRequest
 }
 
 // NewLockManager creates a lock manager.
 func NewLockManager() *LockManager {
 	var lm LockManager
 	lm.locks = make(map[Object]*LockHeader)
+	lm.waiting = make(map[uint64]*LockRequest)
 	return &lm
 }
 
@@ -46,6 +51,10 @@ func (lm *LockManager) HasLock(txid uint64, obj *Object, mode LockMode) bool {
 	return false
 }
 
+// Lock acquires a lock on the given object for the transaction, waiting for
+// incompatible locks to be released.
+// If waiting would cause a deadlock, the lock is not acquired and a conflict error
+// is returned: the transaction must be rolled back to release its locks.
 func (lm *LockManager) Lock(ctx context.Context, txid uint64, obj *Object, mode LockMode) (bool, error) {
 	lm.mu.Lock()
 	head, ok := lm.locks[*obj]
@@ -68,9 +77,9 @@ func (lm *LockManager) Lock(ctx context.Context, txid uint64, obj *Object, mode
 	}
 
 	// A lock exists for this object.
-	// Lock the queue header and unlock the map.
+	// The map stays locked until the request is either granted or waiting,
+	// to make sure the wait-for graph doesn't change during deadlock detection.
 	head.mu.Lock()
-	lm.mu.Unlock()
 
 	// check if a lock request is already in the queue for this couple txid / obj
 	var req, last *LockRequest
@@ -107,14 +116,29 @@ func (lm *LockManager) Lock(ctx context.Context, txid uint64, obj *Object, mode
 			head.GroupMode = MaxMode(mode, head.GroupMode)
 			req.Status = LockGranted
 			head.mu.Unlock()
+			lm.mu.Unlock()
 			return true, nil
 		}
 
-		// Wait for the lock.
-		head.Waiting = true
+		// Wait for the lock, unless it creates a deadlock.
 		req.Status = LockWaiting
+		if lm.causesDeadlock(req) {
+			// remove the request from the queue
+			if last != nil {
+				last.Next = nil
+			} else {
+				head.Queue = nil
+			}
+			head.mu.Unlock()
+			lm.mu.Unlock()
+			return false, errors.WithStack(errs.NewConflictError("deadlock detected"))
+		}
+
+		head.Waiting = true
 		req.WakeUp = make(chan struct{})
+		lm.waiting[txid] = req
 		head.mu.Unlock()
+		lm.mu.Unlock()
 
 		select {
 		case <-ctx.Done():
@@ -127,19 +151,31 @@ func (lm *LockManager) Lock(ctx context.Context, txid uint64, obj *Object, mode
 
 	// A lock request is already in the queue for this couple txid / obj.
 	// Check if the lock is compatible with all locks of the granted group.
+	// Converting requests still hold their lock in their current mode.
+	mode = MaxMode(mode, req.Mode)
 	compatible := true
-	for other := head.Queue; other != nil && other.Status == LockGranted; other = other.Next {
-		if other != req && !other.Mode.IsCompatibleWith(mode) {
+	for other := head.Queue; other != nil; other = other.Next {
+		if other != req && other.Status != LockWaiting && !other.Mode.IsCompatibleWith(mode) {
 			compatible = false
 		}
 	}
 	if !compatible {
-		// Wait for the lock.
-		head.Waiting = true
+		// Wait for the lock, unless it creates a deadlock.
 		req.Status = LockConverting
 		req.ConvertMode = mode
+		if lm.causesDeadlock(req) {
+			req.Status = LockGranted
+			req.ConvertMode = Free
+			head.mu.Unlock()
+			lm.mu.Unlock()
+			return false, errors.WithStack(errs.NewConflictError("deadlock detected"))
+		}
+
+		head.Waiting = true
 		req.WakeUp = make(chan struct{})
+		lm.waiting[txid] = req
 		head.mu.Unlock()
+		lm.mu.Unlock()
 
 		select {
 		case <-ctx.Done():
@@ -157,9 +193,76 @@ func (lm *LockManager) Lock(ctx context.Context, txid uint64, obj *Object, mode
 	req.Mode = mode
 	head.GroupMode = MaxMode(mode, head.GroupMode)
 	head.mu.Unlock()
+	lm.mu.Unlock()
 	return true, nil
 }
 
+// causesDeadlock returns true if waiting for the given request creates a cycle
+// in the wait-for graph, i.e. if the transaction is directly or indirectly waiting
+// for a transaction that is itself waiting for it.
+// It must be called with the map locked.
+func (lm *LockManager) causesDeadlock(req *LockRequest) bool {
+	visited := make(map[uint64]struct{})
+	stack := req.Head.blockers(req)
+	for len(stack) > 0 {
+		txid := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if txid == req.Txid {
+			return true
+		}
+
+		if _, ok := visited[txid]; ok {
+			continue
+		}
+		visited[txid] = struct{}{}
+
+		if w, ok := lm.waiting[txid]; ok {
+			stack = append(stack, w.Head.blockers(w)...)
+		}
+	}
+
+	return false
+}
+
+// blockers returns the ids of the transactions a waiting or converting request
+// has to wait for.
+func (h *LockHeader) blockers(req *LockRequest) []uint64 {
+	mode := req.Mode
+	if req.Status == LockConverting {
+		mode = req.ConvertMode
+	}
+
+	var txids []uint64
+	for other := h.Queue; other != nil; other = other.Next {
+		if other == req {
+			// waiting requests are granted in order
+			if req.Status == LockWaiting {
+				break
+			}
+			continue
+		}
+
+		switch other.Status {
+		case LockGranted:
+			if !other.Mode.IsCompatibleWith(mode) {
+				txids = append(txids, other.Txid)
+			}
+		case LockConverting:
+			// converting requests are granted before waiting requests
+			if req.Status == LockWaiting || !other.Mode.IsCompatibleWith(mode) {
+				txids = append(txids, other.Txid)
+			}
+		case LockWaiting:
+			if req.Status == LockWaiting {
+				txids = append(txids, other.Txid)
+			}
+		}
+	}
+
+	return txids
+}
+
 func (lm *LockManager) Unlock(txid uint64, obj *Object) bool {
 	lm.mu.Lock()
 	head, ok := lm.locks[*obj]
@@ -184,6 +287,10 @@ func (lm *LockManager) Unlock(txid uint64, obj *Object) bool {
 		return true
 	}
 
+	if lm.waiting[txid] == req {
+		delete(lm.waiting, txid)
+	}
+
 	// if this request is held multiple times by the same transaction,
 	// decrement the count and return
 	if req.Count > 1 {
@@ -225,8 +332,8 @@ func (lm *LockManager) Unlock(txid uint64, obj *Object) bool {
 			// if a lock is converting, only wake up the request if the
 			// new mode is compatible with every other member of the group.
 			compatible := true
-			for other := head.Queue; other != nil && other.Status == LockGranted; other = other.Next {
-				if other == req {
+			for other := head.Queue; other != nil; other = other.Next {
+				if other == req || other.Status == LockWaiting {
 					continue
 				}
 
@@ -238,7 +345,9 @@ func (lm *LockManager) Unlock(txid uint64, obj *Object) bool {
 			if compatible {
 				req.Status = LockGranted
 				req.Count++
+				req.Mode = req.ConvertMode
 				head.GroupMode = MaxMode(req.Mode, head.GroupMode)
+				delete(lm.waiting, req.Txid)
 				close(req.WakeUp)
 			} else {
 				// stop here
@@ -255,6 +364,7 @@ func (lm *LockManager) Unlock(txid uint64, obj *Object) bool {
 			if head.GroupMode.IsCompatibleWith(req.Mode) {
 				req.Status = LockGranted
 				head.GroupMode = MaxMode(req.Mode, head.GroupMode)
+				delete(lm.waiting, req.Txid)
 				close(req.WakeUp)
 			} else {
 				// stop here
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -241,3 +242,108 @@ func TestLockManagerUnlock(t *testing.T) {
 		<-ch2
 	})
 }
+
+func TestLockManagerDeadlock(t *testing.T) {
+	t.Run("two transactions", func(t *testing.T) {
+		m := NewLockManager()
+
+		a := NewDocumentObject("t", []byte("a"))
+		b := NewDocumentObject("t", []byte("b"))
+
+		ok, err := m.Lock(getCtx(t), 1, a, X)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = m.Lock(getCtx(t), 2, b, X)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			// 1 waits for 2
+			ok, err := m.Lock(getCtx(t), 1, b, X)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		// 2 waiting for 1 would create a cycle
+		ok, err = m.Lock(getCtx(t), 2, a, X)
+		require.Error(t, err)
+		require.True(t, errs.IsConflictError(err))
+		require.False(t, ok)
+		require.Equal(t, 1, queueLen(m.locks[*a].Queue))
+
+		// rolling back 2 unblocks 1
+		m.Unlock(2, b)
+		<-done
+	})
+
+	t.Run("lock conversion", func(t *testing.T) {
+		m := NewLockManager()
+
+		doc := NewDocumentObject("t", []byte("a"))
+
+		ok, err := m.Lock(getCtx(t), 1, doc, S)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = m.Lock(getCtx(t), 2, doc, S)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			ok, err := m.Lock(getCtx(t), 1, doc, X)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		// both transactions trying to upgrade their lock is a deadlock
+		ok, err = m.Lock(getCtx(t), 2, doc, X)
+		require.True(t, errs.IsConflictError(err))
+		require.False(t, ok)
+
+		m.Unlock(2, doc)
+		<-done
+		require.True(t, m.HasLock(1, doc, X))
+	})
+
+	t.Run("no cycle", func(t *testing.T) {
+		m := NewLockManager()
+
+		a := NewDocumentObject("t", []byte("a"))
+		b := NewDocumentObject("t", []byte("b"))
+
+		ok, err := m.Lock(getCtx(t), 1, a, X)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			// 2 waits for 1
+			ok, err := m.Lock(getCtx(t), 2, a, X)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		// 1 doesn't wait for 2
+		ok, err = m.Lock(getCtx(t), 1, b, X)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		m.Unlock(1, a)
+		<-done
+	})
+}
@@ -0,0 +1,163 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/index"
+)
+
+// UseCoveringIndexRule replaces an index scan by a covering scan if every path
+// used by the rest of the stream is stored in the index, either as an indexed path
+// or as a path of the primary key. The documents are then built from the entries
+// of the index, without reading the table.
+// Example, with an index on foo(a, b):
+//
+//	this:
+//	  index.Scan("foo_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Project(a, b)
+//	becomes this:
+//	  index.CoveringScan("foo_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Project(a, b)
+//
+// The rule only applies to streams made of filter, projection, sort, skip and take nodes,
+// and is ignored if one of them uses a wildcard or a subquery.
+func UseCoveringIndexRule(sctx *StreamContext) error {
+	scan, ok := sctx.Stream.First().(*index.ScanOperator)
+	if !ok {
+		return nil
+	}
+
+	info, err := sctx.Catalog.GetIndexInfo(scan.IndexName)
+	if err != nil {
+		return err
+	}
+	// multikey indexes store the elements of the arrays
+	if info.Multikey {
+		return nil
+	}
+
+	ti, err := sctx.Catalog.GetTableInfo(info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+
+	covered := append(document.Paths(nil), info.Paths...)
+	if pk := ti.GetPrimaryKey(); pk != nil {
+		covered = append(covered, pk.Paths...)
+	}
+	for _, p := range covered {
+		for _, f := range p {
+			if f.FieldName == "" {
+				return nil
+			}
+		}
+	}
+
+	c := coverage{
+		paths: covered,
+		name:  scan.Alias,
+	}
+	if c.name == "" {
+		c.name = ti.TableName
+	}
+
+	var projected bool
+	for n := scan.GetNext(); n != nil; n = n.GetNext() {
+		var exprs []expr.Expr
+
+		switch t := n.(type) {
+		case *docs.FilterOperator:
+			exprs = append(exprs, t.Expr)
+		case *docs.ProjectOperator:
+			exprs = append(exprs, t.Exprs...)
+			projected = true
+		case *docs.TempTreeSortOperator:
+			for _, k := range t.Keys {
+				exprs = append(exprs, k.Expr)
+			}
+		case *docs.SkipOperator:
+			exprs = append(exprs, t.E)
+		case *docs.TakeOperator:
+			exprs = append(exprs, t.E)
+		default:
+			return nil
+		}
+
+		for _, e := range exprs {
+			if !c.covers(e) {
+				return nil
+			}
+		}
+	}
+
+	// without projection, the whole document is returned
+	if !projected {
+		return nil
+	}
+
+	cs := index.CoveringScan(scan.IndexName, scan.Ranges...)
+	cs.Alias = scan.Alias
+	cs.Reverse = scan.Reverse
+
+	s := sctx.Stream
+	next := scan.GetNext()
+	s.Remove(scan)
+	stream.InsertBefore(next, cs)
+
+	return nil
+}
+
+// coverage determines if expressions only use the paths stored in an index.
+type coverage struct {
+	paths document.Paths
+	// name of the table or of its alias.
+	name string
+}
+
+func (c *coverage) covers(e expr.Expr) bool {
+	ok := true
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.Path:
+			ok = c.coversPath(document.Path(t))
+		case expr.Cast:
+			ok = c.covers(t.Expr)
+		case expr.AggregatorBuilder:
+			ok = false
+		case expr.Operator, expr.Parentheses, *expr.NamedExpr, expr.Function,
+			expr.LiteralExprList, *expr.KVPairs,
+			expr.LiteralValue, expr.PositionalParam, expr.NamedParam, expr.NextValueFor:
+		default:
+			// wildcards, subqueries and unknown expressions may
+			// read any path of the document
+			ok = false
+		}
+
+		return ok
+	})
+
+	return ok
+}
+
+func (c *coverage) coversPath(p document.Path) bool {
+	if len(p) == 0 {
+		return true
+	}
+
+	if p[0].FieldName == c.name {
+		// the name of the table alone refers to the whole document
+		if len(p) == 1 {
+			return false
+		}
+		p = p[1:]
+	}
+
+	for _, cp := range c.paths {
+		if len(cp) <= len(p) && cp.IsEqual(p[:len(cp)]) {
+			return true
+		}
+	}
+
+	return false
+}
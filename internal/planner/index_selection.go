@@ -2,12 +2,15 @@ package planner
 
 import (
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
 )
 
 // SelectIndex attempts to replace a sequential scan by an index scan or a pk scan by
@@ -17,9 +20,13 @@ import (
 // Compatibility of filter nodes.
 //
 // For a filter node to be selected if must be of the following form:
-//   <path> <compatible operator> <expression>
+//
+//	<path> <compatible operator> <expression>
+//
 // or
-//   <expression> <compatible operator> <path>
+//
+//	<expression> <compatible operator> <path>
+//
 // path: path of a document
 // compatible operator: one of =, >, >=, <, <=, IN
 // expression: any expression
@@ -29,37 +36,61 @@ import (
 // Once we have a list of all compatible filter nodes, we try to associate
 // indexes with them.
 // Given the following index:
-//   CREATE INDEX foo_a_idx ON foo (a)
+//
+//	CREATE INDEX foo_a_idx ON foo (a)
+//
 // and this query:
-//   SELECT * FROM foo WHERE a > 5 AND b > 10
-//   table.Scan('foo') | docs.Filter(a > 5) | docs.Filter(b > 10) | docs.Project(*)
+//
+//	SELECT * FROM foo WHERE a > 5 AND b > 10
+//	table.Scan('foo') | docs.Filter(a > 5) | docs.Filter(b > 10) | docs.Project(*)
+//
 // foo_a_idx matches docs.Filter(a > 5) and can be selected.
 // Now, with a different index:
-//   CREATE INDEX foo_a_b_c_idx ON foo(a, b, c)
+//
+//	CREATE INDEX foo_a_b_c_idx ON foo(a, b, c)
+//
 // and this query:
-//   SELECT * FROM foo WHERE a > 5 AND c > 20
-//   table.Scan('foo') | docs.Filter(a > 5) | docs.Filter(c > 20) | docs.Project(*)
+//
+//	SELECT * FROM foo WHERE a > 5 AND c > 20
+//	table.Scan('foo') | docs.Filter(a > 5) | docs.Filter(c > 20) | docs.Project(*)
+//
 // foo_a_b_c_idx matches with the first filter because a is the leftmost path indexed by it.
 // The second filter is not selected because it is not the second leftmost path.
 // For composite indexes, filter nodes can be selected if they match with one or more indexed path
 // consecutively, from left to right.
 // Now, let's have a look a this query:
-//   SELECT * FROM foo WHERE a = 5 AND b = 10 AND c > 15 AND d > 20
-//   table.Scan('foo') | docs.Filter(a = 5) | docs.Filter(b = 10) | docs.Filter(c > 15) | docs.Filter(d > 20) | docs.Project(*)
+//
+//	SELECT * FROM foo WHERE a = 5 AND b = 10 AND c > 15 AND d > 20
+//	table.Scan('foo') | docs.Filter(a = 5) | docs.Filter(b = 10) | docs.Filter(c > 15) | docs.Filter(d > 20) | docs.Project(*)
+//
 // foo_a_b_c_idx matches with first three filters because they satisfy several conditions:
 // - each of them matches with the first 3 indexed paths, consecutively.
 // - the first 2 filters use the equal operator
 // A counter-example:
-//   SELECT * FROM foo WHERE a = 5 AND b > 10 AND c > 15 AND d > 20
-//   table.Scan('foo') | docs.Filter(a = 5) | docs.Filter(b > 10) | docs.Filter(c > 15) | docs.Filter(d > 20) | docs.Project(*)
+//
+//	SELECT * FROM foo WHERE a = 5 AND b > 10 AND c > 15 AND d > 20
+//	table.Scan('foo') | docs.Filter(a = 5) | docs.Filter(b > 10) | docs.Filter(c > 15) | docs.Filter(d > 20) | docs.Project(*)
+//
 // foo_a_b_c_idx only matches with the first two filter nodes because while the first node uses the equal
 // operator, the second one doesn't, and thus the third node cannot be selected as well.
 //
-// Candidates and cost
+// # Candidates and cost
 //
 // Because a table can have multiple indexes, we need to establish which of these
 // indexes should be used to run the query, if not all of them.
 // For that we generate a cost for each selected index and return the one with the cheapest cost.
+//
+// If the table was analyzed with ANALYZE, the cost is the number of entries read
+// by each candidate, estimated from the statistics of the primary key and the indexes,
+// reading from an index being twice as expensive as reading from the primary key.
+// The table is read sequentially if that is cheaper than using any of them.
+// Otherwise, the candidate associated with the most filter nodes is preferred.
+//
+// # Index union
+//
+// A filter node of the form a OR b can be evaluated by reading the documents
+// matching a and b from their own index and merging them, if it is cheaper than
+// reading the whole table. See selectUnion.
 func SelectIndex(sctx *StreamContext) error {
 	// Lookup the seq scan node.
 	// We will assume that at this point
@@ -80,8 +111,9 @@ func SelectIndex(sctx *StreamContext) error {
 		return err
 	}
 
-	// ensure the list of filter nodes is not empty
-	if len(sctx.Filters) == 0 && len(sctx.TempTreeSorts) == 0 {
+	// ensure the list of filter nodes is not empty.
+	// TempTreeSort nodes located after a join can't be associated with the first table.
+	if len(sctx.Filters) == 0 && (len(sctx.TempTreeSorts) == 0 || len(sctx.Joins) > 0) {
 		return nil
 	}
 
@@ -123,59 +155,48 @@ func (i *indexSelector) selectIndex() error {
 	// In this case, we can only associate the first TempSort node
 	// with an index, as the second one will be used to sort the
 	// results downstream.
-	if len(i.sctx.TempTreeSorts) > 0 {
+	if len(i.sctx.TempTreeSorts) > 0 && len(i.sctx.Joins) == 0 {
 		node := i.isTempTreeSortIndexable(i.sctx.TempTreeSorts[0])
 		if node != nil {
 			nodes = append(nodes, node)
 		}
 	}
 
-	// select the cheapest plan
-	var selected *candidate
-	var cost int
-
-	// start with the primary key of the table
 	tb, err := i.sctx.Catalog.GetTableInfo(i.tableScan.TableName)
 	if err != nil {
 		return err
 	}
-	pk := tb.GetPrimaryKey()
-	if pk != nil {
-		selected = i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, nodes)
-		if selected != nil {
-			cost = selected.Cost()
-		}
-	}
 
-	// get all the indexes for this table and associate them
-	// with compatible candidates
-	for _, idxName := range i.sctx.Catalog.ListIndexes(i.tableScan.TableName) {
-		idxInfo, err := i.sctx.Catalog.GetIndexInfo(idxName)
-		if err != nil {
-			return err
-		}
-
-		candidate := i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, nodes)
+	conds := make([]expr.Expr, 0, len(i.sctx.Filters))
+	for _, f := range i.sctx.Filters {
+		conds = append(conds, f.Expr)
+	}
 
-		if candidate == nil {
-			continue
-		}
+	candidates, err := i.candidates(tb, nodes, conds)
+	if err != nil {
+		return err
+	}
 
-		if selected == nil {
-			selected = candidate
-			cost = selected.Cost()
-			continue
-		}
+	// reading the whole table is also a candidate, selected
+	// if the indexes are estimated to read too many entries
+	if full := i.tableScanCandidate(tb, nodes); full != nil {
+		candidates = append(candidates, full)
+	}
 
-		c := candidate.Cost()
+	// select the cheapest plan
+	selected, estimated := i.selectCandidate(tb, candidates)
 
-		if len(selected.nodes) < len(candidate.nodes) || (len(selected.nodes) == len(candidate.nodes) && c < cost) {
-			cost = c
-			selected = candidate
-		}
+	// filters made of OR expressions can be evaluated by merging
+	// the documents read from multiple indexes
+	union, err := i.selectUnion(tb)
+	if err != nil {
+		return err
+	}
+	if union != nil && (selected == nil || union.cheaperThan(selected, estimated && union.estimated)) {
+		selected = union
 	}
 
-	if selected == nil {
+	if selected == nil || selected.fullScan {
 		return nil
 	}
 
@@ -192,6 +213,18 @@ func (i *indexSelector) selectIndex() error {
 		}
 	}
 
+	// the new root must keep the alias of the table
+	for _, op := range selected.replaceRootBy {
+		switch t := op.(type) {
+		case *table.ScanOperator:
+			t.Alias = i.tableScan.Alias
+		case *index.ScanOperator:
+			t.Alias = i.tableScan.Alias
+		case *index.UnionOperator:
+			t.Alias = i.tableScan.Alias
+		}
+	}
+
 	// we replace the seq scan node by the selected root
 	s := i.sctx.Stream
 	s.Remove(s.First())
@@ -207,6 +240,234 @@ func (i *indexSelector) selectIndex() error {
 	return nil
 }
 
+// candidates returns the candidates reading from the primary key
+// or from one of the indexes of the table that can replace some of the given nodes.
+// Partial indexes are only used if the conditions, which are true for all the documents
+// returned by the stream, imply their predicate.
+func (i *indexSelector) candidates(tb *database.TableInfo, nodes indexableNodes, conds []expr.Expr) ([]*candidate, error) {
+	var candidates []*candidate
+
+	// start with the primary key of the table
+	pk := tb.GetPrimaryKey()
+	if pk != nil {
+		c := i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, nodes)
+		if c != nil {
+			candidates = append(candidates, c)
+		}
+	}
+
+	// get all the indexes for this table and associate them
+	// with compatible candidates
+	for _, idxName := range i.sctx.Catalog.ListIndexes(tb.TableName) {
+		idxInfo, err := i.sctx.Catalog.GetIndexInfo(idxName)
+		if err != nil {
+			return nil, err
+		}
+
+		if idxInfo.IsPartial() && !i.impliesPredicate(conds, idxInfo) {
+			continue
+		}
+
+		var candidate *candidate
+		if idxInfo.Multikey {
+			candidate = i.associateMultikeyIndexWithNodes(idxInfo.IndexName, idxInfo.Unique, idxInfo.Paths[0], nodes)
+		} else {
+			candidate = i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, nodes)
+		}
+
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// tableScanCandidate returns a candidate reading the whole table, whose cost
+// is the number of documents of the table.
+// It returns nil if that number is unknown, or if one of the nodes sorts the stream,
+// reading the documents in order from an index being assumed cheaper than sorting them.
+func (i *indexSelector) tableScanCandidate(tb *database.TableInfo, nodes indexableNodes) *candidate {
+	for _, n := range nodes {
+		if n.operator == scanner.ORDER {
+			return nil
+		}
+	}
+
+	rows, ok := i.tableRowCount(tb)
+	if !ok {
+		return nil
+	}
+
+	return &candidate{
+		rangesCost: fullScanCost,
+		rows:       rows,
+		fullScan:   true,
+	}
+}
+
+// tableRowCount returns the number of documents of the table,
+// as collected by ANALYZE.
+// Tables without primary key rely on the statistics of an index
+// that is neither partial nor multikey, which has exactly one entry per document.
+func (i *indexSelector) tableRowCount(tb *database.TableInfo) (float64, bool) {
+	if pk := tb.GetPrimaryKey(); pk != nil {
+		stats := i.sctx.Catalog.GetStatistics(tb.TableName, pk.Paths)
+		if stats == nil {
+			return 0, false
+		}
+		return float64(stats.RowCount), true
+	}
+
+	for _, idxName := range i.sctx.Catalog.ListIndexes(tb.TableName) {
+		idxInfo, err := i.sctx.Catalog.GetIndexInfo(idxName)
+		if err != nil || idxInfo.IsPartial() || idxInfo.Multikey {
+			continue
+		}
+
+		if stats := i.sctx.Catalog.GetStatistics(tb.TableName, idxInfo.Paths); stats != nil {
+			return float64(stats.RowCount), true
+		}
+	}
+
+	return 0, false
+}
+
+// selectCandidate returns the cheapest candidate and whether
+// its cost was estimated using the statistics of the table.
+func (i *indexSelector) selectCandidate(tb *database.TableInfo, candidates []*candidate) (*candidate, bool) {
+	if i.estimateRows(tb, candidates) {
+		return selectByEstimation(candidates), true
+	}
+
+	return selectByCost(candidates), false
+}
+
+// selectUnion returns the cheapest candidate reading the documents matching
+// a filter node of the form a OR b OR ... from multiple indexes,
+// or nil if it is not cheaper than reading the whole table.
+// Each operand of the OR expression must be associated with the primary key or an index.
+// If all of them can be evaluated entirely by reading from the index,
+// the filter node is removed.
+// Example:
+//
+//	SELECT * FROM foo WHERE a = 1 OR b = 2
+//	-> index.Union("foo", index.Scan("foo_a_idx", [{"min": [1], "exact": true}]), index.Scan("foo_b_idx", [{"min": [2], "exact": true}]))
+func (i *indexSelector) selectUnion(tb *database.TableInfo) (*candidate, error) {
+	var selected *candidate
+
+	for _, f := range i.sctx.Filters {
+		c, err := i.unionCandidate(tb, f)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			continue
+		}
+
+		if selected == nil || c.cheaperThan(selected, c.estimated && selected.estimated) {
+			selected = c
+		}
+	}
+
+	return selected, nil
+}
+
+func (i *indexSelector) unionCandidate(tb *database.TableInfo, f *docs.FilterOperator) (*candidate, error) {
+	operands := splitORExpr(f.Expr)
+	if len(operands) < 2 {
+		return nil, nil
+	}
+
+	u := candidate{
+		estimated: true,
+	}
+	covered := true
+	scans := make([]stream.Operator, 0, len(operands))
+
+	for _, e := range operands {
+		conds := splitANDExpr(unwrapParentheses(e))
+
+		var nodes indexableNodes
+		for _, cond := range conds {
+			n := i.isFilterIndexable(docs.Filter(cond))
+			if n != nil {
+				nodes = append(nodes, n)
+			}
+		}
+
+		// the other filter nodes are also true for the documents of this operand
+		implied := append([]expr.Expr(nil), conds...)
+		for _, other := range i.sctx.Filters {
+			if other != f {
+				implied = append(implied, other.Expr)
+			}
+		}
+
+		candidates, err := i.candidates(tb, nodes, implied)
+		if err != nil {
+			return nil, err
+		}
+
+		c, estimated := i.selectCandidate(tb, candidates)
+		if c == nil {
+			return nil, nil
+		}
+
+		// the filter must be kept if some of the conditions
+		// are not evaluated by the scan
+		if len(c.nodes) < len(conds) {
+			covered = false
+		}
+
+		u.branches = append(u.branches, c)
+		u.estimated = u.estimated && estimated
+		scans = append(scans, c.replaceRootBy...)
+	}
+
+	// compare with the cost of reading the whole table
+	if u.estimated {
+		rows, ok := i.tableRowCount(tb)
+		if !ok {
+			u.estimated = false
+		} else if u.estimatedCost() >= rows {
+			return nil, nil
+		}
+	}
+	if !u.estimated && u.Cost() >= fullScanCost {
+		return nil, nil
+	}
+
+	if covered {
+		u.nodes = indexableNodes{{node: f}}
+	}
+	u.replaceRootBy = []stream.Operator{index.Union(tb.TableName, scans...)}
+
+	return &u, nil
+}
+
+// splitORExpr splits an expression by OR operator.
+func splitORExpr(e expr.Expr) []expr.Expr {
+	e = unwrapParentheses(e)
+
+	op, ok := e.(expr.Operator)
+	if ok && op.Token() == scanner.OR {
+		return append(splitORExpr(op.LeftHand()), splitORExpr(op.RightHand())...)
+	}
+
+	return []expr.Expr{e}
+}
+
+func unwrapParentheses(e expr.Expr) expr.Expr {
+	for {
+		p, ok := e.(expr.Parentheses)
+		if !ok {
+			return e
+		}
+		e = p.E
+	}
+}
+
 func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode {
 	// only operators can associate this node to an index
 	op, ok := f.Expr.(expr.Operator)
@@ -219,6 +480,18 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 		return nil
 	}
 
+	// x IN path can only use multikey indexes,
+	// which store the elements of the arrays.
+	if p, ok := op.RightHand().(expr.Path); ok && op.Token() == scanner.IN && !exprContainsPath(op.LeftHand()) {
+		return &indexableNode{
+			node:     f,
+			path:     i.unqualifiedPath(document.Path(p)),
+			operator: scanner.EQ,
+			operand:  op.LeftHand(),
+			elem:     true,
+		}
+	}
+
 	// determine if the operator could benefit from an index
 	ok, path, e := operatorCanUseIndex(op)
 	if !ok {
@@ -227,7 +500,7 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 
 	node := indexableNode{
 		node:     f,
-		path:     path,
+		path:     i.unqualifiedPath(path),
 		operator: op.Token(),
 		operand:  e,
 	}
@@ -236,18 +509,50 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 }
 
 func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *indexableNode {
-	// only paths can be associated with an index
-	path, ok := n.Expr.(expr.Path)
-	if !ok {
-		return nil
+	var paths []document.Path
+	for _, k := range n.Keys {
+		// only paths can be associated with an index
+		path, ok := k.Expr.(expr.Path)
+		if !ok {
+			return nil
+		}
+
+		// an index can only be read in one direction
+		if k.Desc != n.Keys[0].Desc {
+			return nil
+		}
+
+		// NULL values are stored first in an index
+		if (k.NullsFirst && k.Desc) || (k.NullsLast && !k.Desc) {
+			return nil
+		}
+
+		paths = append(paths, i.unqualifiedPath(document.Path(path)))
 	}
 
 	return &indexableNode{
-		node:     n,
-		path:     document.Path(path),
-		desc:     n.Desc,
-		operator: scanner.ORDER,
+		node:      n,
+		path:      paths[0],
+		sortPaths: paths[1:],
+		desc:      n.Desc(),
+		operator:  scanner.ORDER,
+	}
+}
+
+// unqualifiedPath removes the name or the alias of the table from
+// the given path, if any.
+// Example: with table.Scan("foo" AS f), f.a.b becomes a.b
+func (i *indexSelector) unqualifiedPath(p document.Path) document.Path {
+	name := i.tableScan.Alias
+	if name == "" {
+		name = i.tableScan.TableName
+	}
+
+	if len(p) > 1 && p[0].FieldName == name {
+		return p[1:]
 	}
+
+	return p
 }
 
 // for a given index, select all filter nodes that match according to the following rules:
@@ -258,19 +563,20 @@ func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *i
 // - transform all associated nodes into an index range
 // If not all indexed paths have an associated filter node, return whatever has been associated
 // A few examples for this index: CREATE INDEX ON foo(a, b, c)
-//   fitler(a = 3) | docs.Filter(b = 10) | (c > 20)
-//   -> range = {min: [3, 10, 20]}
-//   fitler(a = 3) | docs.Filter(b > 10) | (c > 20)
-//   -> range = {min: [3], exact: true}
-//  docs.Filter(a IN (1, 2))
-//   -> ranges = [1], [2]
+//
+//	 fitler(a = 3) | docs.Filter(b = 10) | (c > 20)
+//	 -> range = {min: [3, 10, 20]}
+//	 fitler(a = 3) | docs.Filter(b > 10) | (c > 20)
+//	 -> range = {min: [3], exact: true}
+//	docs.Filter(a IN (1, 2))
+//	 -> ranges = [1], [2]
 func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, isUnique bool, paths []document.Path, nodes indexableNodes) *candidate {
 	found := make([]*indexableNode, 0, len(paths))
 	var desc bool
 
 	var hasIn bool
 	var sorter *indexableNode
-	for _, p := range paths {
+	for j, p := range paths {
 		ns := nodes.getByPath(p)
 		if len(ns) == 0 {
 			break
@@ -279,7 +585,15 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 		// get the filter node and the TempSort node if any
 		var filter *indexableNode
 		for i, n := range ns {
+			if n.elem {
+				continue
+			}
 			if n.operator == scanner.ORDER && sorter == nil {
+				// when sorting by multiple paths, the next paths
+				// of the index must follow the same order
+				if !n.sortsLike(paths[j+1:], isIndex) {
+					continue
+				}
 				sorter = ns[i]
 				desc = sorter.desc
 				continue
@@ -328,6 +642,7 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 	if len(found) == 0 {
 		c := candidate{
 			nodes:      []*indexableNode{sorter},
+			paths:      paths,
 			rangesCost: 10_000,
 			isIndex:    isIndex,
 			isUnique:   isUnique,
@@ -376,6 +691,8 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 
 	c := candidate{
 		nodes:      found,
+		paths:      paths,
+		ranges:     ranges,
 		rangesCost: ranges.Cost(),
 		isIndex:    isIndex,
 		isUnique:   isUnique,
@@ -406,6 +723,32 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 	return &c
 }
 
+// associateMultikeyIndexWithNodes selects the first filter node of the form x IN path
+// that can be evaluated by reading the elements equal to x from a multikey index.
+// Multikey indexes can't be used for anything else: they contain one entry
+// per element of each array and none for the documents without any.
+func (i *indexSelector) associateMultikeyIndexWithNodes(indexName string, isUnique bool, path document.Path, nodes indexableNodes) *candidate {
+	for _, n := range nodes.getByPath(path) {
+		if !n.elem {
+			continue
+		}
+
+		ranges := stream.Ranges{i.buildRangeFromOperator(scanner.EQ, []document.Path{path}, n.operand)}
+
+		return &candidate{
+			nodes:         []*indexableNode{n},
+			paths:         []document.Path{path},
+			ranges:        ranges,
+			rangesCost:    ranges.Cost(),
+			isIndex:       true,
+			isUnique:      isUnique,
+			replaceRootBy: []stream.Operator{index.Scan(indexName, ranges...)},
+		}
+	}
+
+	return nil
+}
+
 func (i *indexSelector) buildRangesFromFilterNodes(paths []document.Path, filters []*indexableNode) stream.Ranges {
 	// build a 2 dimentional list of all expressions
 	// so that: docs.Filter(a IN (10, 11)) | docs.Filter(b = 20) | docs.Filter(c IN (30, 31))
@@ -549,20 +892,44 @@ type indexableNode struct {
 	// the expression of the node
 	// has been broken into
 	// <path> <direction>
-	// Ex:  ORDER BY a.b[0] ASC
+	// Ex:  ORDER BY a.b[0] ASC, c ASC
 	// Gives:
 	// - path: a.b[0]
+	// - sortPaths: c
 	// - desc: false
-	path     document.Path
-	operator scanner.Token
-	operand  expr.Expr
-	desc     bool
+	path      document.Path
+	sortPaths []document.Path
+	operator  scanner.Token
+	operand   expr.Expr
+	desc      bool
+	// For filter nodes of the form x IN path, where the operand
+	// must be one of the elements of the array located at the path.
+	// Such nodes can only be associated with multikey indexes.
+	elem bool
 
 	// merged TempTreeSort node to remove
 	// from the stream
 	orderBy *indexableNode
 }
 
+// sortsLike returns true if the paths following the first path of a TempTreeSort node
+// are a prefix of the given paths.
+// Since the primary key is unique, the order of the documents is fully determined
+// once all of its paths are used.
+func (n *indexableNode) sortsLike(paths []document.Path, isIndex bool) bool {
+	for i, p := range n.sortPaths {
+		if i >= len(paths) {
+			return !isIndex
+		}
+
+		if !p.IsEqual(paths[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 type indexableNodes []*indexableNode
 
 // getByPath returns all indexable nodes for the given path.
@@ -587,18 +954,48 @@ type candidate struct {
 	// replace the table.Scan by these nodes
 	replaceRootBy []stream.Operator
 
+	// paths of the index or of the primary key
+	paths document.Paths
+
+	// ranges read by the candidate, if any
+	ranges stream.Ranges
+
 	// cost of the associated ranges
 	rangesCost int
 
+	// estimated number of entries read, based on the
+	// statistics collected by ANALYZE.
+	rows float64
+
 	// is this candidate reading from an index.
 	// if false, we are reading from the table
 	// primary key.
 	isIndex bool
 	// if it's an index, does it have a unique constraint
 	isUnique bool
+
+	// whether the candidate reads the whole table
+	// instead of reading from the primary key or an index
+	fullScan bool
+
+	// for index unions, the candidates whose results are merged
+	branches []*candidate
+	// whether the cost of the branches was estimated
+	estimated bool
 }
 
+// cost of reading the whole table, as computed by Ranges.Cost
+const fullScanCost = 200
+
 func (c *candidate) Cost() int {
+	if len(c.branches) > 0 {
+		var cost int
+		for _, b := range c.branches {
+			cost += b.Cost()
+		}
+		return cost
+	}
+
 	// we start with the cost of ranges
 	cost := c.rangesCost
 
@@ -614,6 +1011,159 @@ func (c *candidate) Cost() int {
 	return cost
 }
 
+// selectByCost returns the candidate associated with the most nodes,
+// or the cheapest one if several of them are associated with the same number of nodes.
+func selectByCost(candidates []*candidate) *candidate {
+	var selected *candidate
+	var cost int
+
+	for _, c := range candidates {
+		if selected == nil {
+			selected = c
+			cost = c.Cost()
+			continue
+		}
+
+		cc := c.Cost()
+		if len(selected.nodes) < len(c.nodes) || (len(selected.nodes) == len(c.nodes) && cc < cost) {
+			cost = cc
+			selected = c
+		}
+	}
+
+	return selected
+}
+
+// estimatedCost returns the cost of the candidate based on the estimated
+// number of entries it reads.
+// Reading from an index is more expensive than reading from the primary key,
+// as each entry requires fetching the document from the table.
+func (c *candidate) estimatedCost() float64 {
+	if len(c.branches) > 0 {
+		var cost float64
+		for _, b := range c.branches {
+			cost += b.estimatedCost()
+		}
+		return cost
+	}
+
+	if c.isIndex {
+		return c.rows * 2
+	}
+	return c.rows
+}
+
+// cheaperThan returns whether c is cheaper than other, comparing
+// their estimated costs if estimated is true.
+func (c *candidate) cheaperThan(other *candidate, estimated bool) bool {
+	if estimated {
+		return c.estimatedCost() < other.estimatedCost()
+	}
+
+	return c.Cost() < other.Cost()
+}
+
+// selectByEstimation returns the candidate with the lowest estimated cost.
+func selectByEstimation(candidates []*candidate) *candidate {
+	var selected *candidate
+	var cost float64
+
+	for _, c := range candidates {
+		cc := c.estimatedCost()
+
+		if selected == nil || cc < cost ||
+			(cc == cost && (len(selected.nodes) < len(c.nodes) || (len(selected.nodes) == len(c.nodes) && c.Cost() < selected.Cost()))) {
+			cost = cc
+			selected = c
+		}
+	}
+
+	return selected
+}
+
+// estimateRows estimates the number of entries read by each candidate
+// using the statistics of the table. It returns false if the table
+// was not analyzed since some of the indexes were created.
+func (i *indexSelector) estimateRows(info *database.TableInfo, candidates []*candidate) bool {
+	if len(candidates) == 0 || !i.sctx.Catalog.HasStatistics(info.TableName) {
+		return false
+	}
+
+	stats := make([]*database.Statistics, len(candidates))
+	for j, c := range candidates {
+		// the number of documents of the table was already estimated
+		if c.fullScan {
+			continue
+		}
+
+		stats[j] = i.sctx.Catalog.GetStatistics(info.TableName, c.paths)
+		if stats[j] == nil {
+			return false
+		}
+	}
+
+	for j, c := range candidates {
+		if c.fullScan {
+			continue
+		}
+
+		if len(c.ranges) == 0 {
+			c.rows = float64(stats[j].RowCount)
+			continue
+		}
+
+		c.rows = 0
+		for k := range c.ranges {
+			r := &c.ranges[k]
+			n := len(r.Min)
+			if len(r.Max) > n {
+				n = len(r.Max)
+			}
+
+			c.rows += stats[j].EstimateRange(info, evalConstantRange(r), r.Exact, n)
+		}
+	}
+
+	return true
+}
+
+// evalConstantRange evaluates a range whose boundaries are constant expressions.
+// It returns nil if the range depends on parameters or on expressions that
+// can only be evaluated when the query is run.
+func evalConstantRange(r *stream.Range) *database.Range {
+	for _, l := range []expr.LiteralExprList{r.Min, r.Max} {
+		for _, e := range l {
+			if !isConstantExpr(e) {
+				return nil
+			}
+		}
+	}
+
+	rng, err := r.Eval(&environment.Environment{})
+	if err != nil {
+		return nil
+	}
+
+	return rng
+}
+
+// isConstantExpr returns whether e can be evaluated without a document,
+// parameters or a transaction.
+func isConstantExpr(e expr.Expr) bool {
+	constant := true
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch e.(type) {
+		case expr.Path, expr.PositionalParam, expr.NamedParam, *expr.Subquery, expr.NextValueFor:
+			constant = false
+			return false
+		}
+		return true
+	})
+
+	return constant
+}
+
 // operatorIsIndexCompatible returns whether the operator can be used to read from an index.
 func operatorIsIndexCompatible(op expr.Operator) bool {
 	switch op.Token() {
@@ -634,12 +1184,13 @@ func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
 	// invalid: a IN (b + 1, 2)
 	if op.Token() == scanner.IN {
 		if leftIsPath && !rightIsPath && !exprContainsPath(op.RightHand()) {
-			rh := op.RightHand()
-			// The IN operator can use indexes only if the right hand side is an expression list.
-			if _, ok := rh.(expr.LiteralExprList); !ok {
+			// The IN operator can use indexes only if the right hand side is a list
+			// of expressions or an array, each element being read separately.
+			el, ok := inOperands(op.RightHand())
+			if !ok {
 				return false, nil, nil
 			}
-			return true, document.Path(lf), rh
+			return true, document.Path(lf), el
 		}
 
 		return false, nil, nil
@@ -670,11 +1221,76 @@ func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
 	return false, nil, nil
 }
 
+// inOperands returns the elements of the right hand side of an IN operator.
+// Lists made of constant values only are precalculated into arrays,
+// whose elements are converted back to literals.
+// NULL elements never match and duplicates would read the same
+// documents twice, so both are removed.
+func inOperands(e expr.Expr) (expr.LiteralExprList, bool) {
+	var elems []expr.Expr
+
+	switch t := e.(type) {
+	case expr.LiteralExprList:
+		elems = t
+	case expr.LiteralValue:
+		if t.Value.Type() != types.ArrayValue {
+			return nil, false
+		}
+
+		err := types.As[types.Array](t.Value).Iterate(func(_ int, v types.Value) error {
+			elems = append(elems, expr.LiteralValue{Value: v})
+			return nil
+		})
+		if err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	el := make(expr.LiteralExprList, 0, len(elems))
+	for _, e := range elems {
+		lv, ok := e.(expr.LiteralValue)
+		if !ok {
+			el = append(el, e)
+			continue
+		}
+		if lv.Value.Type() == types.NullValue || containsValue(el, lv.Value) {
+			continue
+		}
+		el = append(el, lv)
+	}
+
+	if len(el) == 0 {
+		return nil, false
+	}
+
+	return el, true
+}
+
+// containsValue returns whether el contains a literal equal to v.
+func containsValue(el expr.LiteralExprList, v types.Value) bool {
+	for _, e := range el {
+		lv, ok := e.(expr.LiteralValue)
+		if !ok {
+			continue
+		}
+
+		if ok, err := types.IsEqual(lv.Value, v); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func exprContainsPath(e expr.Expr) bool {
 	var hasPath bool
 
 	expr.Walk(e, func(e expr.Expr) bool {
-		if _, ok := e.(expr.Path); ok {
+		switch e.(type) {
+		// subqueries may reference the paths of the outer query
+		case expr.Path, *expr.Subquery:
 			hasPath = true
 			return false
 		}
@@ -0,0 +1,212 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/join"
+	"github.com/genjidb/genji/internal/stream/table"
+)
+
+// SelectJoinIndex replaces nested loop joins by index lookups when possible.
+// A nested loop join iterates over the whole table on the right for each document
+// on the left. If the join condition contains an equality between a path of the table
+// on the right and an expression that only depends on the tables on the left, and if
+// that path is indexed, or is the primary key of the table, the documents on the right
+// can be looked up directly.
+// Example:
+//   CREATE INDEX b_y_idx ON b(y)
+//   SELECT * FROM a JOIN b ON a.x = b.y
+//   this:
+//     table.Scan("a") | join.NestedLoop(table.Scan("b"), a.x = b.y)
+//   becomes this:
+//     table.Scan("a") | join.IndexLookup("b", "b_y_idx", a.x, a.x = b.y)
+// The join condition is still evaluated on each document returned by the lookup.
+func SelectJoinIndex(sctx *StreamContext) error {
+	for _, j := range sctx.Joins {
+		if j.On == nil {
+			continue
+		}
+
+		// only joins on a whole table can use an index
+		scan, ok := j.Right.Op.(*table.ScanOperator)
+		if !ok || scan.GetPrev() != nil || len(scan.Ranges) > 0 {
+			continue
+		}
+
+		leftNames, err := joinLeftNames(sctx, j)
+		if err != nil {
+			return err
+		}
+
+		lookup, err := selectJoinLookup(sctx, j, scan.TableName, leftNames)
+		if err != nil {
+			return err
+		}
+		if lookup == nil {
+			continue
+		}
+
+		stream.InsertAfter(j, lookup)
+		if sctx.Stream.Op == j {
+			sctx.Stream.Op = lookup
+		}
+		sctx.Stream.Remove(j)
+	}
+
+	return nil
+}
+
+// joinLeftNames returns the names of the tables joined before j.
+func joinLeftNames(sctx *StreamContext, j *join.NestedLoopOperator) (map[string]struct{}, error) {
+	names := make(map[string]struct{})
+
+	for n := j.GetPrev(); n != nil; n = n.GetPrev() {
+		switch t := n.(type) {
+		case *table.ScanOperator:
+			names[tableRefName(t.TableName, t.Alias)] = struct{}{}
+		case *index.ScanOperator:
+			info, err := sctx.Catalog.GetIndexInfo(t.IndexName)
+			if err != nil {
+				return nil, err
+			}
+			names[tableRefName(info.Owner.TableName, t.Alias)] = struct{}{}
+		case *join.NestedLoopOperator:
+			names[t.Alias] = struct{}{}
+		case *join.IndexLookupOperator:
+			names[t.Alias] = struct{}{}
+		case *join.UnnestOperator:
+			names[t.Alias] = struct{}{}
+		case *stream.SubqueryOperator:
+			names[t.Alias] = struct{}{}
+		}
+	}
+
+	return names, nil
+}
+
+func tableRefName(tableName, alias string) string {
+	if alias != "" {
+		return alias
+	}
+
+	return tableName
+}
+
+// selectJoinLookup looks for an equality in the join condition that can use the primary key
+// or an index of the table on the right.
+func selectJoinLookup(sctx *StreamContext, j *join.NestedLoopOperator, tableName string, leftNames map[string]struct{}) (*join.IndexLookupOperator, error) {
+	tb, err := sctx.Catalog.GetTableInfo(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookup *join.IndexLookupOperator
+	var lookupUnique bool
+
+	for _, e := range splitANDExpr(j.On) {
+		op, ok := e.(expr.Operator)
+		if !ok || op.Token() != scanner.EQ {
+			continue
+		}
+
+		path, key := joinEqualityOperands(op, j.Alias, leftNames)
+		if path == nil {
+			continue
+		}
+
+		// the primary key is always the best choice
+		if pk := tb.GetPrimaryKey(); pk != nil && pk.Paths[0].IsEqual(path) {
+			return newJoinLookup(j, tableName, "", key), nil
+		}
+
+		for _, idxName := range sctx.Catalog.ListIndexes(tableName) {
+			idxInfo, err := sctx.Catalog.GetIndexInfo(idxName)
+			if err != nil {
+				return nil, err
+			}
+
+			// multikey indexes contain the elements of arrays
+			// and partial indexes only some of the documents
+			if idxInfo.Multikey || idxInfo.IsPartial() || !idxInfo.Paths[0].IsEqual(path) {
+				continue
+			}
+
+			// prefer unique indexes
+			if lookup == nil || (idxInfo.Unique && !lookupUnique) {
+				lookup = newJoinLookup(j, tableName, idxName, key)
+				lookupUnique = idxInfo.Unique
+			}
+		}
+	}
+
+	return lookup, nil
+}
+
+func newJoinLookup(j *join.NestedLoopOperator, tableName, indexName string, key expr.Expr) *join.IndexLookupOperator {
+	if j.Outer {
+		return join.LeftIndexLookup(tableName, j.Alias, indexName, key, j.On)
+	}
+
+	return join.IndexLookup(tableName, j.Alias, indexName, key, j.On)
+}
+
+// joinEqualityOperands returns the unqualified path of the table on the right and the expression it
+// is compared to, if that expression only references tables on the left.
+func joinEqualityOperands(op expr.Operator, alias string, leftNames map[string]struct{}) (document.Path, expr.Expr) {
+	if p := rightTablePath(op.LeftHand(), alias); p != nil && onlyReferences(op.RightHand(), leftNames) {
+		return p, op.RightHand()
+	}
+
+	if p := rightTablePath(op.RightHand(), alias); p != nil && onlyReferences(op.LeftHand(), leftNames) {
+		return p, op.LeftHand()
+	}
+
+	return nil, nil
+}
+
+// rightTablePath returns the path without the table name if e is a path
+// qualified by the given table name.
+func rightTablePath(e expr.Expr, name string) document.Path {
+	p, ok := e.(expr.Path)
+	if !ok || len(p) < 2 || p[0].FieldName != name {
+		return nil
+	}
+
+	return document.Path(p[1:])
+}
+
+// onlyReferences returns true if all the paths of e are qualified
+// by one of the given table names.
+func onlyReferences(e expr.Expr, names map[string]struct{}) bool {
+	ok := true
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		// the paths referenced by subqueries are unknown
+		if _, isSubquery := e.(*expr.Subquery); isSubquery {
+			ok = false
+			return false
+		}
+
+		p, isPath := e.(expr.Path)
+		if !isPath {
+			return true
+		}
+
+		if len(p) < 2 {
+			ok = false
+			return false
+		}
+
+		if _, found := names[p[0].FieldName]; !found {
+			ok = false
+			return false
+		}
+
+		return true
+	})
+
+	return ok
+}
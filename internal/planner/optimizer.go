@@ -8,6 +8,7 @@ import (
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/join"
 	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/types"
 )
@@ -19,6 +20,8 @@ var optimizerRules = []func(sctx *StreamContext) error{
 	RemoveUnnecessaryFilterNodesRule,
 	RemoveUnnecessaryTempSortNodesRule,
 	SelectIndex,
+	UseCoveringIndexRule,
+	SelectJoinIndex,
 }
 
 // Optimize takes a tree, applies a list of optimization rules
@@ -61,6 +64,7 @@ type StreamContext struct {
 	Filters       []*docs.FilterOperator
 	Projections   []*docs.ProjectOperator
 	TempTreeSorts []*docs.TempTreeSortOperator
+	Joins         []*join.NestedLoopOperator
 }
 
 func NewStreamContext(s *stream.Stream) *StreamContext {
@@ -71,19 +75,42 @@ func NewStreamContext(s *stream.Stream) *StreamContext {
 	n := s.First()
 
 	prevIsFilter := false
+	aggregated := false
+	windowed := false
+	unnested := false
 
 	for n != nil {
 		switch t := n.(type) {
 		case *docs.FilterOperator:
-			if prevIsFilter || len(sctx.Filters) == 0 {
+			// filters located after a join are evaluated on joined documents
+			// and can't be associated with the first table.
+			// The same goes for filters located after an aggregation, like HAVING,
+			// or after the computation of window functions.
+			if len(sctx.Joins) == 0 && !unnested && !aggregated && !windowed && (prevIsFilter || len(sctx.Filters) == 0) {
 				sctx.Filters = append(sctx.Filters, t)
 				prevIsFilter = true
 			}
+		case *docs.GroupAggregateOperator:
+			aggregated = true
+			prevIsFilter = false
+		case *docs.WindowOperator:
+			windowed = true
+			prevIsFilter = false
+		case *join.NestedLoopOperator:
+			sctx.Joins = append(sctx.Joins, t)
+			prevIsFilter = false
+		case *join.UnnestOperator:
+			unnested = true
+			prevIsFilter = false
 		case *docs.ProjectOperator:
 			sctx.Projections = append(sctx.Projections, t)
 			prevIsFilter = false
 		case *docs.TempTreeSortOperator:
-			sctx.TempTreeSorts = append(sctx.TempTreeSorts, t)
+			// documents sorted after a window operator carry the values
+			// of the window functions and can't be read from an index.
+			if !windowed {
+				sctx.TempTreeSorts = append(sctx.TempTreeSorts, t)
+			}
 			prevIsFilter = false
 		}
 
@@ -228,7 +255,12 @@ func PrecalculateExprRule(sctx *StreamContext) error {
 				}
 			}
 		case *docs.TempTreeSortOperator:
-			t.Expr, err = precalculateExpr(t.Expr)
+			for i := range t.Keys {
+				t.Keys[i].Expr, err = precalculateExpr(t.Keys[i].Expr)
+				if err != nil {
+					return err
+				}
+			}
 		case *path.SetOperator:
 			t.Expr, err = precalculateExpr(t.Expr)
 		case *docs.EmitOperator:
@@ -424,7 +456,13 @@ func RemoveUnnecessaryProjection(sctx *StreamContext) error {
 // In the following case, we can remove the second TempSort node.
 // 		SELECT * FROM foo GROUP BY a ORDER BY a
 //		table.Scan('foo') | docs.TempSort(a) | docs.GroupBy(a) | docs.TempSort(a)
-// This only works if both temp sort nodes use the same path
+// The same goes when grouping by multiple paths, if the documents are ordered
+// by the same paths, in the same order:
+// 		SELECT * FROM foo GROUP BY a, b ORDER BY a DESC, b
+//		table.Scan('foo') | docs.TempSort([a, b]) | docs.GroupBy([a, b]) | docs.TempSort(a DESC, b)
+// This only works if both temp sort nodes use the same paths.
+// The remaining TempSort node can then be replaced by an index scan by the SelectIndex rule,
+// if an index or the primary key of the table already provides that order.
 func RemoveUnnecessaryTempSortNodesRule(sctx *StreamContext) error {
 	if len(sctx.TempTreeSorts) > 2 {
 		panic("unexpected number of TempSort nodes")
@@ -434,23 +472,46 @@ func RemoveUnnecessaryTempSortNodesRule(sctx *StreamContext) error {
 		return nil
 	}
 
-	lpath, ok := sctx.TempTreeSorts[0].Expr.(expr.Path)
-	if !ok {
+	// the first node sorts the documents before grouping them,
+	// either by a single path or by a list of paths.
+	if len(sctx.TempTreeSorts[0].Keys) != 1 {
 		return nil
 	}
 
-	rpath, ok := sctx.TempTreeSorts[1].Expr.(expr.Path)
-	if !ok {
+	var lpaths []expr.Expr
+	switch t := sctx.TempTreeSorts[0].Keys[0].Expr.(type) {
+	case expr.Path:
+		lpaths = []expr.Expr{t}
+	case expr.LiteralExprList:
+		lpaths = t
+	default:
 		return nil
 	}
 
-	if !lpath.IsEqual(rpath) {
+	rkeys := sctx.TempTreeSorts[1].Keys
+	if len(lpaths) != len(rkeys) {
 		return nil
 	}
 
+	for i, k := range rkeys {
+		lpath, ok := lpaths[i].(expr.Path)
+		if !ok {
+			return nil
+		}
+
+		rpath, ok := k.Expr.(expr.Path)
+		if !ok {
+			return nil
+		}
+
+		if !lpath.IsEqual(rpath) {
+			return nil
+		}
+	}
+
 	// we remove the rightmost one
-	// and we override the direction of the first one
-	sctx.TempTreeSorts[0].Desc = sctx.TempTreeSorts[1].Desc
+	// and we use its keys to sort the documents before grouping them
+	sctx.TempTreeSorts[0].Keys = rkeys
 	sctx.removeTempTreeNodeNode(sctx.TempTreeSorts[1])
 
 	return nil
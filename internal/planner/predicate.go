@@ -0,0 +1,303 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/types"
+)
+
+// impliesPredicate returns whether the given conditions imply the predicate
+// of a partial index, i.e. if all the documents matching the conditions are
+// stored in the index.
+// Each operand of the AND operators of the predicate must be implied by one of the conditions.
+// A condition implies another one if they are equal, or if they compare the same path
+// with constant values and every value matching the condition also matches the other one.
+// Example, with an index created with WHERE status != 'done':
+//
+//	status != 'done'            -> implied
+//	status = 'pending'          -> implied
+//	status IN ['new', 'failed'] -> implied
+//	status > 'e'                -> implied
+//	status = ?                  -> not implied
+func (i *indexSelector) impliesPredicate(conds []expr.Expr, info *database.IndexInfo) bool {
+	pred, ok := info.Predicate.(*expr.ConstraintExpr)
+	if !ok {
+		return false
+	}
+
+	var all []expr.Expr
+	for _, c := range conds {
+		all = append(all, conjuncts(c)...)
+	}
+
+	for _, p := range conjuncts(pred.Expr) {
+		var implied bool
+		for _, c := range all {
+			if i.implies(c, p) {
+				implied = true
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+// conjuncts splits an expression by AND operator, ignoring parentheses.
+func conjuncts(e expr.Expr) []expr.Expr {
+	e = unwrapParentheses(e)
+
+	op, ok := e.(expr.Operator)
+	if ok && op.Token() == scanner.AND {
+		return append(conjuncts(op.LeftHand()), conjuncts(op.RightHand())...)
+	}
+
+	return []expr.Expr{e}
+}
+
+// implies returns whether all the documents matching c also match p.
+func (i *indexSelector) implies(c, p expr.Expr) bool {
+	if expr.Equal(c, p) {
+		return true
+	}
+
+	pcs := comparisonsOf(p)
+	if len(pcs) != 1 {
+		return false
+	}
+	pc := pcs[0]
+
+	// a condition made of multiple comparisons, like BETWEEN, implies p
+	// if one of them does
+	for _, cc := range comparisonsOf(c) {
+		if i.unqualifiedPath(cc.path).IsEqual(pc.path) && cc.implies(&pc) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A comparison is a condition of the form path <op> value.
+type comparison struct {
+	path document.Path
+	op   scanner.Token
+	// values is the list of values of the IN operator
+	// or contains the compared value. It is empty for IS NOT NULL.
+	values []types.Value
+}
+
+// comparisonsOf returns the comparisons that are true for the documents matching e,
+// or nothing if e doesn't compare a path with constant values.
+func comparisonsOf(e expr.Expr) []comparison {
+	op, ok := unwrapParentheses(e).(expr.Operator)
+	if !ok {
+		return nil
+	}
+
+	switch op.Token() {
+	case scanner.EQ, scanner.NEQ, scanner.GT, scanner.GTE, scanner.LT, scanner.LTE:
+		if p, ok := op.LeftHand().(expr.Path); ok {
+			if v, ok := constantValue(op.RightHand()); ok {
+				return []comparison{{path: document.Path(p), op: op.Token(), values: []types.Value{v}}}
+			}
+		}
+
+		// 10 < a is equivalent to a > 10
+		if p, ok := op.RightHand().(expr.Path); ok {
+			if v, ok := constantValue(op.LeftHand()); ok {
+				return []comparison{{path: document.Path(p), op: swapOperator(op.Token()), values: []types.Value{v}}}
+			}
+		}
+	case scanner.IN:
+		p, ok := op.LeftHand().(expr.Path)
+		if !ok {
+			return nil
+		}
+
+		var vs []types.Value
+		switch t := op.RightHand().(type) {
+		case expr.LiteralValue:
+			if t.Value.Type() != types.ArrayValue {
+				return nil
+			}
+			err := types.As[types.Array](t.Value).Iterate(func(_ int, v types.Value) error {
+				vs = append(vs, v)
+				return nil
+			})
+			if err != nil {
+				return nil
+			}
+		case expr.LiteralExprList:
+			for _, e := range t {
+				v, ok := constantValue(e)
+				if !ok {
+					return nil
+				}
+				vs = append(vs, v)
+			}
+		default:
+			return nil
+		}
+
+		for _, v := range vs {
+			if v.Type() == types.NullValue {
+				return nil
+			}
+		}
+
+		return []comparison{{path: document.Path(p), op: scanner.IN, values: vs}}
+	case scanner.BETWEEN:
+		bt := op.(*expr.BetweenOperator)
+		p, ok := bt.X.(expr.Path)
+		if !ok {
+			return nil
+		}
+		min, ok := constantValue(bt.LeftHand())
+		if !ok {
+			return nil
+		}
+		max, ok := constantValue(bt.RightHand())
+		if !ok {
+			return nil
+		}
+
+		return []comparison{
+			{path: document.Path(p), op: scanner.GTE, values: []types.Value{min}},
+			{path: document.Path(p), op: scanner.LTE, values: []types.Value{max}},
+		}
+	case scanner.ISN:
+		p, ok := op.LeftHand().(expr.Path)
+		if !ok {
+			return nil
+		}
+		if v, ok := op.RightHand().(expr.LiteralValue); ok && v.Value.Type() == types.NullValue {
+			return []comparison{{path: document.Path(p), op: scanner.ISN}}
+		}
+	}
+
+	return nil
+}
+
+// constantValue returns the value of e if it is a literal value other than NULL.
+func constantValue(e expr.Expr) (types.Value, bool) {
+	lv, ok := e.(expr.LiteralValue)
+	if !ok || lv.Value.Type() == types.NullValue {
+		return nil, false
+	}
+
+	return lv.Value, true
+}
+
+func swapOperator(tok scanner.Token) scanner.Token {
+	switch tok {
+	case scanner.GT:
+		return scanner.LT
+	case scanner.GTE:
+		return scanner.LTE
+	case scanner.LT:
+		return scanner.GT
+	case scanner.LTE:
+		return scanner.GTE
+	}
+
+	return tok
+}
+
+// implies returns whether all the values matching c also match p.
+// Both comparisons must be on the same path.
+func (c *comparison) implies(p *comparison) bool {
+	// comparing with NULL is never true
+	if p.op == scanner.ISN {
+		return true
+	}
+
+	switch c.op {
+	case scanner.EQ, scanner.IN:
+		for _, v := range c.values {
+			if !p.matches(v) {
+				return false
+			}
+		}
+
+		return true
+	case scanner.NEQ:
+		return p.op == scanner.NEQ && sameOrdering(c.values[0], p.values[0]) && compareValues(scanner.EQ, c.values[0], p.values[0])
+	case scanner.GT, scanner.GTE:
+		if p.op != scanner.GT && p.op != scanner.GTE && p.op != scanner.NEQ {
+			return false
+		}
+		if !sameOrdering(c.values[0], p.values[0]) {
+			return false
+		}
+
+		// a > 10 implies a >= 10, a >= 10 doesn't imply a > 10
+		if c.op == scanner.GT || p.op == scanner.GTE {
+			return compareValues(scanner.GTE, c.values[0], p.values[0])
+		}
+		return compareValues(scanner.GT, c.values[0], p.values[0])
+	case scanner.LT, scanner.LTE:
+		if p.op != scanner.LT && p.op != scanner.LTE && p.op != scanner.NEQ {
+			return false
+		}
+		if !sameOrdering(c.values[0], p.values[0]) {
+			return false
+		}
+
+		if c.op == scanner.LT || p.op == scanner.LTE {
+			return compareValues(scanner.LTE, c.values[0], p.values[0])
+		}
+		return compareValues(scanner.LT, c.values[0], p.values[0])
+	}
+
+	return false
+}
+
+// matches returns whether the comparison is true for v.
+func (c *comparison) matches(v types.Value) bool {
+	if c.op != scanner.IN {
+		return compareValues(c.op, v, c.values[0])
+	}
+
+	for _, other := range c.values {
+		if compareValues(scanner.EQ, v, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameOrdering returns whether the values are ordered the same way
+// regardless of the type of the compared path.
+func sameOrdering(a, b types.Value) bool {
+	return a.Type() == b.Type() || (a.Type().IsNumber() && b.Type().IsNumber())
+}
+
+func compareValues(op scanner.Token, a, b types.Value) bool {
+	var ok bool
+	var err error
+
+	switch op {
+	case scanner.EQ:
+		ok, err = types.IsEqual(a, b)
+	case scanner.NEQ:
+		ok, err = types.IsNotEqual(a, b)
+	case scanner.GT:
+		ok, err = types.IsGreaterThan(a, b)
+	case scanner.GTE:
+		ok, err = types.IsGreaterThanOrEqual(a, b)
+	case scanner.LT:
+		ok, err = types.IsLesserThan(a, b)
+	case scanner.LTE:
+		ok, err = types.IsLesserThanOrEqual(a, b)
+	}
+
+	return ok && err == nil
+}
@@ -114,7 +114,9 @@ func (q Query) Run(context *Context) (*statement.Result, error) {
 		if qa, ok := stmt.(queryAlterer); ok {
 			err = qa.alterQuery(context.DB, &q)
 			if err != nil {
-				if tx := context.GetTx(); tx != nil {
+				// savepoint errors leave the transaction open,
+				// like the errors returned by other statements
+				if tx := context.GetTx(); tx != nil && !isSavepointStmt(stmt) {
 					tx.Rollback()
 				}
 				return nil, err
@@ -190,3 +192,12 @@ func (q Query) Run(context *Context) (*statement.Result, error) {
 type queryAlterer interface {
 	alterQuery(db *database.Database, q *Query) error
 }
+
+func isSavepointStmt(stmt statement.Statement) bool {
+	switch stmt.(type) {
+	case SavepointStmt, RollbackToSavepointStmt, ReleaseSavepointStmt:
+		return true
+	}
+
+	return false
+}
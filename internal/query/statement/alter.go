@@ -2,8 +2,15 @@ package statement
 
 import (
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
 )
 
 // AlterStmt is a DSL that allows creating a full ALTER TABLE query.
@@ -34,7 +41,13 @@ func (stmt AlterStmt) Run(ctx *Context) (Result, error) {
 		return res, errs.AlreadyExistsError{Name: stmt.NewTableName}
 	}
 
-	err := ctx.Catalog.RenameTable(ctx.Tx, stmt.TableName, stmt.NewTableName)
+	// views refer to the table by name
+	err := ensureNoDependentViews(ctx, "rename table", stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.RenameTable(ctx.Tx, stmt.TableName, stmt.NewTableName)
 	return res, err
 }
 
@@ -57,6 +70,308 @@ func (stmt AlterTableAddField) Run(ctx *Context) (Result, error) {
 		fc = stmt.Info.FieldConstraints.Ordered[0]
 	}
 
-	err := ctx.Catalog.AddFieldConstraint(ctx.Tx, stmt.Info.TableName, fc, stmt.Info.TableConstraints)
+	// the primary key is added once the field exists
+	var pk *database.TableConstraint
+	var tcs database.TableConstraints
+	for _, tc := range stmt.Info.TableConstraints {
+		if tc.PrimaryKey {
+			pk = tc
+			continue
+		}
+		tcs = append(tcs, tc)
+	}
+
+	if pk != nil {
+		ti, err := ctx.Catalog.GetTableInfo(stmt.Info.TableName)
+		if err != nil {
+			return res, err
+		}
+		if ti.GetPrimaryKey() != nil {
+			return res, errors.Errorf("multiple primary keys for table %q are not allowed", ti.TableName)
+		}
+	}
+
+	err := ctx.Catalog.AddFieldConstraint(ctx.Tx, stmt.Info.TableName, fc, tcs)
+	if err != nil || pk == nil {
+		return res, err
+	}
+
+	err = addPrimaryKey(ctx, stmt.Info.TableName, pk)
 	return res, err
 }
+
+// addPrimaryKey rewrites the table under the given primary key
+// and rebuilds its indexes, which reference the documents by key.
+func addPrimaryKey(ctx *Context, tableName string, tc *database.TableConstraint) error {
+	err := ctx.Catalog.AddPrimaryKey(ctx.Tx, tableName, tc)
+	if err != nil {
+		return err
+	}
+
+	return reindexTable(ctx, tableName)
+}
+
+// reindexTable truncates and refills all the indexes of a table.
+func reindexTable(ctx *Context, tableName string) error {
+	for _, indexName := range ctx.Catalog.ListIndexes(tableName) {
+		idx, err := ctx.Catalog.GetIndex(ctx.Tx, indexName)
+		if err != nil {
+			return err
+		}
+
+		err = idx.Truncate()
+		if err != nil {
+			return err
+		}
+
+		s := stream.New(table.Scan(tableName)).
+			Pipe(index.IndexInsert(indexName)).
+			Pipe(stream.Discard())
+
+		it := StreamStmtIterator{Stream: s, Context: ctx}
+		err = it.Iterate(func(d types.Document) error { return nil })
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AlterTableAddConstraint is a DSL that allows creating a full ALTER TABLE ADD CONSTRAINT query.
+type AlterTableAddConstraint struct {
+	TableName  string
+	Constraint *database.TableConstraint
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableAddConstraint) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE ADD CONSTRAINT statement in the given transaction.
+// It implements the Statement interface.
+// Existing documents are validated against the new constraint
+// and the statement fails if one of them violates it.
+func (stmt AlterTableAddConstraint) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	tc := stmt.Constraint
+	if tc.PrimaryKey {
+		return res, addPrimaryKey(ctx, stmt.TableName, tc)
+	}
+
+	err := ctx.Catalog.AddFieldConstraint(ctx.Tx, stmt.TableName, nil, database.TableConstraints{tc})
+	if err != nil {
+		return res, err
+	}
+
+	tb, err := ctx.Catalog.GetTable(ctx.Tx, stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	if tc.Check != nil || tc.ForeignKey != nil {
+		tcs := database.TableConstraints{tc}
+		err = tb.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
+			var err error
+			if tc.Check != nil {
+				err = tcs.ValidateDocument(ctx.Tx, d)
+			} else {
+				err = ctx.Catalog.ValidateForeignKey(ctx.Tx, tb.Info, tc, d)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "cannot add constraint %q on document %s", tc.Name, types.NewDocumentValue(d))
+			}
+			return nil
+		})
+		return res, err
+	}
+
+	// create a unique index for the unique constraint
+	// and fill it with the existing documents
+	info := database.IndexInfo{
+		Paths:  tc.Paths,
+		Unique: true,
+		Owner: database.Owner{
+			TableName: stmt.TableName,
+			Paths:     tc.Paths,
+		},
+	}
+	err = ctx.Catalog.CreateIndex(ctx.Tx, &info)
+	if err != nil {
+		return res, err
+	}
+
+	s := stream.New(table.Scan(stmt.TableName)).
+		Pipe(index.Validate(info.IndexName)).
+		Pipe(index.IndexInsert(info.IndexName)).
+		Pipe(stream.Discard())
+
+	// run the stream immediately to report any violation
+	it := StreamStmtIterator{Stream: s, Context: ctx}
+	err = it.Iterate(func(d types.Document) error { return nil })
+	var cerr *database.ConstraintViolationError
+	if errors.As(err, &cerr) {
+		d, derr := tb.GetDocument(cerr.Key)
+		if derr != nil {
+			return res, derr
+		}
+		return res, errors.Wrapf(err, "cannot add constraint %q on document %s", tc.Name, types.NewDocumentValue(d))
+	}
+	return res, err
+}
+
+// AlterTableDropConstraint is a DSL that allows creating a full ALTER TABLE DROP CONSTRAINT query.
+type AlterTableDropConstraint struct {
+	TableName      string
+	ConstraintName string
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableDropConstraint) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE DROP CONSTRAINT statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableDropConstraint) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	ti, err := ctx.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
+	var isPK bool
+	for _, tc := range ti.TableConstraints {
+		if tc.PrimaryKey && tc.Name == stmt.ConstraintName {
+			isPK = true
+		}
+	}
+
+	err = ctx.Catalog.DropTableConstraint(ctx.Tx, stmt.TableName, stmt.ConstraintName)
+	if err != nil || !isPK {
+		return res, err
+	}
+
+	// documents are now identified by a docid
+	err = reindexTable(ctx, stmt.TableName)
+	return res, err
+}
+
+// AlterTableDropField is a DSL that allows creating a full ALTER TABLE DROP FIELD query.
+type AlterTableDropField struct {
+	TableName string
+	Path      document.Path
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableDropField) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE DROP FIELD statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableDropField) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	err := ensureFieldIsNotUsed(ctx, stmt.TableName, stmt.Path)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.DropFieldConstraint(ctx.Tx, stmt.TableName, stmt.Path)
+	return res, err
+}
+
+// AlterTableAlterFieldType is a DSL that allows creating a full ALTER TABLE ALTER FIELD TYPE query.
+type AlterTableAlterFieldType struct {
+	TableName string
+	Path      document.Path
+	Type      types.ValueType
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AlterTableAlterFieldType) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the ALTER TABLE ALTER FIELD TYPE statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AlterTableAlterFieldType) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	err := ensureFieldIsNotUsed(ctx, stmt.TableName, stmt.Path)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.AlterFieldConstraintType(ctx.Tx, stmt.TableName, stmt.Path, stmt.Type)
+	return res, err
+}
+
+// ensureFieldIsNotUsed returns an error if an index or a table constraint
+// of the table depends on the field at the given path, its parents or its children.
+func ensureFieldIsNotUsed(ctx *Context, tableName string, path document.Path) error {
+	info, err := ctx.Catalog.GetTableInfo(tableName)
+	if err != nil {
+		return err
+	}
+
+	for _, idxName := range ctx.Catalog.ListIndexes(tableName) {
+		idxInfo, err := ctx.Catalog.GetIndexInfo(idxName)
+		if err != nil {
+			return err
+		}
+
+		paths := idxInfo.Paths
+		if c, ok := idxInfo.Predicate.(*expr.ConstraintExpr); ok {
+			paths = append(paths[:len(paths):len(paths)], constraintPaths(c)...)
+		}
+
+		for _, p := range paths {
+			if pathsOverlap(p, path) {
+				return errors.Errorf("cannot alter field %q: index %q depends on it", path, idxName)
+			}
+		}
+	}
+
+	for _, tc := range info.TableConstraints {
+		paths := tc.Paths
+		if c, ok := tc.Check.(*expr.ConstraintExpr); ok {
+			paths = append(paths[:len(paths):len(paths)], constraintPaths(c)...)
+		}
+
+		for _, p := range paths {
+			if pathsOverlap(p, path) {
+				return errors.Errorf("cannot alter field %q: constraint %q depends on it", path, tc.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// constraintPaths returns the paths used by the expression of a constraint.
+func constraintPaths(c *expr.ConstraintExpr) []document.Path {
+	var paths []document.Path
+
+	expr.Walk(c.Expr, func(e expr.Expr) bool {
+		if p, ok := e.(expr.Path); ok {
+			paths = append(paths, document.Path(p))
+		}
+		return true
+	})
+
+	return paths
+}
+
+// pathsOverlap returns true if one of the paths is a prefix of the other.
+func pathsOverlap(a, b document.Path) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	return a.IsEqual(b[:len(a)])
+}
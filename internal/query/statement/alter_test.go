@@ -1,6 +1,7 @@
 package statement_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/genjidb/genji"
@@ -46,3 +47,38 @@ func TestAlterTable(t *testing.T) {
 	err = db.Exec("ALTER TABLE __genji_catalog RENAME TO bar")
 	assert.Error(t, err)
 }
+
+func TestAlterTableFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdb")
+
+	db, err := genji.Open(path)
+	assert.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE foo (a INTEGER, b INTEGER, c TEXT);
+		INSERT INTO foo (a, b, c) VALUES (1, 2, "x");
+		ALTER TABLE foo DROP FIELD b;
+		ALTER TABLE foo ALTER FIELD a TYPE DOUBLE;
+	`)
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// ensure the changes are persisted
+	db, err = genji.Open(path)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d, err := db.QueryDocument(`SELECT sql FROM __genji_catalog WHERE name = "foo"`)
+	assert.NoError(t, err)
+	data, err := document.MarshalJSON(d)
+	assert.NoError(t, err)
+	require.JSONEq(t, `{"sql": "CREATE TABLE foo (a DOUBLE, c TEXT)"}`, string(data))
+
+	d, err = db.QueryDocument("SELECT * FROM foo")
+	assert.NoError(t, err)
+	data, err = document.MarshalJSON(d)
+	assert.NoError(t, err)
+	require.JSONEq(t, `{"a": 1.0, "c": "x"}`, string(data))
+}
@@ -0,0 +1,47 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/genjidb/genji/internal/database"
+)
+
+// AnalyzeStmt is a DSL that allows creating an ANALYZE statement.
+// It collects the statistics used by the planner to estimate
+// the number of documents selected by the primary key and the indexes
+// of a table.
+type AnalyzeStmt struct {
+	// TableName is the table to analyze.
+	// If empty, all the tables are analyzed.
+	TableName string
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AnalyzeStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the Analyze statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AnalyzeStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	tableNames := []string{stmt.TableName}
+	if stmt.TableName == "" {
+		tableNames = tableNames[:0]
+		for _, name := range ctx.Catalog.Cache.ListObjects(database.RelationTableType) {
+			if !strings.HasPrefix(name, database.InternalPrefix) {
+				tableNames = append(tableNames, name)
+			}
+		}
+	}
+
+	for _, name := range tableNames {
+		err := ctx.Catalog.AnalyzeTable(ctx.Tx, name)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
@@ -3,6 +3,7 @@ package statement
 import (
 	"math"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/stream"
@@ -134,3 +135,91 @@ func (stmt *CreateSequenceStmt) Run(ctx *Context) (Result, error) {
 	}
 	return res, err
 }
+
+// CreateViewStmt represents a parsed CREATE VIEW statement.
+type CreateViewStmt struct {
+	IfNotExists bool
+	Info        database.ViewInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run the statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Catalog.GetViewInfo(stmt.Info.ViewName)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	// ensure the query of the view is valid
+	sel, err := parseViewQuery(stmt.Info.Query)
+	if err != nil {
+		return res, err
+	}
+	_, err = sel.Prepare(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.CreateView(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists {
+		if errs.IsAlreadyExistsError(err) {
+			return res, nil
+		}
+	}
+	return res, err
+}
+
+// CreateTriggerStmt represents a parsed CREATE TRIGGER statement.
+type CreateTriggerStmt struct {
+	IfNotExists bool
+	Info        database.TriggerInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateTriggerStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run the statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateTriggerStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Catalog.GetTriggerInfo(stmt.Info.TriggerName)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	// ensure the statement of the trigger is valid
+	st, err := ParseStatement(stmt.Info.Statement)
+	if err != nil {
+		return res, err
+	}
+	p, ok := st.(Preparer)
+	if !ok {
+		return res, errors.Errorf("unsupported trigger statement %s", stmt.Info.Statement)
+	}
+	_, err = p.Prepare(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.CreateTrigger(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists {
+		if errs.IsAlreadyExistsError(err) {
+			return res, nil
+		}
+	}
+	return res, err
+}
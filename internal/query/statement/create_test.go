@@ -1,12 +1,15 @@
 package statement_test
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/genjidb/genji"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func ParseDocumentPath(t testing.TB, str string) document.Path {
@@ -141,3 +144,63 @@ func TestCreateSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateView(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdb")
+
+	db, err := genji.Open(path)
+	assert.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE foo (a INTEGER, b TEXT);
+		INSERT INTO foo (a, b) VALUES (1, "x"), (2, "y");
+		CREATE VIEW v AS SELECT a FROM foo WHERE b = "y";
+	`)
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// ensure the view is persisted
+	db, err = genji.Open(path)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d, err := db.QueryDocument("SELECT * FROM v")
+	assert.NoError(t, err)
+	data, err := document.MarshalJSON(d)
+	assert.NoError(t, err)
+	require.JSONEq(t, `{"a": 2}`, string(data))
+}
+
+func TestCreateTrigger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdb")
+
+	db, err := genji.Open(path)
+	assert.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE foo (a INTEGER);
+		CREATE TABLE bar (a INTEGER, b INTEGER);
+		CREATE TRIGGER foo_update AFTER UPDATE ON foo DO INSERT INTO bar (a, b) VALUES (OLD.a, NEW.a);
+		INSERT INTO foo (a) VALUES (1);
+	`)
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// ensure the trigger is persisted
+	db, err = genji.Open(path)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("UPDATE foo SET a = 2")
+	assert.NoError(t, err)
+
+	d, err := db.QueryDocument("SELECT * FROM bar")
+	assert.NoError(t, err)
+	data, err := document.MarshalJSON(d)
+	assert.NoError(t, err)
+	require.JSONEq(t, `{"a": 1, "b": 2}`, string(data))
+}
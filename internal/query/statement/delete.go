@@ -1,24 +1,26 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/genjidb/genji/internal/expr"
-	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // DeleteConfig holds DELETE configuration.
 type DeleteStmt struct {
 	basePreparedStatement
 
-	TableName        string
-	WhereExpr        expr.Expr
-	OffsetExpr       expr.Expr
-	OrderBy          expr.Path
-	LimitExpr        expr.Expr
-	OrderByDirection scanner.Token
+	TableName  string
+	WhereExpr  expr.Expr
+	OffsetExpr expr.Expr
+	OrderBy    []expr.SortKey
+	LimitExpr  expr.Expr
 }
 
 func NewDeleteStatement() *DeleteStmt {
@@ -33,18 +35,18 @@ func NewDeleteStatement() *DeleteStmt {
 }
 
 func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
+	if _, err := prepareSubqueries(c, stmt.WhereExpr, stmt.OffsetExpr, stmt.LimitExpr); err != nil {
+		return nil, err
+	}
+
 	s := stream.New(table.Scan(stmt.TableName))
 
 	if stmt.WhereExpr != nil {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
-	if stmt.OrderBy != nil {
-		if stmt.OrderByDirection == scanner.DESC {
-			s = s.Pipe(docs.TempTreeSortReverse(stmt.OrderBy))
-		} else {
-			s = s.Pipe(docs.TempTreeSort(stmt.OrderBy))
-		}
+	if len(stmt.OrderBy) > 0 {
+		s = s.Pipe(docs.TempTreeSortBy(stmt.OrderBy...))
 	}
 
 	if stmt.OffsetExpr != nil {
@@ -71,3 +73,32 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+func (stmt *DeleteStmt) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "DELETE FROM %s", stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&sb, " WHERE %s", stmt.WhereExpr)
+	}
+
+	for i, k := range stmt.OrderBy {
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(k.String())
+	}
+
+	if stmt.LimitExpr != nil {
+		fmt.Fprintf(&sb, " LIMIT %s", stmt.LimitExpr)
+	}
+
+	if stmt.OffsetExpr != nil {
+		fmt.Fprintf(&sb, " OFFSET %s", stmt.OffsetExpr)
+	}
+
+	return sb.String()
+}
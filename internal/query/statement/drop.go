@@ -2,6 +2,9 @@ package statement
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	errs "github.com/genjidb/genji/internal/errors"
@@ -36,6 +39,11 @@ func (stmt DropTableStmt) Run(ctx *Context) (Result, error) {
 		return res, err
 	}
 
+	err = ensureNoDependentViews(ctx, "drop table", stmt.TableName)
+	if err != nil {
+		return res, err
+	}
+
 	err = ctx.Catalog.DropTable(ctx.Tx, stmt.TableName)
 	if err != nil {
 		return res, err
@@ -52,6 +60,71 @@ func (stmt DropTableStmt) Run(ctx *Context) (Result, error) {
 	return res, err
 }
 
+// ensureNoDependentViews returns an error if a view reads the given table or view,
+// as the action would break it.
+func ensureNoDependentViews(ctx *Context, action string, name string) error {
+	views, err := dependentViews(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	switch len(views) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Errorf("cannot %s %q: view %q depends on it", action, name, views[0])
+	default:
+		quoted := make([]string, len(views))
+		for i, v := range views {
+			quoted[i] = strconv.Quote(v)
+		}
+		return errors.Errorf("cannot %s %q: views %s depend on it", action, name, strings.Join(quoted, ", "))
+	}
+}
+
+// dependentViews returns the names of the views that read the given table,
+// either directly or through other views.
+func dependentViews(ctx *Context, tableName string) ([]string, error) {
+	refs := make(map[string][]string)
+	for _, name := range ctx.Catalog.ListViews() {
+		info, err := ctx.Catalog.GetViewInfo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		sel, err := parseViewQuery(info.Query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid view %q", name)
+		}
+
+		refs[name] = sel.tableNames()
+	}
+
+	var views []string
+	used := map[string]bool{tableName: true}
+	for changed := true; changed; {
+		changed = false
+
+		for _, name := range ctx.Catalog.ListViews() {
+			if used[name] {
+				continue
+			}
+
+			for _, ref := range refs[name] {
+				if used[ref] {
+					used[name] = true
+					views = append(views, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	sort.Strings(views)
+	return views, nil
+}
+
 // DropIndexStmt is a DSL that allows creating a DROP INDEX query.
 type DropIndexStmt struct {
 	IndexName string
@@ -119,3 +192,64 @@ func (stmt DropSequenceStmt) Run(ctx *Context) (Result, error) {
 
 	return res, err
 }
+
+// DropViewStmt is a DSL that allows creating a DROP VIEW query.
+type DropViewStmt struct {
+	ViewName string
+	IfExists bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt DropViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the DropView statement in the given transaction.
+// It implements the Statement interface.
+func (stmt DropViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.ViewName == "" {
+		return res, errors.New("missing view name")
+	}
+
+	err := ensureNoDependentViews(ctx, "drop view", stmt.ViewName)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Catalog.DropView(ctx.Tx, stmt.ViewName)
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		err = nil
+	}
+
+	return res, err
+}
+
+// DropTriggerStmt is a DSL that allows creating a DROP TRIGGER query.
+type DropTriggerStmt struct {
+	TriggerName string
+	IfExists    bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt DropTriggerStmt) IsReadOnly() bool {
+	return false
+}
+
+// Run runs the DropTrigger statement in the given transaction.
+// It implements the Statement interface.
+func (stmt DropTriggerStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.TriggerName == "" {
+		return res, errors.New("missing trigger name")
+	}
+
+	err := ctx.Catalog.DropTrigger(ctx.Tx, stmt.TriggerName)
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		err = nil
+	}
+
+	return res, err
+}
@@ -2,6 +2,7 @@ package statement
 
 import (
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
@@ -11,10 +12,17 @@ import (
 // ExplainStmt is a Statement that
 // displays information about how a statement
 // is going to be executed, without executing it.
+// With Analyze, the statement is executed and its changes
+// are rolled back.
 type ExplainStmt struct {
 	Statement Preparer
+	Analyze   bool
 }
 
+// name of the savepoint used to roll back the changes
+// made by EXPLAIN ANALYZE.
+const explainAnalyzeSavepoint = "__genji_explain_analyze"
+
 // Run analyses the inner statement and displays its execution plan.
 // If the statement is a stream, Optimize will be called prior to
 // displaying all the operations.
@@ -30,6 +38,10 @@ func (stmt *ExplainStmt) Run(ctx *Context) (Result, error) {
 		return Result{}, errors.New("EXPLAIN only works on INSERT, SELECT, UPDATE AND DELETE statements")
 	}
 
+	if stmt.Analyze {
+		return stmt.runAnalyze(ctx, s)
+	}
+
 	var plan string
 	if s.Stream != nil {
 		plan = s.Stream.String()
@@ -50,8 +62,68 @@ func (stmt *ExplainStmt) Run(ctx *Context) (Result, error) {
 	return newStatement.Run(ctx)
 }
 
+// runAnalyze executes the statement and returns, for each operator of its stream,
+// the number of documents it produced, the time spent producing them
+// and the number of bytes it read.
+func (stmt *ExplainStmt) runAnalyze(ctx *Context, s *PreparedStreamStmt) (Result, error) {
+	if s.Stream == nil {
+		return Result{}, errors.New("EXPLAIN ANALYZE requires a statement that reads or writes documents")
+	}
+
+	profiles := stream.Profile(s.Stream)
+
+	err := ctx.Tx.Savepoint(explainAnalyzeSavepoint)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res, err := s.Run(ctx)
+	if err == nil {
+		err = res.Iterate(func(d types.Document) error { return nil })
+	}
+
+	// roll back the changes, even if the statement failed
+	rerr := ctx.Tx.RollbackToSavepoint(explainAnalyzeSavepoint)
+	if rerr == nil {
+		rerr = ctx.Tx.ReleaseSavepoint(explainAnalyzeSavepoint)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	if rerr != nil {
+		return Result{}, rerr
+	}
+
+	// the measures of each profile include the operators located before,
+	// they are subtracted to get the measures of each operator
+	exprs := make([]expr.Expr, 0, len(profiles))
+	var prev stream.ProfileOperator
+	for _, p := range profiles {
+		fb := document.NewFieldBuffer().
+			Add("operator", types.NewTextValue(p.GetPrev().String())).
+			Add("rows", types.NewIntegerValue(p.Rows)).
+			Add("time", types.NewTextValue((p.Duration - prev.Duration).String())).
+			Add("bytes_read", types.NewIntegerValue(p.BytesRead-prev.BytesRead))
+
+		exprs = append(exprs, expr.LiteralValue{Value: types.NewDocumentValue(fb)})
+		prev = *p
+	}
+
+	newStatement := PreparedStreamStmt{
+		Stream:   stream.New(docs.Emit(exprs...)),
+		ReadOnly: true,
+	}
+	return newStatement.Run(ctx)
+}
+
 // IsReadOnly indicates that this statement doesn't write anything into
-// the database.
+// the database, unless it is analyzing a statement that does.
 func (s *ExplainStmt) IsReadOnly() bool {
+	if s.Analyze {
+		if st, ok := s.Statement.(Statement); ok {
+			return st.IsReadOnly()
+		}
+	}
+
 	return true
 }
@@ -3,9 +3,12 @@ package statement_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,7 +27,7 @@ func TestExplainStmt(t *testing.T) {
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 10 AND d > 20", false, `"table.Scan(\"test\") | docs.Filter(c > 10) | docs.Filter(d > 20) | docs.Project(a + 1)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 10 OR d > 20", false, `"table.Scan(\"test\") | docs.Filter(c > 10 OR d > 20) | docs.Project(a + 1)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c IN [1 + 1, 2 + 2]", false, `"table.Scan(\"test\") | docs.Filter(c IN [2, 4]) | docs.Project(a + 1)"`},
-		{"EXPLAIN SELECT a + 1 FROM test WHERE a > 10", false, `"index.Scan(\"idx_a\", [{\"min\": [10], \"exclusive\": true}]) | docs.Project(a + 1)"`},
+		{"EXPLAIN SELECT a + 1 FROM test WHERE a > 10", false, `"index.CoveringScan(\"idx_a\", [{\"min\": [10], \"exclusive\": true}]) | docs.Project(a + 1)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE x = 10 AND y > 5", false, `"index.Scan(\"idx_x_y\", [{\"min\": [10, 5], \"exclusive\": true}]) | docs.Project(a + 1)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE a > 10 AND b > 20 AND c > 30", false, `"index.Scan(\"idx_b\", [{\"min\": [20], \"exclusive\": true}]) | docs.Filter(a > 10) | docs.Filter(c > 30) | docs.Project(a + 1)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 30 ORDER BY d LIMIT 10 OFFSET 20", false, `"table.Scan(\"test\") | docs.Filter(c > 30) | docs.Project(a + 1) | docs.TempTreeSort(d) | docs.Skip(20) | docs.Take(10)"`},
@@ -72,3 +75,92 @@ func TestExplainStmt(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainAnalyzeStmt(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test (k INTEGER PRIMARY KEY, a int);
+		CREATE INDEX idx_a ON test (a);
+		INSERT INTO test (k, a) VALUES (1, 10), (2, 20), (3, 30), (4, 40);
+	`)
+	assert.NoError(t, err)
+
+	type operatorStats struct {
+		Operator  string
+		Rows      int64
+		Time      string
+		BytesRead int64 `genji:"bytes_read"`
+	}
+
+	analyze := func(t *testing.T, q string) []operatorStats {
+		t.Helper()
+
+		res, err := db.Query(q)
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var stats []operatorStats
+		err = res.Iterate(func(d types.Document) error {
+			var s operatorStats
+			err := document.StructScan(d, &s)
+			stats = append(stats, s)
+			return err
+		})
+		assert.NoError(t, err)
+		return stats
+	}
+
+	t.Run("select", func(t *testing.T) {
+		stats := analyze(t, "EXPLAIN ANALYZE SELECT a FROM test WHERE a > 15 AND k % 2 = 0")
+		require.Len(t, stats, 3)
+
+		require.Equal(t, `index.CoveringScan("idx_a", [{"min": [15], "exclusive": true}])`, stats[0].Operator)
+		require.EqualValues(t, 3, stats[0].Rows)
+		require.Greater(t, stats[0].BytesRead, int64(0))
+
+		require.Equal(t, "docs.Filter(k % 2 = 0)", stats[1].Operator)
+		require.EqualValues(t, 2, stats[1].Rows)
+
+		require.Equal(t, "docs.Project(a)", stats[2].Operator)
+		require.EqualValues(t, 2, stats[2].Rows)
+
+		for _, s := range stats {
+			_, err := time.ParseDuration(s.Time)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("changes are rolled back", func(t *testing.T) {
+		stats := analyze(t, "EXPLAIN ANALYZE DELETE FROM test WHERE a >= 20")
+		require.Equal(t, `index.Scan("idx_a", [{"min": [20]}])`, stats[0].Operator)
+		require.EqualValues(t, 3, stats[0].Rows)
+
+		d, err := db.QueryDocument("SELECT COUNT(*) FROM test")
+		assert.NoError(t, err)
+		var count int
+		assert.NoError(t, document.Scan(d, &count))
+		require.Equal(t, 4, count)
+	})
+
+	t.Run("within a transaction", func(t *testing.T) {
+		tx, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		err = tx.Exec("INSERT INTO test (k, a) VALUES (5, 50)")
+		assert.NoError(t, err)
+
+		res, err := tx.Query("EXPLAIN ANALYZE UPDATE test SET a = 0")
+		assert.NoError(t, err)
+		assert.NoError(t, res.Close())
+
+		d, err := tx.QueryDocument("SELECT COUNT(*) FROM test WHERE a > 0")
+		assert.NoError(t, err)
+		var count int
+		assert.NoError(t, document.Scan(d, &count))
+		require.Equal(t, 5, count)
+	})
+}
@@ -0,0 +1,87 @@
+package statement
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
+)
+
+func init() {
+	table.RunForeignKeyAction = runForeignKeyAction
+}
+
+var errStillReferenced = errors.New("document still referenced")
+
+// runForeignKeyAction applies an action to the documents of a table referencing values
+// through the foreign key constraint tc, in the transaction of the given environment.
+// The documents are deleted and updated by DELETE and UPDATE statements, which maintain
+// the indexes of the table and run its triggers and the actions of its own references.
+func runForeignKeyAction(env *environment.Environment, tableName string, tc *database.TableConstraint, action database.ForeignKeyAction, values []types.Value) error {
+	var where expr.Expr
+	for i, p := range tc.Paths {
+		e := expr.Eq(expr.Path(p), expr.LiteralValue{Value: values[i]})
+		if where == nil {
+			where = e
+		} else {
+			where = expr.And(where, e)
+		}
+	}
+
+	var p Preparer
+	switch action {
+	case database.ForeignKeyCascade:
+		stmt := NewDeleteStatement()
+		stmt.TableName = tableName
+		stmt.WhereExpr = where
+		p = stmt
+	case database.ForeignKeySetNull:
+		stmt := NewUpdateStatement()
+		stmt.TableName = tableName
+		stmt.WhereExpr = where
+		for _, path := range tc.Paths {
+			stmt.SetPairs = append(stmt.SetPairs, UpdateSetPair{Path: path, E: expr.LiteralValue{Value: types.NewNullValue()}})
+		}
+		p = stmt
+	default:
+		p = &StreamStmt{
+			Stream: stream.New(table.Scan(tableName)).
+				Pipe(docs.Filter(where)).
+				Pipe(docs.Take(expr.LiteralValue{Value: types.NewIntegerValue(1)})),
+			ReadOnly: true,
+		}
+	}
+
+	ctx := Context{
+		DB:      env.GetDB(),
+		Tx:      env.GetTx(),
+		Catalog: env.GetCatalog(),
+	}
+
+	prepared, err := p.Prepare(&ctx)
+	if err != nil {
+		return err
+	}
+
+	var actionEnv environment.Environment
+	actionEnv.DB = ctx.DB
+	actionEnv.Tx = ctx.Tx
+	actionEnv.Catalog = ctx.Catalog
+
+	err = prepared.(*PreparedStreamStmt).Stream.Iterate(&actionEnv, func(out *environment.Environment) error {
+		// only RESTRICT returns documents
+		return errStillReferenced
+	})
+	if errors.Is(err, stream.ErrStreamClosed) {
+		err = nil
+	}
+	if errors.Is(err, errStillReferenced) {
+		return errors.Errorf("document is still referenced by foreign key %q of table %q", tc.Name, tableName)
+	}
+
+	return err
+}
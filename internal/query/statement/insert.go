@@ -1,6 +1,9 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
@@ -9,6 +12,7 @@ import (
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // InsertStmt holds INSERT configuration.
@@ -128,3 +132,58 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+func (stmt *InsertStmt) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "INSERT INTO %s", stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if len(stmt.Fields) > 0 {
+		sb.WriteString(" (")
+		for i, f := range stmt.Fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(stringutil.NormalizeIdentifier(f, '`'))
+		}
+		sb.WriteString(")")
+	}
+
+	if stmt.Values != nil {
+		sb.WriteString(" VALUES ")
+		for i, v := range stmt.Values {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+
+			// values listed without field names are written as a list
+			kvs, ok := v.(*expr.KVPairs)
+			if ok && len(kvs.Pairs) > 0 && (len(stmt.Fields) > 0 || kvs.Pairs[0].K == "") {
+				sb.WriteString("(")
+				for j, kv := range kvs.Pairs {
+					if j > 0 {
+						sb.WriteString(", ")
+					}
+					sb.WriteString(kv.V.String())
+				}
+				sb.WriteString(")")
+				continue
+			}
+
+			sb.WriteString(v.String())
+		}
+	} else {
+		fmt.Fprintf(&sb, " %s", stmt.SelectStmt)
+	}
+
+	if stmt.OnConflict != 0 {
+		fmt.Fprintf(&sb, " ON CONFLICT %s", stmt.OnConflict)
+	}
+
+	if len(stmt.Returning) > 0 {
+		sb.WriteString(" RETURNING ")
+		writeProjection(&sb, stmt.Returning)
+	}
+
+	return sb.String()
+}
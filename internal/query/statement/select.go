@@ -2,39 +2,146 @@ package statement
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/join"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 type SelectCoreStmt struct {
-	TableName       string
+	TableName string
+	// Subquery is the SELECT statement used in the FROM clause instead of a table.
+	Subquery        *SelectStmt
+	TableAlias      string
+	Joins           []*JoinClause
 	Distinct        bool
 	WhereExpr       expr.Expr
-	GroupByExpr     expr.Expr
+	GroupByExprs    []expr.Expr
+	HavingExpr      expr.Expr
 	ProjectionExprs []expr.Expr
 }
 
-func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
+func (stmt *SelectCoreStmt) Prepare(ctx *Context) (*StreamStmt, error) {
 	isReadOnly := true
 
 	var s *stream.Stream
 
-	if stmt.TableName != "" {
-		s = s.Pipe(table.Scan(stmt.TableName))
+	hasFrom := stmt.TableName != "" || stmt.Subquery != nil
+
+	if stmt.Subquery != nil {
+		sub, err := prepareSubquery(ctx, stmt.Subquery)
+		if err != nil {
+			return nil, err
+		}
+		isReadOnly = isReadOnly && sub.ReadOnly
+		s = s.Pipe(stream.Subquery(sub.Stream, stmt.TableAlias))
+	} else if stmt.TableName != "" {
+		view, err := prepareView(ctx, stmt.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		if view != nil {
+			isReadOnly = isReadOnly && view.ReadOnly
+			s = s.Pipe(stream.Subquery(view.Stream, tableRefName(stmt.TableName, stmt.TableAlias)))
+		} else {
+			scan := table.Scan(stmt.TableName)
+			scan.Alias = stmt.TableAlias
+			s = s.Pipe(scan)
+		}
+	}
+
+	if len(stmt.Joins) > 0 {
+		names := map[string]struct{}{
+			tableRefName(stmt.TableName, stmt.TableAlias): {},
+		}
+
+		for _, j := range stmt.Joins {
+			name := tableRefName(j.TableName, j.TableAlias)
+			if _, ok := names[name]; ok {
+				return nil, fmt.Errorf("table name %q specified more than once", name)
+			}
+			names[name] = struct{}{}
+
+			if j.Unnest != nil {
+				if j.Left {
+					s = s.Pipe(join.LeftUnnest(j.Unnest, name, j.On))
+				} else {
+					s = s.Pipe(join.Unnest(j.Unnest, name, j.On))
+				}
+				continue
+			}
+
+			var right *stream.Stream
+			if j.Subquery != nil {
+				sub, err := prepareSubquery(ctx, j.Subquery)
+				if err != nil {
+					return nil, err
+				}
+				isReadOnly = isReadOnly && sub.ReadOnly
+				right = stream.New(stream.Subquery(sub.Stream, j.TableAlias))
+			} else {
+				view, err := prepareView(ctx, j.TableName)
+				if err != nil {
+					return nil, err
+				}
+
+				if view != nil {
+					isReadOnly = isReadOnly && view.ReadOnly
+					right = stream.New(stream.Subquery(view.Stream, name))
+				} else {
+					scan := table.Scan(j.TableName)
+					scan.Alias = j.TableAlias
+					right = stream.New(scan)
+				}
+			}
+
+			if j.Left {
+				s = s.Pipe(join.LeftNestedLoop(right, name, j.On))
+			} else {
+				s = s.Pipe(join.NestedLoop(right, name, j.On))
+			}
+		}
 	}
 
+	exprs := append([]expr.Expr{stmt.WhereExpr, stmt.HavingExpr}, stmt.GroupByExprs...)
+	exprs = append(exprs, stmt.ProjectionExprs...)
+	for _, j := range stmt.Joins {
+		exprs = append(exprs, j.Unnest, j.On)
+	}
+	readOnly, err := prepareSubqueries(ctx, exprs...)
+	if err != nil {
+		return nil, err
+	}
+	isReadOnly = isReadOnly && readOnly
+
 	if stmt.WhereExpr != nil {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
-	// when using GROUP BY, only aggregation functions or GroupByExpr can be selected
-	if stmt.GroupByExpr != nil {
+	windows := groupWindows(stmt.ProjectionExprs)
+	if len(windows) > 0 && !hasFrom {
+		return nil, errors.New("no tables specified")
+	}
+
+	// when using GROUP BY or HAVING, only aggregation functions or GROUP BY expressions can be selected
+	if len(stmt.GroupByExprs) > 0 || stmt.HavingExpr != nil {
+		if !hasFrom {
+			return nil, errors.New("no tables specified")
+		}
+
+		if len(windows) > 0 {
+			return nil, errWindowWithAggregation
+		}
+
 		var invalidProjectedField expr.Expr
 		var aggregators []expr.AggregatorBuilder
 
@@ -52,12 +159,12 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 				continue
 			}
 
-			// check if this is the same expression as the one used in the GROUP BY clause
-			if expr.Equal(e, stmt.GroupByExpr) {
+			// check if this is the same expression as one of those used in the GROUP BY clause
+			if p := stmt.groupByPath(e); p != nil {
 				// if so, replace the expression with a path expression
 				stmt.ProjectionExprs[i] = &expr.NamedExpr{
 					ExprName: ne.ExprName,
-					Expr:     expr.Path(document.NewPath(e.String())),
+					Expr:     p,
 				}
 				continue
 			}
@@ -70,10 +177,30 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 		if invalidProjectedField != nil {
 			return nil, fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", invalidProjectedField)
 		}
+
+		// the HAVING clause is evaluated on the aggregated documents
+		var having expr.Expr
+		if stmt.HavingExpr != nil {
+			having, aggregators, err = stmt.prepareHaving(aggregators)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// add Aggregation node
-		s = s.Pipe(docs.TempTreeSort(stmt.GroupByExpr))
-		s = s.Pipe(docs.GroupAggregate(stmt.GroupByExpr, aggregators...))
-	} else if stmt.TableName != "" {
+		switch len(stmt.GroupByExprs) {
+		case 0:
+		case 1:
+			s = s.Pipe(docs.TempTreeSort(stmt.GroupByExprs[0]))
+		default:
+			s = s.Pipe(docs.TempTreeSort(expr.LiteralExprList(stmt.GroupByExprs)))
+		}
+		s = s.Pipe(docs.GroupAggregate(stmt.GroupByExprs, aggregators...))
+
+		if having != nil {
+			s = s.Pipe(docs.Filter(having))
+		}
+	} else if hasFrom {
 		// if there is no GROUP BY clause, check if there are any aggregation function
 		// and if so add an aggregation node
 		var aggregators []expr.AggregatorBuilder
@@ -93,14 +220,31 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 
 		// add Aggregation node
 		if len(aggregators) > 0 {
+			if len(windows) > 0 {
+				return nil, errWindowWithAggregation
+			}
+
 			s = s.Pipe(docs.GroupAggregate(nil, aggregators...))
 		}
 	}
 
-	// If there is no FROM clause ensure there is no wildcard or path
-	if stmt.TableName == "" {
-		var err error
+	// window functions are computed for every document, after filtering.
+	// each window requires the stream to be sorted by its PARTITION BY and ORDER BY clauses.
+	for _, ws := range windows {
+		keys := make([]expr.SortKey, 0, len(ws[0].PartitionBy)+len(ws[0].OrderBy))
+		for _, e := range ws[0].PartitionBy {
+			keys = append(keys, expr.SortKey{Expr: e})
+		}
+		keys = append(keys, ws[0].OrderBy...)
 
+		if len(keys) > 0 {
+			s = s.Pipe(docs.TempTreeSortBy(keys...))
+		}
+		s = s.Pipe(docs.Window(ws...))
+	}
+
+	// If there is no FROM clause ensure there is no wildcard or path
+	if !hasFrom {
 		for _, e := range stmt.ProjectionExprs {
 			expr.Walk(e, func(e expr.Expr) bool {
 				switch e.(type) {
@@ -142,14 +286,319 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 	}, nil
 }
 
+var errWindowWithAggregation = errors.New("window functions cannot be used with GROUP BY, HAVING or aggregate functions")
+
+// groupWindows returns the window functions used by the given expressions,
+// grouped by window: functions sharing the same PARTITION BY and ORDER BY clauses
+// are computed by the same window operator.
+func groupWindows(exprs []expr.Expr) [][]*expr.Window {
+	var groups [][]*expr.Window
+
+	for _, e := range exprs {
+		expr.Walk(e, func(e expr.Expr) bool {
+			w, ok := e.(*expr.Window)
+			if !ok {
+				return true
+			}
+
+			for i, ws := range groups {
+				if !ws[0].HasSameWindow(w) {
+					continue
+				}
+
+				for _, other := range ws {
+					if other.IsEqual(w) {
+						return true
+					}
+				}
+
+				groups[i] = append(groups[i], w)
+				return true
+			}
+
+			groups = append(groups, []*expr.Window{w})
+			return true
+		})
+	}
+
+	return groups
+}
+
+// groupByPath returns the path to the field of the aggregated documents
+// that contains the value of e, if e is one of the GROUP BY expressions.
+func (stmt *SelectCoreStmt) groupByPath(e expr.Expr) expr.Path {
+	for _, g := range stmt.GroupByExprs {
+		if expr.Equal(e, g) {
+			return expr.Path(document.NewPath(g.String()))
+		}
+	}
+
+	return nil
+}
+
+// prepareHaving returns the HAVING expression that can be evaluated on
+// the aggregated documents, along with the given aggregators and those used by the HAVING clause.
+func (stmt *SelectCoreStmt) prepareHaving(aggregators []expr.AggregatorBuilder) (expr.Expr, []expr.AggregatorBuilder, error) {
+	having := stmt.replaceGroupByExprs(stmt.HavingExpr)
+
+	var err error
+	expr.Walk(having, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.AggregatorBuilder:
+			for _, agg := range aggregators {
+				if expr.Equal(t, agg) {
+					return false
+				}
+			}
+			aggregators = append(aggregators, t)
+			return false
+		case expr.Path:
+			// paths must reference the GROUP BY expressions
+			for _, g := range stmt.GroupByExprs {
+				if t.IsEqual(stmt.groupByPath(g)) {
+					return true
+				}
+			}
+
+			err = fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", t)
+			return false
+		case expr.Wildcard:
+			err = errors.New("wildcard not allowed in HAVING clause")
+			return false
+		}
+
+		return true
+	})
+
+	return having, aggregators, err
+}
+
+// replaceGroupByExprs replaces the parts of e that are equal to one of the GROUP BY expressions
+// with a path to the corresponding field of the aggregated documents.
+func (stmt *SelectCoreStmt) replaceGroupByExprs(e expr.Expr) expr.Expr {
+	if p := stmt.groupByPath(e); p != nil {
+		return p
+	}
+
+	switch t := e.(type) {
+	case expr.Operator:
+		if b, ok := t.(*expr.BetweenOperator); ok {
+			b.X = stmt.replaceGroupByExprs(b.X)
+		}
+		t.SetLeftHandExpr(stmt.replaceGroupByExprs(t.LeftHand()))
+		t.SetRightHandExpr(stmt.replaceGroupByExprs(t.RightHand()))
+	case expr.Parentheses:
+		return expr.Parentheses{E: stmt.replaceGroupByExprs(t.E)}
+	}
+
+	return e
+}
+
+func (stmt *SelectCoreStmt) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("SELECT ")
+	if stmt.Distinct {
+		sb.WriteString("DISTINCT ")
+	}
+
+	writeProjection(&sb, stmt.ProjectionExprs)
+
+	if stmt.TableName != "" || stmt.Subquery != nil {
+		sb.WriteString(" FROM ")
+		writeTableRef(&sb, stmt.TableName, stmt.Subquery, stmt.TableAlias)
+	}
+
+	for _, j := range stmt.Joins {
+		sb.WriteRune(' ')
+		sb.WriteString(j.String())
+	}
+
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&sb, " WHERE %s", stmt.WhereExpr)
+	}
+
+	for i, e := range stmt.GroupByExprs {
+		if i == 0 {
+			sb.WriteString(" GROUP BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(e.String())
+	}
+
+	if stmt.HavingExpr != nil {
+		fmt.Fprintf(&sb, " HAVING %s", stmt.HavingExpr)
+	}
+
+	return sb.String()
+}
+
+// writeProjection writes a list of projected expressions, along with their aliases.
+func writeProjection(sb *strings.Builder, exprs []expr.Expr) {
+	for i, e := range exprs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(e.String())
+		if ne, ok := e.(*expr.NamedExpr); ok && ne.ExprName != ne.Expr.String() {
+			fmt.Fprintf(sb, " AS %s", stringutil.NormalizeIdentifier(ne.ExprName, '`'))
+		}
+	}
+}
+
+func writeTableRef(sb *strings.Builder, tableName string, subquery *SelectStmt, alias string) {
+	if subquery != nil {
+		fmt.Fprintf(sb, "(%s)", subquery)
+	} else {
+		sb.WriteString(stringutil.NormalizeIdentifier(tableName, '`'))
+	}
+
+	if alias != "" {
+		fmt.Fprintf(sb, " AS %s", stringutil.NormalizeIdentifier(alias, '`'))
+	}
+}
+
+// A JoinClause joins the documents of a table, or of a subquery,
+// with the documents selected by the FROM clause.
+// With UNNEST, each document is joined with the values of an array
+// computed from that document instead.
+type JoinClause struct {
+	TableName string
+	Subquery  *SelectStmt
+	// Unnest is the array expression of UNNEST(expr).
+	Unnest     expr.Expr
+	TableAlias string
+	// Left indicates a LEFT JOIN.
+	Left bool
+	On   expr.Expr
+}
+
+func (j *JoinClause) String() string {
+	var sb strings.Builder
+
+	if j.Left {
+		sb.WriteString("LEFT ")
+	}
+	sb.WriteString("JOIN ")
+	if j.Unnest != nil {
+		fmt.Fprintf(&sb, "UNNEST(%s) AS %s", j.Unnest, stringutil.NormalizeIdentifier(j.TableAlias, '`'))
+	} else {
+		writeTableRef(&sb, j.TableName, j.Subquery, j.TableAlias)
+	}
+
+	if j.On != nil {
+		fmt.Fprintf(&sb, " ON %s", j.On)
+	}
+
+	return sb.String()
+}
+
+// tableRefName returns the name under which the documents of a table
+// can be referenced.
+func tableRefName(tableName, alias string) string {
+	if alias != "" {
+		return alias
+	}
+
+	return tableName
+}
+
+// prepareSubquery prepares a SELECT statement used as a subquery.
+func prepareSubquery(ctx *Context, stmt *SelectStmt) (*PreparedStreamStmt, error) {
+	st, err := stmt.Prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.(*PreparedStreamStmt), nil
+}
+
+// parseViewQuery parses the query of a view.
+func parseViewQuery(q string) (*SelectStmt, error) {
+	stmt, err := ParseStatement(q)
+	if err != nil {
+		return nil, err
+	}
+
+	sel, ok := stmt.(*SelectStmt)
+	if !ok {
+		return nil, errors.Errorf("expected SELECT statement, got %s", q)
+	}
+
+	return sel, nil
+}
+
+// prepareView prepares the query of the view with the given name.
+// It returns nil if there is no view with that name.
+// Views are parsed every time they are used because
+// preparing a statement modifies it.
+func prepareView(ctx *Context, name string) (*PreparedStreamStmt, error) {
+	if ctx.Catalog == nil {
+		return nil, nil
+	}
+
+	info, err := ctx.Catalog.GetViewInfo(name)
+	if err != nil {
+		if errs.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	stmt, err := parseViewQuery(info.Query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid view %q", name)
+	}
+
+	return prepareSubquery(ctx, stmt)
+}
+
+// prepareSubqueries prepares the subqueries used by the given expressions
+// and reports whether they are all read-only.
+func prepareSubqueries(ctx *Context, exprs ...expr.Expr) (bool, error) {
+	readOnly := true
+
+	var err error
+	for _, e := range exprs {
+		expr.Walk(e, func(e expr.Expr) bool {
+			sq, ok := e.(*expr.Subquery)
+			if !ok {
+				return true
+			}
+
+			stmt, ok := sq.Stmt.(*SelectStmt)
+			if !ok {
+				err = errors.Errorf("unsupported subquery %s", sq)
+				return false
+			}
+
+			var sub *PreparedStreamStmt
+			sub, err = prepareSubquery(ctx, stmt)
+			if err != nil {
+				return false
+			}
+
+			sq.Stream = sub.Stream
+			readOnly = readOnly && sub.ReadOnly
+			return true
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return readOnly, nil
+}
+
 // SelectStmt holds SELECT configuration.
 type SelectStmt struct {
 	basePreparedStatement
 
 	CompoundSelect    []*SelectCoreStmt
 	CompoundOperators []scanner.Token
-	OrderBy           expr.Path
-	OrderByDirection  scanner.Token
+	OrderBy           []expr.SortKey
 	OffsetExpr        expr.Expr
 	LimitExpr         expr.Expr
 }
@@ -211,12 +660,14 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 		prev = tok
 	}
 
-	if stmt.OrderBy != nil {
-		if stmt.OrderByDirection == scanner.DESC {
-			s = s.Pipe(docs.TempTreeSortReverse(stmt.OrderBy))
-		} else {
-			s = s.Pipe(docs.TempTreeSort(stmt.OrderBy))
-		}
+	subReadOnly, err := prepareSubqueries(ctx, stmt.OffsetExpr, stmt.LimitExpr)
+	if err != nil {
+		return nil, err
+	}
+	readOnly = readOnly && subReadOnly
+
+	if len(stmt.OrderBy) > 0 {
+		s = s.Pipe(docs.TempTreeSortBy(stmt.OrderBy...))
 	}
 
 	if stmt.OffsetExpr != nil {
@@ -234,3 +685,102 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 
 	return st.Prepare(ctx)
 }
+
+func (stmt *SelectStmt) String() string {
+	var sb strings.Builder
+
+	for i, core := range stmt.CompoundSelect {
+		if i > 0 {
+			switch stmt.CompoundOperators[i-1] {
+			case scanner.UNION:
+				sb.WriteString(" UNION ")
+			case scanner.ALL:
+				sb.WriteString(" UNION ALL ")
+			}
+		}
+
+		sb.WriteString(core.String())
+	}
+
+	for i, k := range stmt.OrderBy {
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(k.String())
+	}
+
+	if stmt.LimitExpr != nil {
+		fmt.Fprintf(&sb, " LIMIT %s", stmt.LimitExpr)
+	}
+
+	if stmt.OffsetExpr != nil {
+		fmt.Fprintf(&sb, " OFFSET %s", stmt.OffsetExpr)
+	}
+
+	return sb.String()
+}
+
+// tableNames returns the names of the tables and views read by the statement,
+// including the ones read by its subqueries.
+func (stmt *SelectStmt) tableNames() []string {
+	var names []string
+
+	for _, core := range stmt.CompoundSelect {
+		names = append(names, core.tableNames()...)
+	}
+
+	exprs := []expr.Expr{stmt.LimitExpr, stmt.OffsetExpr}
+	for _, k := range stmt.OrderBy {
+		exprs = append(exprs, k.Expr)
+	}
+
+	return append(names, subqueryTableNames(exprs...)...)
+}
+
+func (stmt *SelectCoreStmt) tableNames() []string {
+	var names []string
+
+	if stmt.TableName != "" {
+		names = append(names, stmt.TableName)
+	}
+	if stmt.Subquery != nil {
+		names = append(names, stmt.Subquery.tableNames()...)
+	}
+
+	exprs := []expr.Expr{stmt.WhereExpr, stmt.HavingExpr}
+	exprs = append(exprs, stmt.GroupByExprs...)
+	exprs = append(exprs, stmt.ProjectionExprs...)
+
+	for _, j := range stmt.Joins {
+		if j.TableName != "" {
+			names = append(names, j.TableName)
+		}
+		if j.Subquery != nil {
+			names = append(names, j.Subquery.tableNames()...)
+		}
+		exprs = append(exprs, j.Unnest, j.On)
+	}
+
+	return append(names, subqueryTableNames(exprs...)...)
+}
+
+// subqueryTableNames returns the names of the tables and views read
+// by the subqueries of the given expressions.
+func subqueryTableNames(exprs ...expr.Expr) []string {
+	var names []string
+
+	for _, e := range exprs {
+		expr.Walk(e, func(e expr.Expr) bool {
+			if sq, ok := e.(*expr.Subquery); ok {
+				if stmt, ok := sq.Stmt.(*SelectStmt); ok {
+					names = append(names, stmt.tableNames()...)
+				}
+			}
+			return true
+		})
+	}
+
+	return names
+}
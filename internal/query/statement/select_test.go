@@ -59,6 +59,9 @@ func TestSelectStmt(t *testing.T) {
 		{"With group by expr", "SELECT weight / 2 as half FROM test GROUP BY weight / 2", false, `[{"half":null},{"half":50},{"half":100}]`, nil},
 		{"With group by and count", "SELECT COUNT(k) FROM test GROUP BY size", false, `[{"COUNT(k)":1},{"COUNT(k)":2}]`, nil},
 		{"With group by and count wildcard", "SELECT COUNT(*  ) FROM test GROUP BY size", false, `[{"COUNT(*)":1},{"COUNT(*)":2}]`, nil},
+		{"With multiple group by", "SELECT size, color, COUNT(*) FROM test GROUP BY size, color", false, `[{"size":null,"color":null,"COUNT(*)":1},{"size":10,"color":"blue","COUNT(*)":1},{"size":10,"color":"red","COUNT(*)":1}]`, nil},
+		{"With group by and having", "SELECT size, COUNT(*) FROM test GROUP BY size HAVING COUNT(*) > 1", false, `[{"size":10,"COUNT(*)":2}]`, nil},
+		{"With invalid having", "SELECT size FROM test GROUP BY size HAVING color = 'red'", true, ``, nil},
 		{"With order by", "SELECT * FROM test ORDER BY color", false, `[{"k":3,"height":100,"weight":200},{"k":2,"color":"blue","size":10,"weight":100},{"k":1,"color":"red","size":10,"shape":"square"}]`, nil},
 		{"With invalid group by / wildcard", "SELECT * FROM test WHERE age = 10 GROUP BY a.b.c", true, ``, nil},
 		{"With invalid group by / a.b", "SELECT a.b FROM test WHERE age = 10 GROUP BY a.b.c", true, ``, nil},
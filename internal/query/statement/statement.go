@@ -14,6 +14,12 @@ type Statement interface {
 	IsReadOnly() bool
 }
 
+// ParseStatement parses a single statement.
+// It is set by the parser package, which depends on this package,
+// and is used to run the statements stored in the catalog,
+// such as the queries of views or the statements of triggers.
+var ParseStatement func(q string) (Statement, error)
+
 type basePreparedStatement struct {
 	Preparer Preparer
 	ReadOnly bool
@@ -60,6 +66,27 @@ func (r *Result) Iterate(fn func(d types.Document) error) error {
 	return r.err
 }
 
+// RowsAffected returns the number of documents inserted, updated or deleted
+// by the statement. It is known once the result has been iterated.
+func (r *Result) RowsAffected() int64 {
+	if it, ok := r.Iterator.(*StreamStmtIterator); ok {
+		return it.Changes.RowsAffected
+	}
+
+	return 0
+}
+
+// LastInsertID returns the key of the last document inserted by the statement,
+// if it is an integer, i.e. a docid or an integer primary key.
+// It is known once the result has been iterated.
+func (r *Result) LastInsertID() int64 {
+	if it, ok := r.Iterator.(*StreamStmtIterator); ok {
+		return it.Changes.LastInsertID
+	}
+
+	return 0
+}
+
 // Close the result stream.
 // After closing the result, Stream is not supposed to be used.
 // If the result stream was already closed, it returns an error.
@@ -57,6 +57,9 @@ func (s *PreparedStreamStmt) String() string {
 type StreamStmtIterator struct {
 	Stream  *stream.Stream
 	Context *Context
+
+	// Changes made by the stream, once iterated.
+	Changes environment.Changes
 }
 
 func (s *StreamStmtIterator) Iterate(fn func(d types.Document) error) error {
@@ -64,6 +67,7 @@ func (s *StreamStmtIterator) Iterate(fn func(d types.Document) error) error {
 	env.DB = s.Context.DB
 	env.Tx = s.Context.Tx
 	env.Catalog = s.Context.Catalog
+	env.Changes = &s.Changes
 	env.SetParams(s.Context.Params)
 
 	err := s.Stream.Iterate(&env, func(env *environment.Environment) error {
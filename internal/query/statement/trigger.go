@@ -0,0 +1,84 @@
+package statement
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
+)
+
+func init() {
+	table.RunTrigger = runTrigger
+}
+
+// runTrigger runs the statement of a trigger in the transaction of the given environment.
+// The OLD and NEW documents are available to the statement as variables.
+// The statement is parsed and prepared every time the trigger runs, because
+// preparing a statement modifies it.
+func runTrigger(env *environment.Environment, info *database.TriggerInfo, old, new types.Document) error {
+	// a trigger cannot fire itself, directly or through other triggers
+	running := document.NewValueBuffer()
+	if v, ok := env.Get(environment.TriggersKey); ok {
+		err := running.Copy(types.As[types.Array](v))
+		if err != nil {
+			return err
+		}
+	}
+	err := running.Iterate(func(i int, v types.Value) error {
+		if types.As[string](v) == info.TriggerName {
+			return errors.New("a trigger cannot fire itself")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	running.Append(types.NewTextValue(info.TriggerName))
+
+	st, err := ParseStatement(info.Statement)
+	if err != nil {
+		return errors.Wrapf(err, "invalid trigger %q", info.TriggerName)
+	}
+
+	ctx := Context{
+		DB:      env.GetDB(),
+		Tx:      env.GetTx(),
+		Catalog: env.GetCatalog(),
+	}
+
+	p, ok := st.(Preparer)
+	if !ok {
+		return errors.Errorf("invalid trigger %q: unsupported statement %s", info.TriggerName, info.Statement)
+	}
+	prepared, err := p.Prepare(&ctx)
+	if err != nil {
+		return errors.Wrapf(err, "trigger %q", info.TriggerName)
+	}
+
+	var triggerEnv environment.Environment
+	triggerEnv.DB = ctx.DB
+	triggerEnv.Tx = ctx.Tx
+	triggerEnv.Catalog = ctx.Catalog
+	triggerEnv.Set(environment.TriggersKey, types.NewArrayValue(running))
+	if old != nil {
+		triggerEnv.Set(document.NewPath("OLD"), types.NewDocumentValue(old))
+	}
+	if new != nil {
+		triggerEnv.Set(document.NewPath("NEW"), types.NewDocumentValue(new))
+	}
+
+	err = prepared.(*PreparedStreamStmt).Stream.Iterate(&triggerEnv, func(out *environment.Environment) error {
+		return nil
+	})
+	if errors.Is(err, stream.ErrStreamClosed) {
+		err = nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "trigger %q", info.TriggerName)
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
@@ -9,6 +12,7 @@ import (
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // UpdateConfig holds UPDATE configuration.
@@ -53,6 +57,14 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 	}
 	pk := ti.GetPrimaryKey()
 
+	exprs := []expr.Expr{stmt.WhereExpr}
+	for _, pair := range stmt.SetPairs {
+		exprs = append(exprs, pair.E)
+	}
+	if _, err := prepareSubqueries(c, exprs...); err != nil {
+		return nil, err
+	}
+
 	s := stream.New(table.Scan(stmt.TableName))
 
 	if stmt.WhereExpr != nil {
@@ -101,8 +113,14 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 	}
 
 	if pkModified {
-		s = s.Pipe(table.Delete(stmt.TableName))
-		s = s.Pipe(table.Insert(stmt.TableName))
+		// the document is inserted again with its new key,
+		// which must not run the ON DELETE actions of foreign keys
+		del := table.Delete(stmt.TableName)
+		del.Update = true
+		s = s.Pipe(del)
+		ins := table.Insert(stmt.TableName)
+		ins.Update = true
+		s = s.Pipe(ins)
 	} else {
 		s = s.Pipe(table.Replace(stmt.TableName))
 	}
@@ -120,3 +138,33 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+func (stmt *UpdateStmt) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "UPDATE %s", stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if len(stmt.SetPairs) > 0 {
+		sb.WriteString(" SET ")
+		for i, pair := range stmt.SetPairs {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%s = %s", pair.Path, pair.E)
+		}
+	} else {
+		sb.WriteString(" UNSET ")
+		for i, f := range stmt.UnsetFields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(stringutil.NormalizeIdentifier(f, '`'))
+		}
+	}
+
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&sb, " WHERE %s", stmt.WhereExpr)
+	}
+
+	return sb.String()
+}
@@ -97,3 +97,83 @@ func (stmt CommitStmt) IsReadOnly() bool {
 func (stmt CommitStmt) Run(ctx *statement.Context) (statement.Result, error) {
 	return statement.Result{}, errors.New("cannot commit with no active transaction")
 }
+
+// SavepointStmt is a statement that creates a savepoint in the current active transaction.
+type SavepointStmt struct {
+	Name string
+}
+
+// Prepare implements the Preparer interface.
+func (stmt SavepointStmt) Prepare(*statement.Context) (statement.Statement, error) {
+	return stmt, nil
+}
+
+func (stmt SavepointStmt) alterQuery(db *database.Database, q *Query) error {
+	if q.tx == nil || q.autoCommit {
+		return errors.New("cannot create a savepoint with no active transaction")
+	}
+
+	return q.tx.Savepoint(stmt.Name)
+}
+
+func (stmt SavepointStmt) IsReadOnly() bool {
+	return false
+}
+
+func (stmt SavepointStmt) Run(ctx *statement.Context) (statement.Result, error) {
+	return statement.Result{}, errors.New("cannot create a savepoint with no active transaction")
+}
+
+// RollbackToSavepointStmt is a statement that undoes the changes made
+// since a savepoint of the current active transaction.
+type RollbackToSavepointStmt struct {
+	Name string
+}
+
+// Prepare implements the Preparer interface.
+func (stmt RollbackToSavepointStmt) Prepare(*statement.Context) (statement.Statement, error) {
+	return stmt, nil
+}
+
+func (stmt RollbackToSavepointStmt) alterQuery(db *database.Database, q *Query) error {
+	if q.tx == nil || q.autoCommit {
+		return errors.New("cannot rollback to a savepoint with no active transaction")
+	}
+
+	return q.tx.RollbackToSavepoint(stmt.Name)
+}
+
+func (stmt RollbackToSavepointStmt) IsReadOnly() bool {
+	return false
+}
+
+func (stmt RollbackToSavepointStmt) Run(ctx *statement.Context) (statement.Result, error) {
+	return statement.Result{}, errors.New("cannot rollback to a savepoint with no active transaction")
+}
+
+// ReleaseSavepointStmt is a statement that removes a savepoint
+// of the current active transaction, keeping its changes.
+type ReleaseSavepointStmt struct {
+	Name string
+}
+
+// Prepare implements the Preparer interface.
+func (stmt ReleaseSavepointStmt) Prepare(*statement.Context) (statement.Statement, error) {
+	return stmt, nil
+}
+
+func (stmt ReleaseSavepointStmt) alterQuery(db *database.Database, q *Query) error {
+	if q.tx == nil || q.autoCommit {
+		return errors.New("cannot release a savepoint with no active transaction")
+	}
+
+	return q.tx.ReleaseSavepoint(stmt.Name)
+}
+
+func (stmt ReleaseSavepointStmt) IsReadOnly() bool {
+	return false
+}
+
+func (stmt ReleaseSavepointStmt) Run(ctx *statement.Context) (statement.Result, error) {
+	return statement.Result{}, errors.New("cannot release a savepoint with no active transaction")
+}
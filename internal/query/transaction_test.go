@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransactionRun(t *testing.T) {
@@ -23,6 +25,11 @@ func TestTransactionRun(t *testing.T) {
 		{"Multiple execs/ Double", []string{`BEGIN`, `COMMIT`, `BEGIN`, `COMMIT`}, false},
 		{"Multiple execs/ Begin then begin", []string{`BEGIN`, `BEGIN`}, true},
 		{"Multiple execs/ Nested", []string{`BEGIN`, `BEGIN`, `COMMIT`, `COMMIT`}, true},
+		{"Same exec/ Savepoint", []string{`BEGIN;SAVEPOINT a;ROLLBACK TO a;RELEASE a;COMMIT`}, false},
+		{"Same exec/ Savepoint without transaction", []string{`SAVEPOINT a`}, true},
+		{"Same exec/ Unknown savepoint", []string{`BEGIN;SAVEPOINT a;ROLLBACK TO b`}, true},
+		{"Multiple execs/ Savepoint", []string{`BEGIN`, `SAVEPOINT a`, `ROLLBACK TO SAVEPOINT a`, `COMMIT`}, false},
+		{"Multiple execs/ Released savepoint", []string{`BEGIN`, `SAVEPOINT a`, `RELEASE SAVEPOINT a`, `ROLLBACK TO a`}, true},
 	}
 
 	for _, test := range tests {
@@ -46,3 +53,62 @@ func TestTransactionRun(t *testing.T) {
 		})
 	}
 }
+
+func TestSavepointRun(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		BEGIN;
+		CREATE TABLE test(a INT PRIMARY KEY);
+		INSERT INTO test (a) VALUES (1);
+		SAVEPOINT a;
+		INSERT INTO test (a) VALUES (2);
+		CREATE TABLE other;
+		SAVEPOINT b;
+		INSERT INTO test (a) VALUES (3);
+		ROLLBACK TO SAVEPOINT a;
+		INSERT INTO test (a) VALUES (4);
+		COMMIT;
+	`)
+	assert.NoError(t, err)
+
+	d, err := db.QueryDocument("SELECT COUNT(*) AS n, SUM(a) AS s FROM test")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"n": 2, "s": 5}`)
+
+	// the table created after the savepoint was removed from the catalog
+	err = db.Exec("SELECT * FROM other")
+	require.Error(t, err)
+	err = db.Exec("CREATE TABLE other")
+	assert.NoError(t, err)
+}
+
+func TestSavepointError(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		BEGIN;
+		CREATE TABLE test(a INT PRIMARY KEY);
+		INSERT INTO test (a) VALUES (1);
+		SAVEPOINT a;
+		INSERT INTO test (a) VALUES (2);
+	`)
+	assert.NoError(t, err)
+
+	// an unknown savepoint doesn't end the transaction
+	err = db.Exec("ROLLBACK TO nosuch")
+	assert.Error(t, err)
+	err = db.Exec("RELEASE nosuch")
+	assert.Error(t, err)
+
+	err = db.Exec("INSERT INTO test (a) VALUES (3); COMMIT")
+	assert.NoError(t, err)
+
+	d, err := db.QueryDocument("SELECT COUNT(*) AS n, SUM(a) AS s FROM test")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"n": 3, "s": 6}`)
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/genjidb/genji/internal/query/statement"
@@ -40,10 +42,6 @@ func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (_ statement
 		return stmt, err
 	}
 
-	if fc.IsEmpty() {
-		return stmt, &ParseError{Message: "cannot add a field with no constraint"}
-	}
-
 	err = stmt.Info.AddFieldConstraint(fc)
 	if err != nil {
 		return stmt, err
@@ -56,8 +54,91 @@ func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (_ statement
 		}
 	}
 
-	if stmt.Info.GetPrimaryKey() != nil {
-		return stmt, &ParseError{Message: "cannot add a PRIMARY KEY constraint"}
+	// checked last, as a PRIMARY KEY makes the field NOT NULL
+	if fc.IsEmpty() {
+		return stmt, &ParseError{Message: "cannot add a field with no constraint"}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseAlterTableAddConstraintStatement(tableName string) (_ statement.AlterTableAddConstraint, err error) {
+	var stmt statement.AlterTableAddConstraint
+	stmt.TableName = tableName
+
+	// Parse constraint definition.
+	stmt.Constraint, err = p.parseTableConstraint(nil)
+	if err != nil {
+		return stmt, err
+	}
+	if stmt.Constraint == nil {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"FIELD", "CONSTRAINT", "UNIQUE", "CHECK", "FOREIGN"}, pos)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseAlterTableDropConstraintStatement(tableName string) (_ statement.AlterTableDropConstraint, err error) {
+	var stmt statement.AlterTableDropConstraint
+	stmt.TableName = tableName
+
+	// Parse constraint name.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.IDENT, scanner.STRING:
+		stmt.ConstraintName = lit
+	default:
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"IDENT", "STRING"}, pos)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseAlterTableDropFieldStatement(tableName string) (_ statement.AlterTableDropField, err error) {
+	var stmt statement.AlterTableDropField
+	stmt.TableName = tableName
+
+	// Parse "FIELD".
+	if err := p.parseTokens(scanner.FIELD); err != nil {
+		return stmt, err
+	}
+
+	// Parse field path.
+	stmt.Path, err = p.parsePath()
+	if err != nil {
+		return stmt, err
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseAlterTableAlterFieldStatement(tableName string) (_ statement.AlterTableAlterFieldType, err error) {
+	var stmt statement.AlterTableAlterFieldType
+	stmt.TableName = tableName
+
+	// Parse "FIELD".
+	if err := p.parseTokens(scanner.FIELD); err != nil {
+		return stmt, err
+	}
+
+	// Parse field path.
+	stmt.Path, err = p.parsePath()
+	if err != nil {
+		return stmt, err
+	}
+
+	// Parse "TYPE".
+	// TYPE is not a keyword, to allow it to be used as a field name.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.IDENT || !strings.EqualFold(lit, "TYPE") {
+		return stmt, newParseError(scanner.Tokstr(tok, lit), []string{"TYPE"}, pos)
+	}
+
+	// Parse new type.
+	stmt.Type, err = p.parseType()
+	if err != nil {
+		return stmt, err
 	}
 
 	return stmt, nil
@@ -85,8 +166,21 @@ func (p *Parser) parseAlterStatement() (statement.Statement, error) {
 	case scanner.RENAME:
 		return p.parseAlterTableRenameStatement(tableName)
 	case scanner.ADD_KEYWORD:
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.FIELD {
+			p.Unscan()
+			return p.parseAlterTableAddConstraintStatement(tableName)
+		}
+		p.Unscan()
 		return p.parseAlterTableAddFieldStatement(tableName)
+	case scanner.DROP:
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.CONSTRAINT {
+			return p.parseAlterTableDropConstraintStatement(tableName)
+		}
+		p.Unscan()
+		return p.parseAlterTableDropFieldStatement(tableName)
+	case scanner.ALTER:
+		return p.parseAlterTableAlterFieldStatement(tableName)
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "RENAME"}, pos)
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "ALTER", "DROP", "RENAME"}, pos)
 }
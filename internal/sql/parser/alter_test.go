@@ -3,10 +3,12 @@ package parser_test
 import (
 	"testing"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
@@ -69,7 +71,24 @@ func TestParserAlterTableAddField(t *testing.T) {
 				),
 			},
 		}, false},
-		{"With primary key", "ALTER TABLE foo ADD FIELD bar PRIMARY KEY", nil, true},
+		{"With primary key", "ALTER TABLE foo ADD FIELD bar PRIMARY KEY", statement.AlterTableAddField{
+			Info: database.TableInfo{
+				TableName: "foo",
+				FieldConstraints: database.MustNewFieldConstraints(
+					&database.FieldConstraint{
+						Field:     "bar",
+						IsNotNull: true,
+					},
+				),
+				TableConstraints: []*database.TableConstraint{
+					{
+						Name:       "foo_pk",
+						Paths:      document.Paths{document.NewPath("bar")},
+						PrimaryKey: true,
+					},
+				},
+			},
+		}, false},
 		{"With multiple constraints", "ALTER TABLE foo ADD FIELD bar integer NOT NULL DEFAULT 0", statement.AlterTableAddField{
 			Info: database.TableInfo{
 				TableName: "foo",
@@ -100,3 +119,182 @@ func TestParserAlterTableAddField(t *testing.T) {
 		})
 	}
 }
+
+func TestParserAlterTableDropField(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo DROP FIELD bar", statement.AlterTableDropField{
+			TableName: "foo",
+			Path:      document.Path(testutil.ParseDocumentPath(t, "bar")),
+		}, false},
+		{"Nested", "ALTER TABLE foo DROP FIELD bar.baz", statement.AlterTableDropField{
+			TableName: "foo",
+			Path:      document.Path(testutil.ParseDocumentPath(t, "bar.baz")),
+		}, false},
+		{"With error / missing FIELD keyword", "ALTER TABLE foo DROP bar", nil, true},
+		{"With error / missing field name", "ALTER TABLE foo DROP FIELD", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableAlterField(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo ALTER FIELD bar TYPE DOUBLE", statement.AlterTableAlterFieldType{
+			TableName: "foo",
+			Path:      document.Path(testutil.ParseDocumentPath(t, "bar")),
+			Type:      types.DoubleValue,
+		}, false},
+		{"Nested", "ALTER TABLE foo ALTER FIELD bar.baz type text", statement.AlterTableAlterFieldType{
+			TableName: "foo",
+			Path:      document.Path(testutil.ParseDocumentPath(t, "bar.baz")),
+			Type:      types.TextValue,
+		}, false},
+		{"Field named type", "ALTER TABLE foo ALTER FIELD type TYPE INT", statement.AlterTableAlterFieldType{
+			TableName: "foo",
+			Path:      document.Path(testutil.ParseDocumentPath(t, "type")),
+			Type:      types.IntegerValue,
+		}, false},
+		{"With error / missing TYPE keyword", "ALTER TABLE foo ALTER FIELD bar DOUBLE", nil, true},
+		{"With error / missing type", "ALTER TABLE foo ALTER FIELD bar TYPE", nil, true},
+		{"With error / unknown type", "ALTER TABLE foo ALTER FIELD bar TYPE baz", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableAddConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Check", "ALTER TABLE foo ADD CONSTRAINT bar CHECK (a > 0)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Name:  "bar",
+				Check: expr.Constraint(testutil.ParseExpr(t, "a > 0")),
+				Paths: []document.Path{document.Path(testutil.ParseDocumentPath(t, "a"))},
+			},
+		}, false},
+		{"Unique", "ALTER TABLE foo ADD UNIQUE (a, b)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Unique: true,
+				Paths: []document.Path{
+					document.Path(testutil.ParseDocumentPath(t, "a")),
+					document.Path(testutil.ParseDocumentPath(t, "b")),
+				},
+			},
+		}, false},
+		{"Foreign key", "ALTER TABLE foo ADD CONSTRAINT bar FOREIGN KEY (a) REFERENCES baz", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				Name:       "bar",
+				ForeignKey: &database.ForeignKey{Table: "baz"},
+				Paths:      []document.Path{document.Path(testutil.ParseDocumentPath(t, "a"))},
+			},
+		}, false},
+		{"Foreign key / paths and action", "ALTER TABLE foo ADD FOREIGN KEY (a, b) REFERENCES baz (c, d) ON DELETE SET NULL", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				ForeignKey: &database.ForeignKey{
+					Table: "baz",
+					Paths: []document.Path{
+						document.Path(testutil.ParseDocumentPath(t, "c")),
+						document.Path(testutil.ParseDocumentPath(t, "d")),
+					},
+					OnDelete: database.ForeignKeySetNull,
+				},
+				Paths: []document.Path{
+					document.Path(testutil.ParseDocumentPath(t, "a")),
+					document.Path(testutil.ParseDocumentPath(t, "b")),
+				},
+			},
+		}, false},
+		{"With error / foreign key without table", "ALTER TABLE foo ADD FOREIGN KEY (a) REFERENCES", nil, true},
+		{"With error / foreign key with unknown action", "ALTER TABLE foo ADD FOREIGN KEY (a) REFERENCES baz ON DELETE NOTHING", nil, true},
+		{"With primary key", "ALTER TABLE foo ADD PRIMARY KEY (a)", statement.AlterTableAddConstraint{
+			TableName: "foo",
+			Constraint: &database.TableConstraint{
+				PrimaryKey: true,
+				Paths: []document.Path{
+					document.Path(testutil.ParseDocumentPath(t, "a")),
+				},
+			},
+		}, false},
+		{"With error / missing constraint", "ALTER TABLE foo ADD CONSTRAINT bar", nil, true},
+		{"With error / unknown constraint", "ALTER TABLE foo ADD NOT NULL", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableDropConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo DROP CONSTRAINT bar", statement.AlterTableDropConstraint{TableName: "foo", ConstraintName: "bar"}, false},
+		{"String", "ALTER TABLE foo DROP CONSTRAINT 'bar baz'", statement.AlterTableDropConstraint{TableName: "foo", ConstraintName: "bar baz"}, false},
+		{"With error / missing name", "ALTER TABLE foo DROP CONSTRAINT", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
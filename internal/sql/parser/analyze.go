@@ -0,0 +1,24 @@
+package parser
+
+import (
+	"github.com/genjidb/genji/internal/query/statement"
+	"github.com/genjidb/genji/internal/sql/scanner"
+)
+
+// parseAnalyzeStatement parses an analyze statement.
+func (p *Parser) parseAnalyzeStatement() (statement.Statement, error) {
+	var stmt statement.AnalyzeStmt
+
+	// Parse "ANALYZE".
+	if err := p.parseTokens(scanner.ANALYZE); err != nil {
+		return nil, err
+	}
+
+	tok, _, lit := p.ScanIgnoreWhitespace()
+	if tok == scanner.IDENT {
+		stmt.TableName = lit
+	} else {
+		p.Unscan()
+	}
+	return stmt, nil
+}
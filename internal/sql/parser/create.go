@@ -3,7 +3,9 @@ package parser
 import (
 	"fmt"
 	"math"
+	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
@@ -33,9 +35,13 @@ func (p *Parser) parseCreateStatement() (statement.Statement, error) {
 		return p.parseCreateIndexStatement(false)
 	case scanner.SEQUENCE:
 		return p.parseCreateSequenceStatement()
+	case scanner.VIEW:
+		return p.parseCreateViewStatement()
+	case scanner.TRIGGER:
+		return p.parseCreateTriggerStatement()
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE"}, pos)
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE", "VIEW", "TRIGGER"}, pos)
 }
 
 // parseCreateTableStatement parses a create table string and returns a Statement AST object.
@@ -280,6 +286,16 @@ LOOP:
 				Check: expr.Constraint(e),
 				Paths: paths,
 			})
+		case scanner.REFERENCES:
+			fk, err := p.parseForeignKey()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			tcs = append(tcs, &database.TableConstraint{
+				ForeignKey: fk,
+				Paths:      document.Paths{path},
+			})
 		default:
 			p.Unscan()
 			break LOOP
@@ -390,9 +406,35 @@ func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*databas
 
 		tc.Check = expr.Constraint(e)
 		tc.Paths = paths
+	case scanner.FOREIGN:
+		// Parse "KEY ("
+		err = p.parseTokens(scanner.KEY)
+		if err != nil {
+			return nil, err
+		}
+
+		tc.Paths, err = p.parsePathList()
+		if err != nil {
+			return nil, err
+		}
+		if len(tc.Paths) == 0 {
+			tok, pos, lit := p.ScanIgnoreWhitespace()
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PATHS"}, pos)
+		}
+
+		// Parse "REFERENCES"
+		err = p.parseTokens(scanner.REFERENCES)
+		if err != nil {
+			return nil, err
+		}
+
+		tc.ForeignKey, err = p.parseForeignKey()
+		if err != nil {
+			return nil, err
+		}
 	default:
 		if requiresTc {
-			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PRIMARY", "UNIQUE", "CHECK"}, pos)
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"PRIMARY", "UNIQUE", "CHECK", "FOREIGN"}, pos)
 		}
 
 		p.Unscan()
@@ -402,6 +444,50 @@ func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*databas
 	return &tc, nil
 }
 
+// parseForeignKey parses the referenced table and paths of a foreign key
+// and its optional ON DELETE action, after the REFERENCES keyword.
+// If the paths are omitted, the primary key of the referenced table is used.
+func (p *Parser) parseForeignKey() (*database.ForeignKey, error) {
+	var fk database.ForeignKey
+	var err error
+
+	// Parse table name
+	fk.Table, err = p.parseIdent()
+	if err != nil {
+		pErr := errors.UnwrapAll(err).(*ParseError)
+		pErr.Expected = []string{"table_name"}
+		return nil, pErr
+	}
+
+	fk.Paths, err = p.parsePathList()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse optional ON DELETE action.
+	// CASCADE and RESTRICT are not keywords, to allow them to be used as field names.
+	if ok, err := p.parseOptional(scanner.ON, scanner.DELETE); !ok || err != nil {
+		return &fk, err
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch {
+	case tok == scanner.IDENT && strings.EqualFold(lit, "CASCADE"):
+		fk.OnDelete = database.ForeignKeyCascade
+	case tok == scanner.IDENT && strings.EqualFold(lit, "RESTRICT"):
+		fk.OnDelete = database.ForeignKeyRestrict
+	case tok == scanner.SET:
+		if err := p.parseTokens(scanner.NULL); err != nil {
+			return nil, err
+		}
+		fk.OnDelete = database.ForeignKeySetNull
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"CASCADE", "SET NULL", "RESTRICT"}, pos)
+	}
+
+	return &fk, nil
+}
+
 // parseCreateIndexStatement parses a create index string and returns a Statement AST object.
 // This function assumes the CREATE INDEX or CREATE UNIQUE INDEX tokens have already been consumed.
 func (p *Parser) parseCreateIndexStatement(unique bool) (*statement.CreateIndexStmt, error) {
@@ -437,16 +523,52 @@ func (p *Parser) parseCreateIndexStatement(unique bool) (*statement.CreateIndexS
 		return nil, err
 	}
 
-	paths, err := p.parsePathList()
-	if err != nil {
+	// Parse required ( token.
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
 		return nil, err
 	}
-	if len(paths) == 0 {
-		tok, pos, lit := p.ScanIgnoreWhitespace()
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
+
+	// Parse paths, each one optionally followed by [*]
+	var multikeyPath document.Path
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Info.Paths = append(stmt.Info.Paths, path)
+
+		multikey, err := p.parseOptional(scanner.LSBRACKET, scanner.MUL, scanner.RSBRACKET)
+		if err != nil {
+			return nil, err
+		}
+		if multikey {
+			multikeyPath = path
+		}
+		stmt.Info.Multikey = stmt.Info.Multikey || multikey
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
 	}
 
-	stmt.Info.Paths = paths
+	// Parse required ) token.
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	if stmt.Info.Multikey && len(stmt.Info.Paths) > 1 {
+		return nil, errors.Errorf("cannot index %q with other paths: composite multikey indexes are not supported", multikeyPath)
+	}
+
+	// Parse optional WHERE clause of partial indexes
+	e, err := p.parseCondition()
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		stmt.Info.Predicate = expr.Constraint(e)
+	}
 
 	return &stmt, nil
 }
@@ -723,3 +845,146 @@ func (p *Parser) parseCheckConstraint() (expr.Expr, []document.Path, error) {
 
 	return e, paths, nil
 }
+
+// parseCreateViewStatement parses a create view string and returns a Statement AST object.
+// This function assumes the CREATE VIEW tokens have already been consumed.
+func (p *Parser) parseCreateViewStatement() (*statement.CreateViewStmt, error) {
+	var stmt statement.CreateViewStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse view name
+	stmt.Info.ViewName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.AS); err != nil {
+		return nil, err
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	sel, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	// the query is stored in the catalog, it cannot depend on parameters
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "parameters are not allowed in views"}
+	}
+
+	stmt.Info.Query = sel.String()
+
+	return &stmt, nil
+}
+
+// parseCreateTriggerStatement parses a create trigger string and returns a Statement AST object.
+// This function assumes the CREATE TRIGGER tokens have already been consumed.
+func (p *Parser) parseCreateTriggerStatement() (*statement.CreateTriggerStmt, error) {
+	var stmt statement.CreateTriggerStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse trigger name
+	stmt.Info.TriggerName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse BEFORE or AFTER.
+	// BEFORE, AFTER, EACH and ROW are not keywords, to allow them to be used as field names.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch {
+	case tok == scanner.IDENT && strings.EqualFold(lit, "BEFORE"):
+		stmt.Info.Timing = database.TriggerBefore
+	case tok == scanner.IDENT && strings.EqualFold(lit, "AFTER"):
+		stmt.Info.Timing = database.TriggerAfter
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"BEFORE", "AFTER"}, pos)
+	}
+
+	// Parse the event
+	tok, pos, lit = p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.INSERT:
+		stmt.Info.Event = database.TriggerInsert
+	case scanner.UPDATE:
+		stmt.Info.Event = database.TriggerUpdate
+	case scanner.DELETE:
+		stmt.Info.Event = database.TriggerDelete
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE"}, pos)
+	}
+
+	// Parse "ON"
+	if err := p.parseTokens(scanner.ON); err != nil {
+		return nil, err
+	}
+
+	// Parse table name
+	stmt.Info.TableName, err = p.parseIdent()
+	if err != nil {
+		pErr := errors.UnwrapAll(err).(*ParseError)
+		pErr.Expected = []string{"table_name"}
+		return nil, pErr
+	}
+
+	// Parse optional FOR EACH ROW
+	if ok, _ := p.parseOptional(scanner.FOR); ok {
+		for _, kw := range []string{"EACH", "ROW"} {
+			tok, pos, lit := p.ScanIgnoreWhitespace()
+			if tok != scanner.IDENT || !strings.EqualFold(lit, kw) {
+				return nil, newParseError(scanner.Tokstr(tok, lit), []string{kw}, pos)
+			}
+		}
+	}
+
+	// Parse "DO"
+	if err := p.parseTokens(scanner.DO); err != nil {
+		return nil, err
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	var st fmt.Stringer
+	tok, pos, lit = p.ScanIgnoreWhitespace()
+	p.Unscan()
+	switch tok {
+	case scanner.INSERT:
+		st, err = p.parseInsertStatement()
+	case scanner.UPDATE:
+		st, err = p.parseUpdateStatement()
+	case scanner.DELETE:
+		var s statement.Statement
+		s, err = p.parseDeleteStatement()
+		if err == nil {
+			st = s.(fmt.Stringer)
+		}
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE"}, pos)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the statement is stored in the catalog, it cannot depend on parameters
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "parameters are not allowed in triggers"}
+	}
+
+	stmt.Info.Statement = st.String()
+
+	return &stmt, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
@@ -48,6 +49,18 @@ func TestParserCreateIndex(t *testing.T) {
 			},
 			false},
 		{"No fields", "CREATE INDEX idx ON test", nil, true},
+		{"Multikey", "CREATE INDEX idx ON test (foo.bar[*])", &statement.CreateIndexStmt{
+			Info: database.IndexInfo{
+				IndexName: "idx", Owner: database.Owner{TableName: "test"}, Paths: []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo.bar"))}, Multikey: true,
+			}}, false},
+		{"Multikey with invalid wildcard", "CREATE INDEX idx ON test (foo[*].bar)", nil, true},
+		{"Composite multikey", "CREATE INDEX idx ON test (foo[*], bar)", nil, true},
+		{"Partial", "CREATE INDEX idx ON test (foo) WHERE bar != 'done'", &statement.CreateIndexStmt{
+			Info: database.IndexInfo{
+				IndexName: "idx", Owner: database.Owner{TableName: "test"}, Paths: []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo"))},
+				Predicate: expr.Constraint(testutil.ParseExpr(t, "bar != 'done'")),
+			}}, false},
+		{"Partial without predicate", "CREATE INDEX idx ON test (foo) WHERE", nil, true},
 	}
 
 	for _, test := range tests {
@@ -204,3 +217,96 @@ func TestParserCreateSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestParserCreateView(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "CREATE VIEW v AS SELECT * FROM test", &statement.CreateViewStmt{
+			Info: database.ViewInfo{ViewName: "v", Query: "SELECT * FROM test"},
+		}, false},
+		{"If not exists", "CREATE VIEW IF NOT EXISTS v AS SELECT * FROM test", &statement.CreateViewStmt{
+			Info:        database.ViewInfo{ViewName: "v", Query: "SELECT * FROM test"},
+			IfNotExists: true,
+		}, false},
+		{"Complex query", "CREATE VIEW v AS SELECT a, COUNT(*) AS n FROM test WHERE b > 10 GROUP BY a ORDER BY a LIMIT 10", &statement.CreateViewStmt{
+			Info: database.ViewInfo{ViewName: "v", Query: "SELECT a, COUNT(*) AS n FROM test WHERE b > 10 GROUP BY a ORDER BY a LIMIT 10"},
+		}, false},
+		{"No AS", "CREATE VIEW v SELECT * FROM test", nil, true},
+		{"No query", "CREATE VIEW v AS", nil, true},
+		{"Not a SELECT", "CREATE VIEW v AS DELETE FROM test", nil, true},
+		{"Positional params", "CREATE VIEW v AS SELECT * FROM test WHERE a = ?", nil, true},
+		{"Named params", "CREATE VIEW v AS SELECT * FROM test WHERE a = $a", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserCreateTrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "CREATE TRIGGER trg AFTER INSERT ON test DO DELETE FROM foo", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "DELETE FROM foo"},
+		}, false},
+		{"If not exists", "CREATE TRIGGER IF NOT EXISTS trg BEFORE UPDATE ON test FOR EACH ROW DO DELETE FROM foo", &statement.CreateTriggerStmt{
+			Info:        database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerBefore, Event: database.TriggerUpdate, Statement: "DELETE FROM foo"},
+			IfNotExists: true,
+		}, false},
+		{"Insert values", "CREATE TRIGGER trg AFTER DELETE ON test DO INSERT INTO foo (a, b) VALUES (OLD.a, 1), (OLD.b, 2)", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerDelete, Statement: "INSERT INTO foo (a, b) VALUES (OLD.a, 1), (OLD.b, 2)"},
+		}, false},
+		{"Insert documents", "CREATE TRIGGER trg AFTER INSERT ON test DO INSERT INTO foo VALUES {a: NEW.a} ON CONFLICT DO NOTHING RETURNING a AS b", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "INSERT INTO foo VALUES {a: NEW.a} ON CONFLICT DO NOTHING RETURNING a AS b"},
+		}, false},
+		{"Insert select", "CREATE TRIGGER trg AFTER INSERT ON test DO INSERT INTO foo SELECT * FROM bar WHERE a = NEW.a", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "INSERT INTO foo SELECT * FROM bar WHERE a = NEW.a"},
+		}, false},
+		{"Update set", "CREATE TRIGGER trg AFTER INSERT ON test DO UPDATE foo SET a = a + 1, b.c = NEW.b WHERE id = NEW.id", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "UPDATE foo SET a = a + 1, b.c = NEW.b WHERE id = NEW.id"},
+		}, false},
+		{"Update unset", "CREATE TRIGGER trg AFTER INSERT ON test DO UPDATE foo UNSET a, b", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "UPDATE foo UNSET a, b"},
+		}, false},
+		{"Delete", "CREATE TRIGGER trg AFTER INSERT ON test DO DELETE FROM foo WHERE a > 1 ORDER BY a DESC LIMIT 10 OFFSET 2", &statement.CreateTriggerStmt{
+			Info: database.TriggerInfo{TriggerName: "trg", TableName: "test", Timing: database.TriggerAfter, Event: database.TriggerInsert, Statement: "DELETE FROM foo WHERE a > 1 ORDER BY a DESC LIMIT 10 OFFSET 2"},
+		}, false},
+		{"No timing", "CREATE TRIGGER trg INSERT ON test DO DELETE FROM foo", nil, true},
+		{"Bad event", "CREATE TRIGGER trg AFTER SELECT ON test DO DELETE FROM foo", nil, true},
+		{"No table", "CREATE TRIGGER trg AFTER INSERT DO DELETE FROM foo", nil, true},
+		{"Incomplete FOR EACH ROW", "CREATE TRIGGER trg AFTER INSERT ON test FOR EACH DO DELETE FROM foo", nil, true},
+		{"No DO", "CREATE TRIGGER trg AFTER INSERT ON test DELETE FROM foo", nil, true},
+		{"Select", "CREATE TRIGGER trg AFTER INSERT ON test DO SELECT * FROM foo", nil, true},
+		{"Params", "CREATE TRIGGER trg AFTER INSERT ON test DO DELETE FROM foo WHERE a = ?", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
@@ -23,7 +23,9 @@ func TestParserExplain(t *testing.T) {
 		errored  bool
 	}{
 		{"Explain select", "EXPLAIN SELECT * FROM test", &statement.ExplainStmt{Statement: slct}, false},
+		{"Explain analyze select", "EXPLAIN ANALYZE SELECT * FROM test", &statement.ExplainStmt{Statement: slct, Analyze: true}, false},
 		{"Multiple Explains", "EXPLAIN EXPLAIN CREATE TABLE test", nil, true},
+		{"Explain analyze", "EXPLAIN ANALYZE test", nil, true},
 	}
 
 	for _, test := range tests {
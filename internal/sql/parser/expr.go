@@ -206,7 +206,7 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 			return p.parseFunction()
 		} else if tok1 == scanner.DOT {
 			// it may be a package function instead.
-			if tok2, _, _ := p.Scan(); tok2 == scanner.IDENT {
+			if tok2, _, _ := p.Scan(); tok2 == scanner.IDENT || tok2.IsKeyword() {
 				if tok3, _, _ := p.Scan(); tok3 == scanner.LPAREN {
 					p.Unscan()
 					p.Unscan()
@@ -288,6 +288,8 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 			return nil, errors.WithStack(&ParseError{Message: "unable to parse integer", Pos: pos})
 		}
 		return expr.LiteralValue{Value: types.NewIntegerValue(v)}, nil
+	case scanner.INTERVAL:
+		return p.parseInterval()
 	case scanner.TRUE, scanner.FALSE:
 		return expr.LiteralValue{Value: types.NewBoolValue(tok == scanner.TRUE)}, nil
 	case scanner.NULL:
@@ -300,6 +302,13 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 		p.Unscan()
 		return p.parseExprList(scanner.LSBRACKET, scanner.RSBRACKET)
 	case scanner.LPAREN:
+		// a SELECT statement between parentheses is a subquery
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.SELECT {
+			p.Unscan()
+			return p.parseSubquery()
+		}
+		p.Unscan()
+
 		e, err := p.ParseExpr()
 		if err != nil {
 			return nil, err
@@ -321,6 +330,16 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 		}
 
 		return nil, newParseError(scanner.Tokstr(tok, lit), []string{")", ","}, pos)
+	case scanner.EXISTS:
+		if err := p.parseTokens(scanner.LPAREN); err != nil {
+			return nil, err
+		}
+
+		sq, err := p.parseSubquery()
+		if err != nil {
+			return nil, err
+		}
+		return expr.Exists{Subquery: sq}, nil
 	case scanner.NOT:
 		e, err := p.ParseExpr()
 		if err != nil {
@@ -452,6 +471,10 @@ func (p *Parser) parseType() (types.ValueType, error) {
 		return types.IntegerValue, nil
 	case scanner.TYPETEXT:
 		return types.TextValue, nil
+	case scanner.TYPETIMESTAMP:
+		return types.TimestampValue, nil
+	case scanner.INTERVAL:
+		return types.IntervalValue, nil
 	case scanner.TYPEVARCHAR, scanner.TYPECHARACTER:
 		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
 			return 0, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
@@ -567,6 +590,13 @@ LOOP:
 			// if it's an integer, we have an array index
 			// if it's a quoted string, we have a field name
 			tok, pos, lit := p.Scan()
+			// [*] is not part of the path, it is used to refer to
+			// all the elements of an array, i.e CREATE INDEX ON foo(a[*])
+			if tok == scanner.MUL {
+				p.Unscan()
+				p.Unscan()
+				break LOOP
+			}
 			switch tok {
 			case scanner.INTEGER:
 				// is the number negative?
@@ -643,7 +673,74 @@ func (p *Parser) parseExprList(leftToken, rightToken scanner.Token) (expr.Litera
 // parseFunction parses a function call.
 // a function is an identifier followed by a parenthesis,
 // an optional coma-separated list of expressions and a closing parenthesis.
+// parseFunction parses a function call, optionally followed by an OVER clause.
 func (p *Parser) parseFunction() (expr.Expr, error) {
+	fn, err := p.parseFunctionCall()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.OVER {
+		return p.parseWindow(fn)
+	}
+	p.Unscan()
+
+	if _, ok := fn.(expr.WindowFunction); ok {
+		return nil, errors.Errorf("window function %s requires an OVER clause", fn)
+	}
+
+	return fn, nil
+}
+
+// parseWindow parses the OVER clause of a window function.
+// This function assumes the OVER token has already been consumed.
+//   fn OVER ([PARTITION BY expr, ...] [ORDER BY expr [ASC|DESC] [NULLS FIRST|NULLS LAST], ...])
+func (p *Parser) parseWindow(fn expr.Expr) (*expr.Window, error) {
+	switch fn.(type) {
+	case expr.WindowFunction, expr.AggregatorBuilder:
+	default:
+		return nil, errors.Errorf("%s is not a window function", fn)
+	}
+
+	w := expr.Window{Func: fn}
+
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
+		return nil, err
+	}
+
+	ok, err := p.parseOptional(scanner.PARTITION, scanner.BY)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		for {
+			e, err := p.ParseExpr()
+			if err != nil {
+				return nil, err
+			}
+			w.PartitionBy = append(w.PartitionBy, e)
+
+			if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+				p.Unscan()
+				break
+			}
+		}
+	}
+
+	w.OrderBy, err = p.parseOrderBy()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// parseFunctionCall parses a function name and its arguments.
+func (p *Parser) parseFunctionCall() (expr.Expr, error) {
 	// Parse function name.
 	funcName, err := p.parseIdent()
 	if err != nil {
@@ -654,9 +751,15 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 	var pkgName string
 	if tok, _, _ := p.Scan(); tok == scanner.DOT {
 		pkgName = funcName
-		funcName, err = p.parseIdent()
-		if err != nil {
-			return nil, err
+		// package functions can be named after keywords (e.g. strings.replace)
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		switch {
+		case tok == scanner.IDENT:
+			funcName = lit
+		case tok.IsKeyword():
+			funcName = strings.ToLower(tok.String())
+		default:
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"identifier"}, pos)
 		}
 	} else {
 		p.Unscan()
@@ -3,10 +3,12 @@ package parser_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
@@ -41,6 +43,15 @@ func TestParserExpr(t *testing.T) {
 		{"blob as hex string", `'\xff'`, testutil.BlobValue([]byte{255}), false},
 		{"invalid blob hex string", `'\xzz'`, nil, true},
 
+		// intervals
+		{"interval", `INTERVAL '1 day 2 hours'`, expr.LiteralValue{Value: types.NewIntervalValue(types.Interval{Duration: 26 * time.Hour})}, false},
+		{"interval: go duration", `INTERVAL '1h30m'`, expr.LiteralValue{Value: types.NewIntervalValue(types.Interval{Duration: 90 * time.Minute})}, false},
+		{"interval: months", `INTERVAL '1 year 2 months 3 hours'`, expr.LiteralValue{Value: types.NewIntervalValue(types.Interval{Months: 14, Duration: 3 * time.Hour})}, false},
+		{"interval: fractional months", `INTERVAL '1.5 months'`, nil, true},
+		{"interval: invalid unit", `INTERVAL '1 fortnight'`, nil, true},
+		{"interval: missing unit", `INTERVAL '1 day 2'`, nil, true},
+		{"interval: not a string", `INTERVAL 1`, nil, true},
+
 		// documents
 		{"empty document", `{}`, &expr.KVPairs{SelfReferenced: true}, false},
 		{"document values", `{a: 1, b: 1.0, c: true, d: 'string', e: "string", f: {foo: 'bar'}, g: h.i.j, k: [1, 2, 3]}`,
@@ -160,6 +171,7 @@ func TestParserExpr(t *testing.T) {
 
 		// unary operators
 		{"CAST", "CAST(a.b[1][0] AS TEXT)", expr.Cast{Expr: testutil.ParsePath(t, "a.b[1][0]"), CastAs: types.TextValue}, false},
+		{"CAST timestamp", "CAST(a AS TIMESTAMP)", expr.Cast{Expr: testutil.ParsePath(t, "a"), CastAs: types.TimestampValue}, false},
 		{"NOT", "NOT 10", expr.Not(testutil.IntegerValue(10)), false},
 		{"NOT", "NOT NOT", nil, true},
 		{"NOT", "NOT NOT 10", expr.Not(expr.Not(testutil.IntegerValue(10))), false},
@@ -172,7 +184,38 @@ func TestParserExpr(t *testing.T) {
 		{"count(expr) function", "count(a)", &functions.Count{Expr: testutil.ParsePath(t, "a")}, false},
 		{"count(*) function", "count(*)", &functions.Count{Wildcard: true}, false},
 		{"count (*) function with spaces", "count      (*)", &functions.Count{Wildcard: true}, false},
+
+		// window functions
+		{"window function", "row_number() OVER ()", &expr.Window{Func: &functions.RowNumber{}}, false},
+		{"window function with partition", "rank() OVER (PARTITION BY a, b ORDER BY c DESC)", &expr.Window{
+			Func:        &functions.Rank{},
+			PartitionBy: []expr.Expr{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b")},
+			OrderBy:     []expr.SortKey{{Expr: testutil.ParsePath(t, "c"), Desc: true}},
+		}, false},
+		{"aggregate window function", "sum(a) OVER (ORDER BY b NULLS LAST)", &expr.Window{
+			Func:    &functions.Sum{Expr: testutil.ParsePath(t, "a")},
+			OrderBy: []expr.SortKey{{Expr: testutil.ParsePath(t, "b"), NullsLast: true}},
+		}, false},
+		{"lag with offset and default", "lag(a, 2, 0) OVER (ORDER BY b)", &expr.Window{
+			Func:    &functions.Lag{Expr: testutil.ParsePath(t, "a"), Offset: testutil.IntegerValue(2), Default: testutil.IntegerValue(0)},
+			OrderBy: []expr.SortKey{{Expr: testutil.ParsePath(t, "b")}},
+		}, false},
+		{"window function without OVER", "row_number()", nil, true},
+		{"scalar function with OVER", "typeof(a) OVER ()", nil, true},
+		{"lead with too many arguments", "lead(a, 1, 2, 3) OVER ()", nil, true},
 		{"packaged function", "math.floor(1.2)", testutil.FunctionExpr(t, "math.floor", testutil.DoubleValue(1.2)), false},
+		{"packaged function named after a keyword", "strings.replace('a', 'b', 'c')", testutil.FunctionExpr(t, "strings.replace", testutil.TextValue("a"), testutil.TextValue("b"), testutil.TextValue("c")), false},
+
+		// subqueries
+		{"scalar subquery", "(SELECT MAX(a) FROM foo)", subquery(t, "SELECT MAX(a) FROM foo"), false},
+		{"scalar subquery in operator", "1 + (SELECT a FROM foo WHERE foo.b = bar.b LIMIT 1)",
+			expr.Add(testutil.IntegerValue(1), subquery(t, "SELECT a FROM foo WHERE foo.b = bar.b LIMIT 1")), false},
+		{"IN subquery", "age IN (SELECT a FROM foo)", expr.In(testutil.ParsePath(t, "age"), subquery(t, "SELECT a FROM foo")), false},
+		{"NOT IN subquery", "age NOT IN (SELECT a FROM foo)", expr.NotIn(testutil.ParsePath(t, "age"), subquery(t, "SELECT a FROM foo")), false},
+		{"EXISTS", "EXISTS (SELECT * FROM foo)", expr.Exists{Subquery: subquery(t, "SELECT * FROM foo")}, false},
+		{"NOT EXISTS", "NOT EXISTS (SELECT * FROM foo)", expr.Not(expr.Exists{Subquery: subquery(t, "SELECT * FROM foo")}), false},
+		{"EXISTS without parentheses", "EXISTS SELECT * FROM foo", nil, true},
+		{"subquery without closing parenthesis", "(SELECT * FROM foo", nil, true},
 	}
 
 	for _, test := range tests {
@@ -279,3 +322,13 @@ func TestParserParams(t *testing.T) {
 		})
 	}
 }
+
+func subquery(t testing.TB, s string) *expr.Subquery {
+	t.Helper()
+
+	q, err := parser.ParseQuery(s)
+	assert.NoError(t, err)
+	require.Len(t, q.Statements, 1)
+
+	return &expr.Subquery{Stmt: q.Statements[0].(*statement.SelectStmt)}
+}
@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/types"
+)
+
+// parseInterval parses an interval literal and returns an interval value.
+// This function assumes the INTERVAL token has already been consumed.
+//
+//	INTERVAL '1 month 2 hours'
+//	INTERVAL '1h30m'
+func (p *Parser) parseInterval() (expr.Expr, error) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.STRING {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"string"}, pos)
+	}
+
+	i, err := types.ParseInterval(lit)
+	if err != nil {
+		return nil, errors.WithStack(&ParseError{Message: err.Error(), Pos: pos})
+	}
+
+	return expr.LiteralValue{Value: types.NewIntervalValue(i)}, nil
+}
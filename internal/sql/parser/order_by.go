@@ -2,31 +2,74 @@ package parser
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/scanner"
 )
 
-func (p *Parser) parseOrderBy() (expr.Path, scanner.Token, error) {
+// parseOrderBy parses a list of sort keys, each having an optional direction
+// and an optional position for NULL values:
+//   ORDER BY expr [ASC|DESC] [NULLS FIRST|NULLS LAST], ...
+func (p *Parser) parseOrderBy() ([]expr.SortKey, error) {
 	// parse ORDER token
 	ok, err := p.parseOptional(scanner.ORDER, scanner.BY)
 	if err != nil || !ok {
-		return nil, 0, err
+		return nil, err
 	}
 
-	// parse path
-	path, err := p.parsePath()
+	var keys []expr.SortKey
+	for {
+		k, err := p.parseSortKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (p *Parser) parseSortKey() (expr.SortKey, error) {
+	var k expr.SortKey
+	var err error
+
+	k.Expr, err = p.ParseExpr()
 	if err != nil {
-		return nil, 0, err
+		return k, err
 	}
 
 	// parse optional ASC or DESC
-	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.ASC || tok == scanner.DESC {
-		return expr.Path(path), tok, nil
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.DESC {
+		k.Desc = true
+	} else if tok != scanner.ASC {
+		p.Unscan()
+	}
+
+	// parse optional NULLS FIRST or NULLS LAST.
+	// NULLS, FIRST and LAST are not keywords, to allow them to be used as field names.
+	tok, _, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.IDENT || !strings.EqualFold(lit, "NULLS") {
+		p.Unscan()
+		return k, nil
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch {
+	case tok == scanner.IDENT && strings.EqualFold(lit, "FIRST"):
+		k.NullsFirst = true
+	case tok == scanner.IDENT && strings.EqualFold(lit, "LAST"):
+		k.NullsLast = true
+	default:
+		return k, newParseError(scanner.Tokstr(tok, lit), []string{"FIRST", "LAST"}, pos)
 	}
-	p.Unscan()
 
-	return expr.Path(path), 0, nil
+	return k, nil
 }
 
 func (p *Parser) parseLimit() (expr.Expr, error) {
@@ -36,6 +36,12 @@ func NewParserWithOptions(r io.Reader, opts *Options) *Parser {
 	return &Parser{s: scanner.NewScanner(r), packagesTable: opts.Packages}
 }
 
+func init() {
+	statement.ParseStatement = func(q string) (statement.Statement, error) {
+		return NewParser(strings.NewReader(q)).ParseStatement()
+	}
+}
+
 // ParseQuery parses a query string and returns its AST representation.
 func ParseQuery(s string) (query.Query, error) {
 	return NewParser(strings.NewReader(s)).ParseQuery()
@@ -112,6 +118,8 @@ func (p *Parser) ParseStatement() (statement.Statement, error) {
 	switch tok {
 	case scanner.ALTER:
 		return p.parseAlterStatement()
+	case scanner.ANALYZE:
+		return p.parseAnalyzeStatement()
 	case scanner.BEGIN:
 		return p.parseBeginStatement()
 	case scanner.COMMIT:
@@ -134,10 +142,14 @@ func (p *Parser) ParseStatement() (statement.Statement, error) {
 		return p.parseReIndexStatement()
 	case scanner.ROLLBACK:
 		return p.parseRollbackStatement()
+	case scanner.SAVEPOINT:
+		return p.parseSavepointStatement()
+	case scanner.RELEASE:
+		return p.parseReleaseStatement()
 	}
 
 	return nil, newParseError(scanner.Tokstr(tok, lit), []string{
-		"ALTER", "BEGIN", "COMMIT", "SELECT", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK",
+		"ALTER", "ANALYZE", "BEGIN", "COMMIT", "SELECT", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK", "SAVEPOINT", "RELEASE",
 	}, pos)
 }
 
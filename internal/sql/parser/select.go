@@ -18,8 +18,8 @@ func (p *Parser) parseSelectStatement() (*statement.SelectStmt, error) {
 		return nil, err
 	}
 
-	// Parse order by: "ORDER BY path [ASC|DESC]?"
-	stmt.OrderBy, stmt.OrderByDirection, err = p.parseOrderBy()
+	// Parse order by: "ORDER BY expr [ASC|DESC]? [NULLS FIRST|NULLS LAST]?, ..."
+	stmt.OrderBy, err = p.parseOrderBy()
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +92,7 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 	}
 
 	// Parse "FROM".
-	stmt.TableName, err = p.parseFrom()
+	err = p.parseFrom(&stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +103,14 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 		return nil, err
 	}
 
-	// Parse group by: "GROUP BY expr"
-	stmt.GroupByExpr, err = p.parseGroupBy()
+	// Parse group by: "GROUP BY expr [, expr ...]"
+	stmt.GroupByExprs, err = p.parseGroupBy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse having: "HAVING expr"
+	stmt.HavingExpr, err = p.parseHaving()
 	if err != nil {
 		return nil, err
 	}
@@ -168,29 +174,230 @@ func (p *Parser) parseProjectedExpr() (expr.Expr, error) {
 	return ne, nil
 }
 
-func (p *Parser) parseFrom() (string, error) {
+// parseFrom parses the FROM clause and the JOIN clauses that follow it.
+// Items separated by commas are joined without condition.
+func (p *Parser) parseFrom(stmt *statement.SelectCoreStmt) error {
 	if ok, err := p.parseOptional(scanner.FROM); !ok || err != nil {
-		return "", err
+		return err
+	}
+
+	var err error
+	stmt.TableName, stmt.Subquery, stmt.TableAlias, err = p.parseFromItem()
+	if err != nil {
+		return err
+	}
+
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.COMMA {
+			var join statement.JoinClause
+			if err := p.parseJoinItem(&join); err != nil {
+				return err
+			}
+			stmt.Joins = append(stmt.Joins, &join)
+			continue
+		}
+		p.Unscan()
+
+		join, err := p.parseJoin()
+		if err != nil {
+			return err
+		}
+		if join == nil {
+			return nil
+		}
+
+		stmt.Joins = append(stmt.Joins, join)
+	}
+}
+
+// parseFromItem parses a table reference or a subquery and its alias:
+//   table_name [[AS] alias]
+//   (select_stmt) [AS] alias
+func (p *Parser) parseFromItem() (string, *statement.SelectStmt, string, error) {
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
+		p.Unscan()
+		tableName, alias, err := p.parseTableRef()
+		return tableName, nil, alias, err
+	}
+
+	sq, err := p.parseSubquery()
+	if err != nil {
+		return "", nil, "", err
 	}
 
+	// subqueries must be named
+	if _, err := p.parseOptional(scanner.AS); err != nil {
+		return "", nil, "", err
+	}
+	alias, err := p.parseIdent()
+	if err != nil {
+		pErr := errors.Unwrap(err).(*ParseError)
+		pErr.Expected = []string{"alias"}
+		return "", nil, "", pErr
+	}
+
+	return "", sq.Stmt.(*statement.SelectStmt), alias, nil
+}
+
+// parseTableRef parses a table name and its optional alias:
+//   table_name [[AS] alias]
+func (p *Parser) parseTableRef() (string, string, error) {
 	// Parse table name
 	ident, err := p.parseIdent()
 	if err != nil {
 		pErr := errors.Unwrap(err).(*ParseError)
 		pErr.Expected = []string{"table_name"}
-		return ident, pErr
+		return ident, "", pErr
 	}
 
-	return ident, nil
+	// Parse optional alias
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.AS:
+		alias, err := p.parseIdent()
+		if err != nil {
+			return ident, "", err
+		}
+		return ident, alias, nil
+	case scanner.IDENT:
+		p.Unscan()
+		alias, err := p.parseIdent()
+		return ident, alias, err
+	}
+	p.Unscan()
+
+	return ident, "", nil
 }
 
-func (p *Parser) parseGroupBy() (expr.Expr, error) {
+// parseJoin parses a join clause, if any:
+//   [INNER | LEFT [OUTER]] JOIN join_item [ON expr]
+func (p *Parser) parseJoin() (*statement.JoinClause, error) {
+	var join statement.JoinClause
+
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.JOIN:
+	case scanner.INNER:
+		if err := p.parseTokens(scanner.JOIN); err != nil {
+			return nil, err
+		}
+	case scanner.LEFT:
+		if _, err := p.parseOptional(scanner.OUTER); err != nil {
+			return nil, err
+		}
+		if err := p.parseTokens(scanner.JOIN); err != nil {
+			return nil, err
+		}
+		join.Left = true
+	default:
+		p.Unscan()
+		return nil, nil
+	}
+
+	err := p.parseJoinItem(&join)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ON clause is optional for inner joins only
+	if join.Left {
+		if err := p.parseTokens(scanner.ON); err != nil {
+			return nil, err
+		}
+	} else if ok, err := p.parseOptional(scanner.ON); err != nil {
+		return nil, err
+	} else if !ok {
+		return &join, nil
+	}
+
+	join.On, err = p.ParseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	return &join, nil
+}
+
+// parseJoinItem parses the item of a join clause:
+//   from_item
+//   UNNEST(expr) [AS] alias
+func (p *Parser) parseJoinItem(join *statement.JoinClause) error {
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.UNNEST {
+		p.Unscan()
+		var err error
+		join.TableName, join.Subquery, join.TableAlias, err = p.parseFromItem()
+		return err
+	}
+
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
+		return err
+	}
+
+	var err error
+	join.Unnest, err = p.ParseExpr()
+	if err != nil {
+		return err
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return err
+	}
+
+	// the values of the array must be named
+	if _, err := p.parseOptional(scanner.AS); err != nil {
+		return err
+	}
+	join.TableAlias, err = p.parseIdent()
+	if err != nil {
+		pErr := errors.Unwrap(err).(*ParseError)
+		pErr.Expected = []string{"alias"}
+		return pErr
+	}
+
+	return nil
+}
+
+// parseSubquery parses a SELECT statement followed by a closing parenthesis.
+// This function assumes the opening parenthesis has already been consumed.
+func (p *Parser) parseSubquery() (*expr.Subquery, error) {
+	stmt, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
+	}
+
+	return &expr.Subquery{Stmt: stmt}, nil
+}
+
+func (p *Parser) parseGroupBy() ([]expr.Expr, error) {
 	ok, err := p.parseOptional(scanner.GROUP, scanner.BY)
 	if err != nil || !ok {
 		return nil, err
 	}
 
-	// parse expr
-	e, err := p.ParseExpr()
-	return e, err
+	// parse expr list
+	var exprs []expr.Expr
+	for {
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			return exprs, nil
+		}
+	}
+}
+
+func (p *Parser) parseHaving() (expr.Expr, error) {
+	if ok, err := p.parseOptional(scanner.HAVING); !ok || err != nil {
+		return nil, err
+	}
+
+	return p.ParseExpr()
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/join"
 	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
@@ -82,10 +83,37 @@ func TestParserSelect(t *testing.T) {
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
 				Pipe(docs.TempTreeSort(parser.MustParseExpr("a.b.c"))).
-				Pipe(docs.GroupAggregate(parser.MustParseExpr("a.b.c"))).
+				Pipe(docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a.b.c")})).
 				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a.b.c", Expr: expr.Path(document.NewPath("a.b.c"))})),
 			true, false,
 		},
+		{"WithMultipleGroupBy", "SELECT a, b + 1, COUNT(*) FROM test GROUP BY a, b + 1",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(expr.LiteralExprList{parser.MustParseExpr("a"), parser.MustParseExpr("b + 1")})).
+				Pipe(docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a"), parser.MustParseExpr("b + 1")}, &functions.Count{Wildcard: true})).
+				Pipe(docs.Project(
+					&expr.NamedExpr{ExprName: "a", Expr: expr.Path(document.NewPath("a"))},
+					&expr.NamedExpr{ExprName: "b + 1", Expr: expr.Path(document.NewPath("b + 1"))},
+					testutil.ParseNamedExpr(t, "COUNT(*)"),
+				)),
+			true, false,
+		},
+		{"WithHaving", "SELECT a FROM test GROUP BY a HAVING a > 1 AND COUNT(*) > 10",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("a"))).
+				Pipe(docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a")}, &functions.Count{Wildcard: true})).
+				Pipe(docs.Filter(parser.MustParseExpr("a > 1 AND COUNT(*) > 10"))).
+				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a", Expr: expr.Path(document.NewPath("a"))})),
+			true, false,
+		},
+		{"WithHavingWithoutGroupBy", "SELECT COUNT(*) FROM test HAVING COUNT(*) > 10",
+			stream.New(table.Scan("test")).
+				Pipe(docs.GroupAggregate(nil, &functions.Count{Wildcard: true})).
+				Pipe(docs.Filter(parser.MustParseExpr("COUNT(*) > 10"))).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "COUNT(*)"))),
+			true, false,
+		},
+		{"WithHavingBeforeGroupBy", "SELECT a FROM test HAVING COUNT(*) > 10 GROUP BY a", nil, true, true},
 		{"WithOrderBy", "SELECT * FROM test WHERE age = 10 ORDER BY a.b.c",
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
@@ -104,6 +132,26 @@ func TestParserSelect(t *testing.T) {
 				Pipe(docs.TempTreeSortReverse(testutil.ParsePath(t, "a.b.c"))),
 			true, false,
 		},
+		{"WithMultipleOrderBy", "SELECT * FROM test ORDER BY a DESC, len(b), c ASC NULLS LAST",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSortBy(
+					expr.SortKey{Expr: testutil.ParsePath(t, "a"), Desc: true},
+					expr.SortKey{Expr: parser.MustParseExpr("len(b)")},
+					expr.SortKey{Expr: testutil.ParsePath(t, "c"), NullsLast: true},
+				)),
+			true, false,
+		},
+		{"WithOrderBy NULLS FIRST", "SELECT * FROM test ORDER BY a DESC NULLS FIRST",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSortBy(expr.SortKey{Expr: testutil.ParsePath(t, "a"), Desc: true, NullsFirst: true})),
+			true, false,
+		},
+		{"WithOrderBy NULLS without position", "SELECT * FROM test ORDER BY a NULLS", nil, true, true},
+		{"WithOrderBy field named nulls", "SELECT * FROM test ORDER BY nulls",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(testutil.ParsePath(t, "nulls"))),
+			true, false,
+		},
 		{"WithLimit", "SELECT * FROM test WHERE age = 10 LIMIT 20",
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
@@ -310,6 +358,69 @@ func TestParserSelect(t *testing.T) {
 			)),
 			false, false,
 		},
+		{"WithTableAlias", "SELECT t.a FROM test AS t WHERE t.b > 1",
+			stream.New(aliasedScan("test", "t")).
+				Pipe(docs.Filter(parser.MustParseExpr("t.b > 1"))).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "t.a"))),
+			true, false,
+		},
+		{"WithJoin", "SELECT * FROM a JOIN b ON a.x = b.y",
+			stream.New(table.Scan("a")).
+				Pipe(join.NestedLoop(stream.New(table.Scan("b")), "b", parser.MustParseExpr("a.x = b.y"))),
+			true, false,
+		},
+		{"WithInnerJoinAndAliases", "SELECT * FROM a x INNER JOIN b AS y",
+			stream.New(aliasedScan("a", "x")).
+				Pipe(join.NestedLoop(stream.New(aliasedScan("b", "y")), "y", nil)),
+			true, false,
+		},
+		{"WithLeftJoins", "SELECT x.a, c.b FROM a AS x LEFT JOIN b ON x.a = b.a LEFT OUTER JOIN c ON b.a = c.a WHERE x.a > 1",
+			stream.New(aliasedScan("a", "x")).
+				Pipe(join.LeftNestedLoop(stream.New(table.Scan("b")), "b", parser.MustParseExpr("x.a = b.a"))).
+				Pipe(join.LeftNestedLoop(stream.New(table.Scan("c")), "c", parser.MustParseExpr("b.a = c.a"))).
+				Pipe(docs.Filter(parser.MustParseExpr("x.a > 1"))).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "x.a"), testutil.ParseNamedExpr(t, "c.b"))),
+			true, false,
+		},
+		{"WithLeftJoinWithoutCondition", "SELECT * FROM a LEFT JOIN b", nil, true, true},
+		{"WithJoinWithoutTable", "SELECT * FROM a JOIN ON a.x = 1", nil, true, true},
+		{"WithSubquery", "SELECT s.a FROM (SELECT a FROM test WHERE b > 1) AS s",
+			stream.New(stream.Subquery(
+				stream.New(table.Scan("test")).
+					Pipe(docs.Filter(parser.MustParseExpr("b > 1"))).
+					Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"))),
+				"s")).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "s.a"))),
+			true, false,
+		},
+		{"WithJoinedSubquery", "SELECT * FROM a JOIN (SELECT x FROM b) s ON a.x = s.x",
+			stream.New(table.Scan("a")).
+				Pipe(join.NestedLoop(stream.New(stream.Subquery(
+					stream.New(table.Scan("b")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "x"))),
+					"s")), "s", parser.MustParseExpr("a.x = s.x"))),
+			true, false,
+		},
+		{"WithCommaJoin", "SELECT * FROM a, b AS y",
+			stream.New(table.Scan("a")).
+				Pipe(join.NestedLoop(stream.New(aliasedScan("b", "y")), "y", nil)),
+			true, false,
+		},
+		{"WithUnnest", "SELECT a.x, i FROM a, UNNEST(a.items) AS i WHERE i > 1",
+			stream.New(table.Scan("a")).
+				Pipe(join.Unnest(parser.MustParseExpr("a.items"), "i", nil)).
+				Pipe(docs.Filter(parser.MustParseExpr("i > 1"))).
+				Pipe(docs.Project(testutil.ParseNamedExpr(t, "a.x"), testutil.ParseNamedExpr(t, "i"))),
+			true, false,
+		},
+		{"WithLeftJoinUnnest", "SELECT * FROM a LEFT JOIN UNNEST(a.items) i ON i > 1",
+			stream.New(table.Scan("a")).
+				Pipe(join.LeftUnnest(parser.MustParseExpr("a.items"), "i", parser.MustParseExpr("i > 1"))),
+			true, false,
+		},
+		{"WithUnnestWithoutAlias", "SELECT * FROM a, UNNEST(a.items)", nil, true, true},
+		{"WithUnnestWithoutParenthesis", "SELECT * FROM a, UNNEST a.items AS i", nil, true, true},
+		{"WithSubqueryWithoutAlias", "SELECT * FROM (SELECT a FROM test)", nil, true, true},
+		{"WithSubqueryWithoutParenthesis", "SELECT * FROM (SELECT a FROM test AS s", nil, true, true},
 	}
 
 	for _, test := range tests {
@@ -344,6 +455,34 @@ func TestParserSelect(t *testing.T) {
 	}
 }
 
+func TestSelectStmtString(t *testing.T) {
+	tests := []string{
+		"SELECT * FROM test",
+		"SELECT DISTINCT a, b + 1 AS c FROM test AS t WHERE t.a > 1 GROUP BY a",
+		"SELECT a, b, COUNT(*) FROM test GROUP BY a, b HAVING COUNT(*) > 1",
+		"SELECT a.x FROM a JOIN b ON a.x = b.y LEFT JOIN (SELECT y FROM c) AS d ON d.y = a.x",
+		"SELECT a FROM test WHERE a IN (SELECT b FROM foo) AND EXISTS (SELECT * FROM bar)",
+		"SELECT a FROM test UNION ALL SELECT b FROM foo UNION SELECT c FROM bar ORDER BY a DESC LIMIT 10 OFFSET 2",
+		"SELECT * FROM test ORDER BY a, b DESC NULLS FIRST, LEN(c) NULLS LAST",
+		"SELECT a, ROW_NUMBER() OVER (PARTITION BY b ORDER BY c DESC) AS n, SUM(a) OVER () FROM test",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			q, err := parser.ParseQuery(test)
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.Equal(t, test, q.Statements[0].(*statement.SelectStmt).String())
+		})
+	}
+}
+
+func aliasedScan(tableName, alias string) *table.ScanOperator {
+	s := table.Scan(tableName)
+	s.Alias = alias
+	return s
+}
+
 func BenchmarkSelect(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = parser.ParseQuery("SELECT a, b.c[100].d AS `foo` FROM `some table` WHERE d.e[100] >= 12 AND c.d IN ([1, true], [2, false]) GROUP BY d.e[0] LIMIT 10 + 10 OFFSET 20 - 20 ORDER BY d DESC")
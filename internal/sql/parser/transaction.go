@@ -47,7 +47,52 @@ func (p *Parser) parseRollbackStatement() (statement.Statement, error) {
 	// parse optional TRANSACTION token
 	_, _ = p.parseOptional(scanner.TRANSACTION)
 
-	return query.RollbackStmt{}, nil
+	// parse optional TO [SAVEPOINT] name
+	if ok, err := p.parseOptional(scanner.TO); !ok || err != nil {
+		return query.RollbackStmt{}, err
+	}
+
+	_, _ = p.parseOptional(scanner.SAVEPOINT)
+
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	return query.RollbackToSavepointStmt{Name: name}, nil
+}
+
+// parseSavepointStatement parses a SAVEPOINT statement.
+func (p *Parser) parseSavepointStatement() (statement.Statement, error) {
+	// Parse "SAVEPOINT".
+	if err := p.parseTokens(scanner.SAVEPOINT); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	return query.SavepointStmt{Name: name}, nil
+}
+
+// parseReleaseStatement parses a RELEASE [SAVEPOINT] statement.
+func (p *Parser) parseReleaseStatement() (statement.Statement, error) {
+	// Parse "RELEASE".
+	if err := p.parseTokens(scanner.RELEASE); err != nil {
+		return nil, err
+	}
+
+	// parse optional SAVEPOINT token
+	_, _ = p.parseOptional(scanner.SAVEPOINT)
+
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	return query.ReleaseSavepointStmt{Name: name}, nil
 }
 
 // parseCommitStatement parses a COMMIT statement.
@@ -24,6 +24,15 @@ func TestParserTransactions(t *testing.T) {
 		{"BEGIN WRITE", query.BeginStmt{}, true},
 		{"ROLLBACK", query.RollbackStmt{}, false},
 		{"ROLLBACK TRANSACTION", query.RollbackStmt{}, false},
+		{"ROLLBACK TO a", query.RollbackToSavepointStmt{Name: "a"}, false},
+		{"ROLLBACK TO SAVEPOINT a", query.RollbackToSavepointStmt{Name: "a"}, false},
+		{"ROLLBACK TRANSACTION TO SAVEPOINT a", query.RollbackToSavepointStmt{Name: "a"}, false},
+		{"ROLLBACK TO", nil, true},
+		{"SAVEPOINT a", query.SavepointStmt{Name: "a"}, false},
+		{"SAVEPOINT", nil, true},
+		{"RELEASE a", query.ReleaseSavepointStmt{Name: "a"}, false},
+		{"RELEASE SAVEPOINT a", query.ReleaseSavepointStmt{Name: "a"}, false},
+		{"RELEASE", nil, true},
 		{"COMMIT", query.CommitStmt{}, false},
 		{"COMMIT TRANSACTION", query.CommitStmt{}, false},
 	}
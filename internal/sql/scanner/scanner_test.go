@@ -121,6 +121,7 @@ func TestScanner_Scan(t *testing.T) {
 		// Keywords
 		{s: `ADD`, tok: ADD_KEYWORD},
 		{s: `ALTER`, tok: ALTER},
+		{s: `ANALYZE`, tok: ANALYZE},
 		{s: `AS`, tok: AS},
 		{s: `ASC`, tok: ASC},
 		{s: `ALL`, tok: ALL},
@@ -143,13 +144,16 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `DROP`, tok: DROP},
 		{s: `EXPLAIN`, tok: EXPLAIN},
 		{s: `GROUP`, tok: GROUP},
+		{s: `HAVING`, tok: HAVING},
 		{s: `FIELD`, tok: FIELD},
 		{s: `FOR`, tok: FOR},
+		{s: `FOREIGN`, tok: FOREIGN},
 		{s: `FROM`, tok: FROM},
 		{s: `IGNORE`, tok: IGNORE},
 		{s: `INCREMENT`, tok: INCREMENT},
 		{s: `INDEX`, tok: INDEX},
 		{s: `INSERT`, tok: INSERT},
+		{s: `INTERVAL`, tok: INTERVAL},
 		{s: `INTO`, tok: INTO},
 		{s: `LIMIT`, tok: LIMIT},
 		{s: `MAXVALUE`, tok: MAXVALUE},
@@ -161,13 +165,18 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `ONLY`, tok: ONLY},
 		{s: `OFFSET`, tok: OFFSET},
 		{s: `ORDER`, tok: ORDER},
+		{s: `OVER`, tok: OVER},
+		{s: `PARTITION`, tok: PARTITION},
 		{s: `PRIMARY`, tok: PRIMARY},
 		{s: `READ`, tok: READ},
+		{s: `REFERENCES`, tok: REFERENCES},
 		{s: `REINDEX`, tok: REINDEX},
+		{s: `RELEASE`, tok: RELEASE},
 		{s: `RENAME`, tok: RENAME},
 		{s: `REPLACE`, tok: REPLACE},
 		{s: `RETURNING`, tok: RETURNING},
 		{s: `ROLLBACK`, tok: ROLLBACK},
+		{s: `SAVEPOINT`, tok: SAVEPOINT},
 		{s: `SELECT`, tok: SELECT},
 		{s: `SEQUENCE`, tok: SEQUENCE},
 		{s: `SET`, tok: SET},
@@ -175,11 +184,14 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `TABLE`, tok: TABLE},
 		{s: `TO`, tok: TO},
 		{s: `TRANSACTION`, tok: TRANSACTION},
+		{s: `TRIGGER`, tok: TRIGGER},
 		{s: `UPDATE`, tok: UPDATE},
 		{s: `UNION`, tok: UNION},
 		{s: `UNSET`, tok: UNSET},
+		{s: `UNNEST`, tok: UNNEST},
 		{s: `VALUE`, tok: VALUE},
 		{s: `VALUES`, tok: VALUES},
+		{s: `VIEW`, tok: VIEW},
 		{s: `WITH`, tok: WITH},
 		{s: `WHERE`, tok: WHERE},
 		{s: `WRITE`, tok: WRITE},
@@ -193,6 +205,7 @@ func TestScanner_Scan(t *testing.T) {
 		{s: "DOUBLE", tok: TYPEDOUBLE},
 		{s: "INTEGER", tok: TYPEINTEGER},
 		{s: "TEXT", tok: TYPETEXT},
+		{s: "TIMESTAMP", tok: TYPETIMESTAMP},
 	}
 
 	for i, tt := range tests {
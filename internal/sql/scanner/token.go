@@ -81,6 +81,7 @@ const (
 	ADD_KEYWORD
 	ALL
 	ALTER
+	ANALYZE
 	AS
 	ASC
 	BEGIN
@@ -103,15 +104,21 @@ const (
 	EXPLAIN
 	FIELD
 	FOR
+	FOREIGN
 	FROM
 	GROUP
+	HAVING
 	IF
 	IGNORE
 	INCREMENT
 	INDEX
+	INNER
 	INSERT
+	INTERVAL
 	INTO
+	JOIN
 	KEY
+	LEFT
 	LIMIT
 	MAXVALUE
 	MINVALUE
@@ -123,14 +130,20 @@ const (
 	ON
 	ONLY
 	ORDER
+	OUTER
+	OVER
+	PARTITION
 	PRECISION
 	PRIMARY
 	READ
+	REFERENCES
 	REINDEX
+	RELEASE
 	RENAME
 	REPLACE
 	RETURNING
 	ROLLBACK
+	SAVEPOINT
 	SELECT
 	SEQUENCE
 	SET
@@ -138,12 +151,15 @@ const (
 	TABLE
 	TO
 	TRANSACTION
+	TRIGGER
 	UNION
 	UNIQUE
+	UNNEST
 	UNSET
 	UPDATE
 	VALUE
 	VALUES
+	VIEW
 	WITH
 	WHERE
 	WRITE
@@ -166,6 +182,7 @@ const (
 	TYPEMEDIUMINT
 	TYPESMALLINT
 	TYPETEXT
+	TYPETIMESTAMP
 	TYPETINYINT
 	TYPEREAL
 	TYPEVARCHAR
@@ -229,6 +246,7 @@ var tokens = [...]string{
 	ADD_KEYWORD: "ADD",
 	ALL:         "ALL",
 	ALTER:       "ALTER",
+	ANALYZE:     "ANALYZE",
 	AS:          "AS",
 	ASC:         "ASC",
 	BEGIN:       "BEGIN",
@@ -250,16 +268,22 @@ var tokens = [...]string{
 	EXISTS:      "EXISTS",
 	EXPLAIN:     "EXPLAIN",
 	GROUP:       "GROUP",
+	HAVING:      "HAVING",
 	KEY:         "KEY",
 	FIELD:       "FIELD",
 	FOR:         "FOR",
+	FOREIGN:     "FOREIGN",
 	FROM:        "FROM",
 	IF:          "IF",
 	IGNORE:      "IGNORE",
 	INCREMENT:   "INCREMENT",
 	INDEX:       "INDEX",
+	INNER:       "INNER",
 	INSERT:      "INSERT",
+	INTERVAL:    "INTERVAL",
 	INTO:        "INTO",
+	JOIN:        "JOIN",
+	LEFT:        "LEFT",
 	LIMIT:       "LIMIT",
 	MAXVALUE:    "MAXVALUE",
 	MINVALUE:    "MINVALUE",
@@ -271,14 +295,20 @@ var tokens = [...]string{
 	ON:          "ON",
 	ONLY:        "ONLY",
 	ORDER:       "ORDER",
+	OUTER:       "OUTER",
+	OVER:        "OVER",
+	PARTITION:   "PARTITION",
 	PRECISION:   "PRECISION",
 	PRIMARY:     "PRIMARY",
 	READ:        "READ",
+	REFERENCES:  "REFERENCES",
 	REINDEX:     "REINDEX",
+	RELEASE:     "RELEASE",
 	RENAME:      "RENAME",
 	RETURNING:   "RETURNING",
 	REPLACE:     "REPLACE",
 	ROLLBACK:    "ROLLBACK",
+	SAVEPOINT:   "SAVEPOINT",
 	START:       "START",
 	SELECT:      "SELECT",
 	SET:         "SET",
@@ -286,12 +316,15 @@ var tokens = [...]string{
 	TABLE:       "TABLE",
 	TO:          "TO",
 	TRANSACTION: "TRANSACTION",
+	TRIGGER:     "TRIGGER",
 	UNION:       "UNION",
 	UNIQUE:      "UNIQUE",
+	UNNEST:      "UNNEST",
 	UNSET:       "UNSET",
 	UPDATE:      "UPDATE",
 	VALUE:       "VALUE",
 	VALUES:      "VALUES",
+	VIEW:        "VIEW",
 	WITH:        "WITH",
 	WHERE:       "WHERE",
 	WRITE:       "WRITE",
@@ -313,6 +346,7 @@ var tokens = [...]string{
 	TYPEMEDIUMINT: "MEDIUMINT",
 	TYPESMALLINT:  "SMALLINT",
 	TYPETEXT:      "TEXT",
+	TYPETIMESTAMP: "TIMESTAMP",
 	TYPETINYINT:   "TINYINT",
 	TYPEREAL:      "REAL",
 	TYPEVARCHAR:   "VARCHAR",
@@ -356,6 +390,9 @@ func (tok Token) Precedence() int {
 // IsOperator returns true for operator tokens.
 func (tok Token) IsOperator() bool { return tok > operatorBeg && tok < operatorEnd }
 
+// IsKeyword returns true for keyword tokens.
+func (tok Token) IsKeyword() bool { return tok > keywordBeg && tok < keywordEnd }
+
 // Tokstr returns a literal if provided, otherwise returns the token string.
 func Tokstr(tok Token, lit string) string {
 	if lit != "" {
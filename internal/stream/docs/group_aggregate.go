@@ -14,34 +14,47 @@ import (
 type GroupAggregateOperator struct {
 	stream.BaseOperator
 	Builders []expr.AggregatorBuilder
-	E        expr.Expr
+	// Exprs are the expressions forming the group key.
+	Exprs []expr.Expr
 }
 
 // GroupAggregate consumes the incoming stream and outputs one value per group.
+// The group of each document is identified by the values of the groupBy expressions.
 // It assumes the stream is sorted by groupBy.
-func GroupAggregate(groupBy expr.Expr, builders ...expr.AggregatorBuilder) *GroupAggregateOperator {
-	return &GroupAggregateOperator{E: groupBy, Builders: builders}
+func GroupAggregate(groupBy []expr.Expr, builders ...expr.AggregatorBuilder) *GroupAggregateOperator {
+	return &GroupAggregateOperator{Exprs: groupBy, Builders: builders}
 }
 
 func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var lastGroup types.Value
 	var ga *groupAggregator
 
-	var groupExpr string
-	if op.E != nil {
-		groupExpr = op.E.String()
+	var groupExprs []string
+	for _, e := range op.Exprs {
+		groupExprs = append(groupExprs, e.String())
+	}
+
+	// documents with the same values for all the expressions
+	// belong to the same group.
+	var groupBy expr.Expr
+	switch len(op.Exprs) {
+	case 0:
+	case 1:
+		groupBy = op.Exprs[0]
+	default:
+		groupBy = expr.LiteralExprList(op.Exprs)
 	}
 
 	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
-		if op.E == nil {
+		if groupBy == nil {
 			if ga == nil {
-				ga = newGroupAggregator(nil, groupExpr, op.Builders)
+				ga = newGroupAggregator(nil, groupExprs, op.Builders)
 			}
 
 			return ga.Aggregate(out)
 		}
 
-		group, err := op.E.Eval(out)
+		group, err := groupBy.Eval(out)
 		if err != nil {
 			return err
 		}
@@ -52,7 +65,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 			if err != nil {
 				return err
 			}
-			ga = newGroupAggregator(lastGroup, groupExpr, op.Builders)
+			ga = newGroupAggregator(lastGroup, groupExprs, op.Builders)
 			return ga.Aggregate(out)
 		}
 
@@ -79,7 +92,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 			return err
 		}
 
-		ga = newGroupAggregator(lastGroup, groupExpr, op.Builders)
+		ga = newGroupAggregator(lastGroup, groupExprs, op.Builders)
 		return ga.Aggregate(out)
 	})
 	if err != nil {
@@ -92,7 +105,7 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 	// we want the following result:
 	// {"COUNT(*)": 0}
 	if ga == nil {
-		ga = newGroupAggregator(nil, "", op.Builders)
+		ga = newGroupAggregator(nil, nil, op.Builders)
 	}
 
 	e, err := ga.Flush(in)
@@ -106,10 +119,13 @@ func (op *GroupAggregateOperator) String() string {
 	var sb strings.Builder
 
 	sb.WriteString("docs.GroupAggregate(")
-	if op.E != nil {
-		sb.WriteString(op.E.String())
-	} else {
+	switch len(op.Exprs) {
+	case 0:
 		sb.WriteString("NULL")
+	case 1:
+		sb.WriteString(op.Exprs[0].String())
+	default:
+		sb.WriteString(expr.LiteralExprList(op.Exprs).String())
 	}
 
 	for _, agg := range op.Builders {
@@ -126,11 +142,11 @@ func (op *GroupAggregateOperator) String() string {
 // result of the aggregation.
 type groupAggregator struct {
 	group       types.Value
-	groupExpr   string
+	groupExprs  []string
 	aggregators []expr.Aggregator
 }
 
-func newGroupAggregator(group types.Value, groupExpr string, builders []expr.AggregatorBuilder) *groupAggregator {
+func newGroupAggregator(group types.Value, groupExprs []string, builders []expr.AggregatorBuilder) *groupAggregator {
 	newAggregators := make([]expr.Aggregator, len(builders))
 	for i, b := range builders {
 		newAggregators[i] = b.Aggregator()
@@ -139,7 +155,7 @@ func newGroupAggregator(group types.Value, groupExpr string, builders []expr.Agg
 	return &groupAggregator{
 		aggregators: newAggregators,
 		group:       group,
-		groupExpr:   groupExpr,
+		groupExprs:  groupExprs,
 	}
 }
 
@@ -158,8 +174,19 @@ func (g *groupAggregator) Flush(env *environment.Environment) (*environment.Envi
 	fb := document.NewFieldBuffer()
 
 	// add the current group to the document
-	if g.groupExpr != "" {
-		fb.Add(g.groupExpr, g.group)
+	switch len(g.groupExprs) {
+	case 0:
+	case 1:
+		fb.Add(g.groupExprs[0], g.group)
+	default:
+		// each value of a composite group is stored in its own field
+		for i, name := range g.groupExprs {
+			v, err := types.As[types.Array](g.group).GetByIndex(i)
+			if err != nil {
+				return nil, err
+			}
+			fb.Add(name, v)
+		}
 	}
 
 	for _, agg := range g.aggregators {
@@ -21,7 +21,7 @@ import (
 func TestAggregate(t *testing.T) {
 	tests := []struct {
 		name     string
-		groupBy  expr.Expr
+		groupBy  []expr.Expr
 		builders []expr.AggregatorBuilder
 		in       []types.Document
 		want     []types.Document
@@ -45,7 +45,7 @@ func TestAggregate(t *testing.T) {
 		},
 		{
 			"count/groupBy",
-			parser.MustParseExpr("a % 2"),
+			[]expr.Expr{parser.MustParseExpr("a % 2")},
 			[]expr.AggregatorBuilder{&functions.Count{Expr: parser.MustParseExpr("a")}, &functions.Avg{Expr: parser.MustParseExpr("a")}},
 			generateSeqDocs(t, 10),
 			[]types.Document{testutil.MakeDocument(t, `{"a % 2": 0, "COUNT(a)": 5, "AVG(a)": 4.0}`), testutil.MakeDocument(t, `{"a % 2": 1, "COUNT(a)": 5, "AVG(a)": 5.0}`)},
@@ -61,12 +61,25 @@ func TestAggregate(t *testing.T) {
 		},
 		{
 			"no aggregator",
-			parser.MustParseExpr("a % 2"),
+			[]expr.Expr{parser.MustParseExpr("a % 2")},
 			nil,
 			generateSeqDocs(t, 4),
 			testutil.MakeDocuments(t, `{"a % 2": 0}`, `{"a % 2": 1}`),
 			false,
 		},
+		{
+			"count/multiple groupBy",
+			[]expr.Expr{parser.MustParseExpr("a % 2"), parser.MustParseExpr("a < 5")},
+			[]expr.AggregatorBuilder{&functions.Count{Wildcard: true}},
+			generateSeqDocs(t, 10),
+			testutil.MakeDocuments(t,
+				`{"a % 2": 0, "a < 5": false, "COUNT(*)": 2}`,
+				`{"a % 2": 0, "a < 5": true, "COUNT(*)": 3}`,
+				`{"a % 2": 1, "a < 5": false, "COUNT(*)": 3}`,
+				`{"a % 2": 1, "a < 5": true, "COUNT(*)": 2}`,
+			),
+			false,
+		},
 	}
 
 	for _, test := range tests {
@@ -86,8 +99,8 @@ func TestAggregate(t *testing.T) {
 			env.Catalog = db.Catalog
 
 			s := stream.New(table.Scan("test"))
-			if test.groupBy != nil {
-				s = s.Pipe(docs.TempTreeSort(test.groupBy))
+			if len(test.groupBy) > 0 {
+				s = s.Pipe(docs.TempTreeSort(expr.LiteralExprList(test.groupBy)))
 			}
 
 			s = s.Pipe(docs.GroupAggregate(test.groupBy, test.builders...))
@@ -115,9 +128,10 @@ func TestAggregate(t *testing.T) {
 	}
 
 	t.Run("String", func(t *testing.T) {
-		require.Equal(t, `docs.GroupAggregate(a % 2, a(), b())`, docs.GroupAggregate(parser.MustParseExpr("a % 2"), makeAggregatorBuilders("a()", "b()")...).String())
+		require.Equal(t, `docs.GroupAggregate(a % 2, a(), b())`, docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a % 2")}, makeAggregatorBuilders("a()", "b()")...).String())
 		require.Equal(t, `docs.GroupAggregate(NULL, a(), b())`, docs.GroupAggregate(nil, makeAggregatorBuilders("a()", "b()")...).String())
-		require.Equal(t, `docs.GroupAggregate(a % 2)`, docs.GroupAggregate(parser.MustParseExpr("a % 2")).String())
+		require.Equal(t, `docs.GroupAggregate(a % 2)`, docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a % 2")}).String())
+		require.Equal(t, `docs.GroupAggregate([a, b], a())`, docs.GroupAggregate([]expr.Expr{parser.MustParseExpr("a"), parser.MustParseExpr("b")}, makeAggregatorBuilders("a()")...).String())
 	})
 }
 
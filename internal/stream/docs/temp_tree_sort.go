@@ -1,13 +1,15 @@
 package docs
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/encoding"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/join"
 	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
 )
@@ -15,19 +17,31 @@ import (
 // A TempTreeSortOperator consumes every value of the stream and outputs them in order.
 type TempTreeSortOperator struct {
 	stream.BaseOperator
-	Expr expr.Expr
-	Desc bool
+	Keys []expr.SortKey
 }
 
 // TempTreeSort consumes every value of the stream, sorts them by the given expr and outputs them in order.
 // It creates a temporary index and uses it to sort the stream.
 func TempTreeSort(e expr.Expr) *TempTreeSortOperator {
-	return &TempTreeSortOperator{Expr: e}
+	return TempTreeSortBy(expr.SortKey{Expr: e})
 }
 
 // TempTreeSortReverse does the same as TempTreeSort but in descending order.
 func TempTreeSortReverse(e expr.Expr) *TempTreeSortOperator {
-	return &TempTreeSortOperator{Expr: e, Desc: true}
+	return TempTreeSortBy(expr.SortKey{Expr: e, Desc: true})
+}
+
+// TempTreeSortBy does the same as TempTreeSort but sorts the stream using
+// a list of keys, each having its own direction.
+// Documents that are equal for the first key are sorted using the second one, and so on.
+func TempTreeSortBy(keys ...expr.SortKey) *TempTreeSortOperator {
+	return &TempTreeSortOperator{Keys: keys}
+}
+
+// Desc reports whether the stream is sorted in descending order
+// on its first key.
+func (op *TempTreeSortOperator) Desc() bool {
+	return len(op.Keys) > 0 && op.Keys[0].Desc
 }
 
 func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
@@ -42,25 +56,41 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 	defer cleanup()
 
 	var counter int64
+	var joined bool
+
+	// the tree is iterated in the direction of the first key.
+	// the values of the keys sorted in the other direction are inverted.
+	reverse := op.Desc()
 
 	var buf []byte
 	err = op.Prev.Iterate(in, func(out *environment.Environment) error {
 		buf = buf[:0]
-		// evaluate the sort expression
-		v, err := op.Expr.Eval(out)
-		if err != nil {
-			return err
-		}
 
-		if types.IsNull(v) {
-			// the expression might be pointing to the original document.
-			v, err = op.Expr.Eval(out.Outer)
+		values := make([]types.Value, 0, len(op.Keys)+4)
+		for _, k := range op.Keys {
+			v, err := op.evalKey(k.Expr, out)
 			if err != nil {
-				// the only valid error here is a missing field.
-				if !errors.Is(err, types.ErrFieldNotFound) {
+				return err
+			}
+
+			if k.NullsFirst || k.NullsLast {
+				// prepend a marker to move NULL values to the requested position
+				isNull := types.IsNull(v)
+				if k.SortsNullsFirst() != reverse {
+					isNull = !isNull
+				}
+				values = append(values, types.NewBoolValue(isNull))
+			}
+
+			if k.Desc != reverse {
+				b, err := invertedKeyBytes(nil, v)
+				if err != nil {
 					return err
 				}
+				v = types.NewBlobValue(b)
 			}
+
+			values = append(values, v)
 		}
 
 		doc, ok := out.GetDocument()
@@ -70,13 +100,35 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 
 		tableName, _ := out.Get(environment.TableKey)
 
+		// the alias of the document is only kept if it was set by the
+		// operator that produced the document, like a table scan.
+		alias := types.NewNullValue()
+		// the same goes for the values computed by a window operator.
+		windows := types.NewNullValue()
+		if out.Vars != nil {
+			if a, err := environment.AliasKey.GetValueFromDocument(out.Vars); err == nil {
+				alias = a
+			}
+			if w, err := environment.WindowsKey.GetValueFromDocument(out.Vars); err == nil {
+				windows = w
+			}
+		}
+
+		// joined documents are stored as is and rebuilt when decoded
+		if _, ok := doc.(*join.Document); ok {
+			joined = true
+			tableName = types.NewNullValue()
+			alias = types.NewNullValue()
+		}
+
 		var encKey []byte
 		key, ok := out.GetKey()
 		if ok {
 			encKey = key.Encoded
 		}
 
-		tk := tree.NewKey(v, tableName, types.NewBlobValue(encKey), types.NewIntegerValue(counter))
+		values = append(values, windows, tableName, alias, types.NewBlobValue(encKey), types.NewIntegerValue(counter))
+		tk := tree.NewKey(values...)
 
 		counter++
 
@@ -93,32 +145,188 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 	var newEnv environment.Environment
 	newEnv.SetOuter(in)
 
-	return tr.IterateOnRange(nil, op.Desc, func(k *tree.Key, data []byte) error {
+	return tr.IterateOnRange(nil, reverse, func(k *tree.Key, data []byte) error {
 		kv, err := k.Decode()
 		if err != nil {
 			return err
 		}
 
-		tableName := kv[1]
+		// the window values, table name, alias and document key are stored
+		// after the sort values, right before the counter
+		windows := kv[len(kv)-5]
+		if windows.Type() != types.NullValue {
+			newEnv.Set(environment.WindowsKey, windows)
+		}
+
+		tableName := kv[len(kv)-4]
 		if tableName.Type() != types.NullValue {
 			newEnv.Set(environment.TableKey, tableName)
 		}
 
-		docKey := kv[2]
+		docKey := kv[len(kv)-2]
 		if docKey.Type() != types.NullValue {
 			newEnv.SetKey(tree.NewEncodedKey(types.As[[]byte](docKey)))
 		}
 
-		newEnv.SetDocument(encoding.DecodeDocument(data, false /* intAsDouble */))
+		doc := encoding.DecodeDocument(data, false /* intAsDouble */)
+
+		if joined {
+			jd, err := join.FromDocument(doc)
+			if err != nil {
+				return err
+			}
+			for i, name := range jd.Names {
+				newEnv.Set(document.Path{document.PathFragment{FieldName: name}}, jd.Values[i])
+			}
+			doc = jd
+		}
+
+		alias := kv[len(kv)-3]
+		if alias.Type() != types.NullValue {
+			newEnv.Set(environment.AliasKey, alias)
+			newEnv.Set(document.Path{document.PathFragment{FieldName: types.As[string](alias)}}, types.NewDocumentValue(doc))
+		}
+
+		newEnv.SetDocument(doc)
 
 		return fn(&newEnv)
 	})
 }
 
+// evalKey evaluates the sort expression.
+func (op *TempTreeSortOperator) evalKey(e expr.Expr, out *environment.Environment) (types.Value, error) {
+	v, err := e.Eval(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if types.IsNull(v) && out.Outer != nil {
+		// the expression might be pointing to the original document.
+		v, err = e.Eval(out.Outer)
+		if err != nil {
+			// the only valid error here is a missing field.
+			if !errors.Is(err, types.ErrFieldNotFound) {
+				return nil, err
+			}
+			return types.NewNullValue(), nil
+		}
+	}
+
+	return v, nil
+}
+
 func (op *TempTreeSortOperator) String() string {
-	if op.Desc {
-		return fmt.Sprintf("docs.TempTreeSortReverse(%s)", op.Expr)
+	var s strings.Builder
+
+	reverse := true
+	for _, k := range op.Keys {
+		if !k.Desc || k.NullsFirst || k.NullsLast {
+			reverse = false
+		}
+	}
+
+	if reverse {
+		s.WriteString("docs.TempTreeSortReverse(")
+	} else {
+		s.WriteString("docs.TempTreeSort(")
+	}
+
+	for i, k := range op.Keys {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		if reverse {
+			s.WriteString(k.Expr.String())
+		} else {
+			s.WriteString(k.String())
+		}
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
+
+// invertedKeyBytes appends to dst an encoding of v whose bytewise order
+// is the opposite of the order of the values.
+func invertedKeyBytes(dst []byte, v types.Value) ([]byte, error) {
+	start := len(dst)
+	dst, err := orderedKeyBytes(dst, v)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := start; i < len(dst); i++ {
+		dst[i] = ^dst[i]
+	}
+
+	return dst, nil
+}
+
+// orderedKeyBytes appends to dst an encoding of v that can be compared bytewise
+// and that follows the order of encoding.Compare.
+// None of the encoded values is a prefix of another one, which guarantees that
+// inverting the bytes of the encoding inverts the order.
+func orderedKeyBytes(dst []byte, v types.Value) ([]byte, error) {
+	switch v.Type() {
+	case types.TextValue:
+		dst = append(dst, encoding.TextValue)
+		if v.V() == nil {
+			return appendEscapedBytes(dst, nil), nil
+		}
+		return appendEscapedBytes(dst, []byte(types.As[string](v))), nil
+	case types.BlobValue:
+		dst = append(dst, encoding.BlobValue)
+		if v.V() == nil {
+			return appendEscapedBytes(dst, nil), nil
+		}
+		return appendEscapedBytes(dst, types.As[[]byte](v)), nil
+	case types.ArrayValue:
+		dst = append(dst, encoding.ArrayValue)
+		if v.V() == nil {
+			return append(dst, 0x00), nil
+		}
+		err := types.As[types.Array](v).Iterate(func(i int, value types.Value) error {
+			var err error
+			dst, err = orderedKeyBytes(append(dst, 0x01), value)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, 0x00), nil
+	case types.DocumentValue:
+		dst = append(dst, encoding.DocumentValue)
+		if v.V() == nil {
+			return append(dst, 0x00), nil
+		}
+		err := types.As[types.Document](v).Iterate(func(field string, value types.Value) error {
+			var err error
+			dst = append(dst, 0x01, encoding.TextValue)
+			dst = appendEscapedBytes(dst, []byte(field))
+			dst, err = orderedKeyBytes(dst, value)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, 0x00), nil
+	}
+
+	// the other types are encoded on a fixed number of bytes,
+	// which depends on their type byte.
+	return encoding.EncodeValue(dst, v)
+}
+
+// appendEscapedBytes appends b to dst, escaping every 0x00 byte as 0x00 0xFF,
+// and terminates it with 0x00 0x00.
+func appendEscapedBytes(dst []byte, b []byte) []byte {
+	for _, c := range b {
+		if c == 0x00 {
+			dst = append(dst, 0x00, 0xFF)
+			continue
+		}
+		dst = append(dst, c)
 	}
 
-	return fmt.Sprintf("docs.TempTreeSort(%s)", op.Expr)
+	return append(dst, 0x00, 0x00)
 }
@@ -24,6 +24,7 @@ func TestTempTreeSort(t *testing.T) {
 		want     []types.Document
 		fails    bool
 		desc     bool
+		keys     []expr.SortKey
 	}{
 		{
 			"ASC",
@@ -40,6 +41,7 @@ func TestTempTreeSort(t *testing.T) {
 			},
 			false,
 			false,
+			nil,
 		},
 		{
 			"DESC",
@@ -56,6 +58,31 @@ func TestTempTreeSort(t *testing.T) {
 			},
 			false,
 			true,
+			nil,
+		},
+		{
+			"Multiple keys",
+			nil,
+			[]types.Document{
+				testutil.MakeDocument(t, `{"a": 0, "b": 1}`),
+				testutil.MakeDocument(t, `{"a": 1, "b": 1}`),
+				testutil.MakeDocument(t, `{"a": 0, "b": 2}`),
+				testutil.MakeDocument(t, `{"a": 0, "b": null}`),
+				testutil.MakeDocument(t, `{"a": null, "b": 3}`),
+			},
+			[]types.Document{
+				testutil.MakeDocument(t, `{"a": 1, "b": 1}`),
+				testutil.MakeDocument(t, `{"a": 0, "b": 1}`),
+				testutil.MakeDocument(t, `{"a": 0, "b": 2}`),
+				testutil.MakeDocument(t, `{"a": 0}`),
+				testutil.MakeDocument(t, `{"b": 3}`),
+			},
+			false,
+			false,
+			[]expr.SortKey{
+				{Expr: parser.MustParseExpr("a"), Desc: true},
+				{Expr: parser.MustParseExpr("b"), NullsLast: true},
+			},
 		},
 	}
 
@@ -64,7 +91,7 @@ func TestTempTreeSort(t *testing.T) {
 			db, tx, cleanup := testutil.NewTestTx(t)
 			defer cleanup()
 
-			testutil.MustExec(t, db, tx, "CREATE TABLE test(a int)")
+			testutil.MustExec(t, db, tx, "CREATE TABLE test(a int, b int)")
 
 			for _, doc := range test.values {
 				testutil.MustExec(t, db, tx, "INSERT INTO test VALUES ?", environment.Param{Value: doc})
@@ -76,7 +103,9 @@ func TestTempTreeSort(t *testing.T) {
 			env.Catalog = db.Catalog
 
 			s := stream.New(table.Scan("test"))
-			if test.desc {
+			if test.keys != nil {
+				s = s.Pipe(docs.TempTreeSortBy(test.keys...))
+			} else if test.desc {
 				s = s.Pipe(docs.TempTreeSortReverse(test.sortExpr))
 			} else {
 				s = s.Pipe(docs.TempTreeSort(test.sortExpr))
@@ -107,5 +136,14 @@ func TestTempTreeSort(t *testing.T) {
 
 	t.Run("String", func(t *testing.T) {
 		require.Equal(t, `docs.TempTreeSort(a)`, docs.TempTreeSort(parser.MustParseExpr("a")).String())
+		require.Equal(t, `docs.TempTreeSortReverse(a)`, docs.TempTreeSortReverse(parser.MustParseExpr("a")).String())
+		require.Equal(t, `docs.TempTreeSortReverse(a, b)`, docs.TempTreeSortBy(
+			expr.SortKey{Expr: parser.MustParseExpr("a"), Desc: true},
+			expr.SortKey{Expr: parser.MustParseExpr("b"), Desc: true},
+		).String())
+		require.Equal(t, `docs.TempTreeSort(a DESC, b NULLS LAST)`, docs.TempTreeSortBy(
+			expr.SortKey{Expr: parser.MustParseExpr("a"), Desc: true},
+			expr.SortKey{Expr: parser.MustParseExpr("b"), NullsLast: true},
+		).String())
 	})
 }
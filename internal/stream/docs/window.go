@@ -0,0 +1,306 @@
+package docs
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/join"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A WindowOperator computes window functions for every document of the stream.
+type WindowOperator struct {
+	stream.BaseOperator
+	// Windows must all share the same PARTITION BY and ORDER BY clauses.
+	Windows []*expr.Window
+}
+
+// Window computes the given window functions for every document of the stream, without collapsing them.
+// It assumes the stream is sorted by the PARTITION BY expressions, then by the ORDER BY keys of the windows.
+// The documents of each partition are buffered in memory, then returned in the same order,
+// with the values of the window functions stored in the environment.
+func Window(windows ...*expr.Window) *WindowOperator {
+	return &WindowOperator{Windows: windows}
+}
+
+// Iterate implements the Operator interface.
+func (op *WindowOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	if len(op.Windows) == 0 {
+		return errors.New("missing window function")
+	}
+
+	// documents with the same values for all the PARTITION BY expressions
+	// belong to the same partition.
+	var partitionBy expr.Expr
+	switch len(op.Windows[0].PartitionBy) {
+	case 0:
+	case 1:
+		partitionBy = op.Windows[0].PartitionBy[0]
+	default:
+		partitionBy = expr.LiteralExprList(op.Windows[0].PartitionBy)
+	}
+
+	var lastPartition types.Value
+	var rows []*environment.Environment
+
+	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
+		if partitionBy != nil {
+			partition, err := partitionBy.Eval(out)
+			if err != nil {
+				return err
+			}
+
+			if lastPartition != nil {
+				ok, err := types.IsEqual(lastPartition, partition)
+				if err != nil {
+					return err
+				}
+
+				// if the document is from a different partition, emit the documents of the previous one
+				if !ok {
+					err = op.flush(rows, fn)
+					if err != nil {
+						return err
+					}
+					rows = rows[:0]
+				}
+			}
+
+			lastPartition, err = document.CloneValue(partition)
+			if err != nil {
+				return err
+			}
+		}
+
+		row, err := cloneRow(in, out)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return op.flush(rows, fn)
+}
+
+// flush computes the window functions for every document of the partition and emits them.
+func (op *WindowOperator) flush(rows []*environment.Environment, fn func(out *environment.Environment) error) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	values := make([][]types.Value, len(op.Windows))
+	for i := range values {
+		values[i] = make([]types.Value, len(rows))
+	}
+
+	// documents with the same values for all the ORDER BY keys are peers.
+	peers, err := op.peers(rows)
+	if err != nil {
+		return err
+	}
+
+	for i, w := range op.Windows {
+		switch t := w.Func.(type) {
+		case expr.WindowFunction:
+			pos := expr.WindowPosition{Rows: rows}
+			for j := range rows {
+				pos.Index = j
+				pos.PeerIndex = peers[j].index
+				pos.PeerGroup = peers[j].group
+
+				values[i][j], err = t.EvalWindow(&pos)
+				if err != nil {
+					return err
+				}
+			}
+		case expr.AggregatorBuilder:
+			// aggregators are computed from the start of the partition
+			// to the last peer of the current document.
+			agg := t.Aggregator()
+			for j := 0; j < len(rows); {
+				k := j
+				for ; k < len(rows) && peers[k].group == peers[j].group; k++ {
+					err = agg.Aggregate(rows[k])
+					if err != nil {
+						return err
+					}
+				}
+
+				v, err := agg.Eval(rows[j])
+				if err != nil {
+					return err
+				}
+				for ; j < k; j++ {
+					values[i][j] = v
+				}
+			}
+		default:
+			return errors.Errorf("%s is not a window function", w.Func)
+		}
+	}
+
+	for j, row := range rows {
+		// keep the values computed by the previous window operators
+		fb := document.NewFieldBuffer()
+		if prev, ok := row.Get(environment.WindowsKey); ok && prev.Type() == types.DocumentValue {
+			err = fb.Copy(types.As[types.Document](prev))
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, w := range op.Windows {
+			fb.Add(w.String(), values[i][j])
+		}
+
+		var newEnv environment.Environment
+		newEnv.SetOuter(row)
+		newEnv.Set(environment.WindowsKey, types.NewDocumentValue(fb))
+
+		err = fn(&newEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type peer struct {
+	// position of the first peer of the document
+	index int
+	// number of distinct ORDER BY values before the ones of the document
+	group int
+}
+
+// peers returns the position of the first peer of each document,
+// and the group of peers it belongs to.
+// Without ORDER BY, all the documents of the partition are peers.
+func (op *WindowOperator) peers(rows []*environment.Environment) ([]peer, error) {
+	peers := make([]peer, len(rows))
+
+	orderBy := make(expr.LiteralExprList, 0, len(op.Windows[0].OrderBy))
+	for _, k := range op.Windows[0].OrderBy {
+		orderBy = append(orderBy, k.Expr)
+	}
+
+	var last types.Value
+	for i, row := range rows {
+		v, err := orderBy.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			last = v
+			continue
+		}
+
+		ok, err := types.IsEqual(last, v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			peers[i] = peers[i-1]
+			continue
+		}
+
+		peers[i] = peer{index: i, group: peers[i-1].group + 1}
+		last = v
+	}
+
+	return peers, nil
+}
+
+// cloneRow copies the document of out and the variables set by the operators
+// that produced it, so that it can be evaluated after the stream has moved on.
+func cloneRow(in, out *environment.Environment) (*environment.Environment, error) {
+	var row environment.Environment
+	row.SetOuter(in)
+
+	vars := document.NewFieldBuffer()
+	for e := out; e != nil && e != in; e = e.Outer {
+		if e.Vars != nil {
+			err := e.Vars.Iterate(func(field string, value types.Value) error {
+				// variables of the inner environments shadow those of the outer ones
+				if _, err := vars.GetByField(field); err == nil {
+					return nil
+				}
+
+				v, err := document.CloneValue(value)
+				if err != nil {
+					return err
+				}
+				vars.Add(field, v)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if row.Key == nil && e.Key != nil {
+			k := e.Key
+			if k.Encoded != nil {
+				k = tree.NewEncodedKey(append([]byte{}, k.Encoded...))
+			}
+			row.SetKey(k)
+		}
+
+		if row.Doc == nil && e.Doc != nil {
+			doc, err := cloneDocument(e.Doc)
+			if err != nil {
+				return nil, err
+			}
+			row.SetDocument(doc)
+		}
+	}
+	row.Vars = vars
+
+	return &row, nil
+}
+
+func cloneDocument(d types.Document) (types.Document, error) {
+	// joined documents must keep their type to be able to resolve unqualified paths
+	if jd, ok := d.(*join.Document); ok {
+		values := make([]types.Value, len(jd.Values))
+		for i := range jd.Values {
+			v, err := document.CloneValue(jd.Values[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+
+		return join.NewDocument(append([]string{}, jd.Names...), values), nil
+	}
+
+	fb := document.NewFieldBuffer()
+	err := fb.Copy(d)
+	return fb, err
+}
+
+func (op *WindowOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("docs.Window(")
+	for i, w := range op.Windows {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(w.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
@@ -0,0 +1,138 @@
+package docs_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow(t *testing.T) {
+	rowNumber := &expr.Window{Func: &functions.RowNumber{}}
+	partitioned := func(fn expr.Expr) *expr.Window {
+		return &expr.Window{
+			Func:        fn,
+			PartitionBy: []expr.Expr{parser.MustParseExpr("a % 2")},
+			OrderBy:     []expr.SortKey{{Expr: parser.MustParseExpr("a")}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		windows []*expr.Window
+		in      []types.Document
+		want    []string
+	}{
+		{
+			"row number",
+			[]*expr.Window{rowNumber},
+			generateSeqDocs(t, 3),
+			[]string{
+				`{"a": 0, "n": 1}`,
+				`{"a": 1, "n": 2}`,
+				`{"a": 2, "n": 3}`,
+			},
+		},
+		{
+			"partitions",
+			[]*expr.Window{partitioned(&functions.RowNumber{})},
+			generateSeqDocs(t, 4),
+			[]string{
+				`{"a": 0, "n": 1}`,
+				`{"a": 2, "n": 2}`,
+				`{"a": 1, "n": 1}`,
+				`{"a": 3, "n": 2}`,
+			},
+		},
+		{
+			"running sum",
+			[]*expr.Window{partitioned(&functions.Sum{Expr: parser.MustParseExpr("a")})},
+			generateSeqDocs(t, 5),
+			[]string{
+				`{"a": 0, "n": 0}`,
+				`{"a": 2, "n": 2}`,
+				`{"a": 4, "n": 6}`,
+				`{"a": 1, "n": 1}`,
+				`{"a": 3, "n": 4}`,
+			},
+		},
+		{
+			"lag",
+			[]*expr.Window{partitioned(&functions.Lag{Expr: parser.MustParseExpr("a")})},
+			generateSeqDocs(t, 4),
+			[]string{
+				`{"a": 0, "n": null}`,
+				`{"a": 2, "n": 0}`,
+				`{"a": 1, "n": null}`,
+				`{"a": 3, "n": 1}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			testutil.MustExec(t, db, tx, "CREATE TABLE test(a int)")
+
+			for _, doc := range test.in {
+				testutil.MustExec(t, db, tx, "INSERT INTO test VALUES ?", environment.Param{Value: doc})
+			}
+
+			var env environment.Environment
+			env.DB = db
+			env.Tx = tx
+			env.Catalog = db.Catalog
+
+			w := test.windows[0]
+			var keys []expr.SortKey
+			for _, e := range w.PartitionBy {
+				keys = append(keys, expr.SortKey{Expr: e})
+			}
+			keys = append(keys, w.OrderBy...)
+
+			s := stream.New(table.Scan("test"))
+			if len(keys) > 0 {
+				s = s.Pipe(docs.TempTreeSortBy(keys...))
+			}
+			s = s.Pipe(docs.Window(test.windows...))
+			s = s.Pipe(docs.Project(
+				testutil.ParseNamedExpr(t, "a"),
+				&expr.NamedExpr{ExprName: "n", Expr: w},
+			))
+
+			var got []types.Document
+			err := s.Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+				var fb document.FieldBuffer
+				err := fb.Copy(d)
+				assert.NoError(t, err)
+				got = append(got, &fb)
+				return nil
+			})
+			assert.NoError(t, err)
+
+			require.Len(t, got, len(test.want))
+			for i := range test.want {
+				testutil.RequireDocJSONEq(t, got[i], test.want[i])
+			}
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, `docs.Window(ROW_NUMBER() OVER (PARTITION BY a % 2 ORDER BY a), SUM(a) OVER (PARTITION BY a % 2 ORDER BY a))`,
+			docs.Window(partitioned(&functions.RowNumber{}), partitioned(&functions.Sum{Expr: parser.MustParseExpr("a")})).String())
+	})
+}
@@ -0,0 +1,204 @@
+package index
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A CoveringScanOperator iterates over the entries of an index and builds
+// documents from the indexed values and the primary key, without reading the table.
+// The documents only contain the indexed paths and the paths of the primary key,
+// it must only be used when the rest of the stream doesn't need any other path.
+type CoveringScanOperator struct {
+	stream.BaseOperator
+
+	// IndexName references the index that will be used to perform the scan
+	IndexName string
+	// Alias is the name under which the documents can be referenced
+	// by qualified paths. If empty, the table name is used.
+	Alias string
+	// Ranges defines the boundaries of the scan, each corresponding to one value of the group of values
+	// being indexed in the case of a composite index.
+	Ranges stream.Ranges
+	// Reverse indicates the direction used to traverse the index.
+	Reverse bool
+}
+
+// CoveringScan creates an iterator that builds documents from the entries of the given index.
+func CoveringScan(name string, ranges ...stream.Range) *CoveringScanOperator {
+	return &CoveringScanOperator{IndexName: name, Ranges: ranges}
+}
+
+// CoveringScanReverse creates an iterator that builds documents from the entries of the given index in reverse order.
+func CoveringScanReverse(name string, ranges ...stream.Range) *CoveringScanOperator {
+	return &CoveringScanOperator{IndexName: name, Ranges: ranges, Reverse: true}
+}
+
+// Iterate over the entries of the index. Each document is stored in the environment
+// that is passed to the fn function, using SetCurrentValue.
+func (it *CoveringScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	catalog := in.GetCatalog()
+	tx := in.GetTx()
+
+	index, err := catalog.GetIndex(tx, it.IndexName)
+	if err != nil {
+		return err
+	}
+
+	info, err := catalog.GetIndexInfo(it.IndexName)
+	if err != nil {
+		return err
+	}
+	if info.Multikey {
+		return errors.Errorf("cannot read documents from multikey index %q", it.IndexName)
+	}
+
+	table, err := catalog.GetTable(tx, info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+
+	pk := table.Info.GetPrimaryKey()
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(table.Info.TableName))
+	// documents can be referenced by the table name or by its alias
+	name := it.Alias
+	if name == "" {
+		name = table.Info.TableName
+	}
+	newEnv.Set(environment.AliasKey, types.NewTextValue(name))
+	alias := document.Path{document.PathFragment{FieldName: name}}
+
+	iterator := func(values []types.Value, key *tree.Key) error {
+		var fb document.FieldBuffer
+
+		for i, v := range values {
+			// paths missing from the document are indexed as NULL,
+			// they are left out to be evaluated like missing fields.
+			if v.Type() == types.NullValue {
+				continue
+			}
+
+			err := setCoveredValue(&fb, info.Paths[i], v)
+			if err != nil {
+				return err
+			}
+		}
+
+		if pk != nil {
+			vs, err := key.Decode()
+			if err != nil {
+				return err
+			}
+
+			for i, v := range vs {
+				if !pk.Types[i].IsAny() {
+					v, err = document.CastAs(v, pk.Types[i])
+					if err != nil {
+						return err
+					}
+				}
+
+				err = setCoveredValue(&fb, pk.Paths[i], v)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		newEnv.SetDocument(&fb)
+		newEnv.Set(alias, types.NewDocumentValue(&fb))
+		newEnv.SetKey(key)
+
+		return fn(&newEnv)
+	}
+
+	if len(it.Ranges) == 0 {
+		return index.IterateValuesOnRange(nil, it.Reverse, iterator)
+	}
+
+	ranges, err := it.Ranges.Eval(in)
+	if err != nil || len(ranges) != len(it.Ranges) {
+		return err
+	}
+
+	for _, rng := range ranges {
+		r, err := rng.ToTreeRange(&table.Info.FieldConstraints, info.Paths)
+		if err != nil {
+			return err
+		}
+
+		err = index.IterateValuesOnRange(r, it.Reverse, iterator)
+		if errors.Is(err, stream.ErrStreamClosed) {
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setCoveredValue sets v at the given path, creating the intermediate documents if necessary.
+// The path must only contain field names.
+func setCoveredValue(fb *document.FieldBuffer, p document.Path, v types.Value) error {
+	if len(p) == 1 {
+		return fb.Set(p, v)
+	}
+
+	var nested *document.FieldBuffer
+	cur, err := fb.GetByField(p[0].FieldName)
+	switch {
+	case err == nil && cur.Type() == types.DocumentValue:
+		nested, _ = types.As[types.Document](cur).(*document.FieldBuffer)
+	case err != nil && !errors.Is(err, types.ErrFieldNotFound):
+		return err
+	}
+
+	if nested == nil {
+		nested = document.NewFieldBuffer()
+		err = fb.Set(p[:1], types.NewDocumentValue(nested))
+		if err != nil {
+			return err
+		}
+	}
+
+	return setCoveredValue(nested, p[1:], v)
+}
+
+func (it *CoveringScanOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("index.CoveringScan")
+	if it.Reverse {
+		s.WriteString("Reverse")
+	}
+
+	s.WriteRune('(')
+
+	s.WriteString(strconv.Quote(it.IndexName))
+	if it.Alias != "" {
+		s.WriteString(" AS ")
+		s.WriteString(it.Alias)
+	}
+	if len(it.Ranges) > 0 {
+		s.WriteString(", [")
+		s.WriteString(it.Ranges.String())
+		s.WriteString("]")
+	}
+
+	s.WriteString(")")
+
+	return s.String()
+}
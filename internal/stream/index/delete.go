@@ -6,7 +6,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // DeleteOperator reads the input stream and deletes the document from the specified index.
@@ -57,20 +56,18 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return err
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(old)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
-		}
-
-		err = idx.Delete(vs, key.Encoded)
+		values, err := indexedValues(tx, info, old)
 		if err != nil {
 			return err
 		}
 
+		for _, vs := range values {
+			err = idx.Delete(vs, key.Encoded)
+			if err != nil {
+				return err
+			}
+		}
+
 		return fn(out)
 	})
 }
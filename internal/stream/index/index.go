@@ -1 +1,56 @@
 package index
+
+import (
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/types"
+)
+
+// indexedValues returns the list of values to store in the index for the given document.
+// Paths that don't exist in the document are indexed as NULL.
+// Multikey indexes get one list per distinct element of the indexed array, except NULL,
+// since they are only used to evaluate x IN array, which is never true for NULL elements.
+// Values that are not arrays are not indexed by multikey indexes.
+// Partial indexes don't store the documents that don't match their predicate.
+func indexedValues(tx *database.Transaction, info *database.IndexInfo, d types.Document) ([][]types.Value, error) {
+	ok, err := info.Includes(tx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	vs := make([]types.Value, 0, len(info.Paths))
+	for _, path := range info.Paths {
+		v, err := path.GetValueFromDocument(d)
+		if err != nil {
+			v = types.NewNullValue()
+		}
+		vs = append(vs, v)
+	}
+
+	if !info.Multikey {
+		return [][]types.Value{vs}, nil
+	}
+
+	if vs[0].Type() != types.ArrayValue {
+		return nil, nil
+	}
+
+	var values [][]types.Value
+	err = types.As[types.Array](vs[0]).Iterate(func(i int, v types.Value) error {
+		if v.Type() == types.NullValue {
+			return nil
+		}
+
+		// the same element must only be indexed once
+		for _, other := range values {
+			ok, err := types.IsEqual(other[0], v)
+			if err != nil || ok {
+				return err
+			}
+		}
+
+		values = append(values, []types.Value{v})
+		return nil
+	})
+
+	return values, err
+}
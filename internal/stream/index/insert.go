@@ -6,7 +6,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // InsertOperator reads the input stream and indexes each document.
@@ -47,18 +46,16 @@ func (op *InsertOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return errors.New("missing document key")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(d)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
+		values, err := indexedValues(tx, info, d)
+		if err != nil {
+			return err
 		}
 
-		err = idx.Set(vs, key.Encoded)
-		if err != nil {
-			return fmt.Errorf("error while inserting index value: %w", err)
+		for _, vs := range values {
+			err = idx.Set(vs, key.Encoded)
+			if err != nil {
+				return fmt.Errorf("error while inserting index value: %w", err)
+			}
 		}
 
 		return fn(out)
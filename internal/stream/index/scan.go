@@ -6,6 +6,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
@@ -19,6 +20,9 @@ type ScanOperator struct {
 
 	// IndexName references the index that will be used to perform the scan
 	IndexName string
+	// Alias is the name under which the documents can be referenced
+	// by qualified paths. If empty, the table name is used.
+	Alias string
 	// Ranges defines the boundaries of the scan, each corresponding to one value of the group of values
 	// being indexed in the case of a composite index.
 	Ranges stream.Ranges
@@ -60,11 +64,19 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 	var newEnv environment.Environment
 	newEnv.SetOuter(in)
 	newEnv.Set(environment.TableKey, types.NewTextValue(table.Info.TableName))
+	// documents can be referenced by the table name or by its alias
+	name := it.Alias
+	if name == "" {
+		name = table.Info.TableName
+	}
+	newEnv.Set(environment.AliasKey, types.NewTextValue(name))
+	alias := document.Path{document.PathFragment{FieldName: name}}
 
 	ptr := DocumentPointer{
 		Table: table,
 	}
 	newEnv.SetDocument(&ptr)
+	newEnv.Set(alias, types.NewDocumentValue(&ptr))
 
 	if len(it.Ranges) == 0 {
 		return index.IterateOnRange(nil, it.Reverse, func(key *tree.Key) error {
@@ -81,8 +93,14 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 		return err
 	}
 
+	// multikey indexes store the elements of the array
+	paths := info.Paths
+	if info.Multikey {
+		paths = []document.Path{paths[0].ExtendIndex(0)}
+	}
+
 	for _, rng := range ranges {
-		r, err := rng.ToTreeRange(&table.Info.FieldConstraints, info.Paths)
+		r, err := rng.ToTreeRange(&table.Info.FieldConstraints, paths)
 		if err != nil {
 			return err
 		}
@@ -116,6 +134,10 @@ func (it *ScanOperator) String() string {
 	s.WriteRune('(')
 
 	s.WriteString(strconv.Quote(it.IndexName))
+	if it.Alias != "" {
+		s.WriteString(" AS ")
+		s.WriteString(it.Alias)
+	}
 	if len(it.Ranges) > 0 {
 		s.WriteString(", [")
 		s.WriteString(it.Ranges.String())
@@ -0,0 +1,123 @@
+package index
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A UnionOperator reads the keys of the documents of a table from multiple scans,
+// deduplicates them and returns the documents in the order of the primary key.
+// It is used to read the documents matching any of the operands of an OR expression
+// from different indexes.
+type UnionOperator struct {
+	stream.BaseOperator
+
+	TableName string
+	// Alias is the name under which the documents can be referenced
+	// by qualified paths. If empty, the table name is used.
+	Alias string
+	// Scans are index.Scan or table.Scan operators reading from the table.
+	Scans []stream.Operator
+}
+
+// Union creates an operator that returns the documents of the table
+// returned by any of the given scans, only once.
+func Union(tableName string, scans ...stream.Operator) *UnionOperator {
+	return &UnionOperator{TableName: tableName, Scans: scans}
+}
+
+// Iterate stores the keys returned by the scans in a temporary tree,
+// then iterates over it to fetch the documents.
+func (it *UnionOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	catalog := in.GetCatalog()
+	tx := in.GetTx()
+
+	table, err := catalog.GetTable(tx, it.TableName)
+	if err != nil {
+		return err
+	}
+
+	tns := catalog.GetFreeTransientNamespace()
+	temp, cleanup, err := tree.NewTransient(in.GetDB().Store.NewTransientSession(), tns)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := cleanup()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	for _, s := range it.Scans {
+		err = s.Iterate(in, func(out *environment.Environment) error {
+			key, ok := out.GetKey()
+			if !ok {
+				return errors.New("missing key")
+			}
+
+			// keys are stored encoded to be returned in the order of the table
+			return temp.Put(tree.NewKey(types.NewBlobValue(key.Encoded)), nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(it.TableName))
+	// documents can be referenced by the table name or by its alias
+	name := it.Alias
+	if name == "" {
+		name = it.TableName
+	}
+	newEnv.Set(environment.AliasKey, types.NewTextValue(name))
+	alias := document.Path{document.PathFragment{FieldName: name}}
+
+	ptr := DocumentPointer{
+		Table: table,
+	}
+	newEnv.SetDocument(&ptr)
+	newEnv.Set(alias, types.NewDocumentValue(&ptr))
+
+	return temp.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		values, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		// the key is copied as the buffer of the iterator is reused
+		key := tree.NewEncodedKey(append([]byte(nil), types.As[[]byte](values[0])...))
+		ptr.key = key
+		ptr.Doc = nil
+		newEnv.SetKey(key)
+
+		return fn(&newEnv)
+	})
+}
+
+func (it *UnionOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("index.Union(")
+	s.WriteString(strconv.Quote(it.TableName))
+	if it.Alias != "" && it.Alias != it.TableName {
+		s.WriteString(" AS ")
+		s.WriteString(it.Alias)
+	}
+	for _, op := range it.Scans {
+		s.WriteString(", ")
+		s.WriteString(op.String())
+	}
+	s.WriteString(")")
+
+	return s.String()
+}
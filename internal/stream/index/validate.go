@@ -6,7 +6,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/lock"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
 )
 
@@ -47,25 +49,37 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return errors.New("missing document")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
+		values, err := indexedValues(tx, info, doc)
+		if err != nil {
+			return err
+		}
 
-		// if the indexes values contain NULL somewhere,
-		// we don't check for unicity.
-		// cf: https://sqlite.org/lang_createindex.html#unique_indexes
-		var hasNull bool
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(doc)
-			if err != nil {
-				hasNull = true
-				v = types.NewNullValue()
-			} else if v.Type() == types.NullValue {
-				hasNull = true
+		for _, vs := range values {
+			// if the indexes values contain NULL somewhere,
+			// we don't check for unicity.
+			// cf: https://sqlite.org/lang_createindex.html#unique_indexes
+			var hasNull bool
+			for _, v := range vs {
+				if v.Type() == types.NullValue {
+					hasNull = true
+					break
+				}
+			}
+			if hasNull {
+				continue
 			}
 
-			vs = append(vs, v)
-		}
+			// lock the values until the end of the transaction
+			// to prevent concurrent transactions from inserting them
+			k, err := tree.NewKey(vs...).Encode(idx.Tree.Namespace)
+			if err != nil {
+				return err
+			}
+			err = catalog.LockDocument(tx, op.indexName, k, lock.X)
+			if err != nil {
+				return err
+			}
 
-		if !hasNull {
 			duplicate, key, err := idx.Exists(vs)
 			if err != nil {
 				return err
@@ -0,0 +1,103 @@
+package join
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// A Document is the result of joining documents coming from multiple tables.
+// Each document is stored under the name of its table, or its alias.
+// When a table doesn't produce any document, like with a LEFT JOIN,
+// the corresponding value is NULL.
+// Fields that don't match any table name are looked up in every joined document.
+// It implements the types.Document interface.
+type Document struct {
+	Names  []string
+	Values []types.Value
+}
+
+// NewDocument creates a document from a list of table names and their documents.
+func NewDocument(names []string, values []types.Value) *Document {
+	return &Document{Names: names, Values: values}
+}
+
+// FromDocument rebuilds a joined document from a document
+// whose fields contain the joined documents, like the one
+// produced by encoding a joined document.
+func FromDocument(d types.Document) (*Document, error) {
+	var jd Document
+
+	err := d.Iterate(func(field string, value types.Value) error {
+		jd.Names = append(jd.Names, field)
+		jd.Values = append(jd.Values, value)
+		return nil
+	})
+
+	return &jd, err
+}
+
+// With returns a new document containing the documents of d
+// and the given document stored under name.
+func (d *Document) With(name string, v types.Value) *Document {
+	names := make([]string, 0, len(d.Names)+1)
+	names = append(names, d.Names...)
+	values := make([]types.Value, 0, len(d.Values)+1)
+	values = append(values, d.Values...)
+
+	return NewDocument(append(names, name), append(values, v))
+}
+
+// Iterate over the joined documents, by table name.
+func (d *Document) Iterate(fn func(field string, value types.Value) error) error {
+	for i := range d.Names {
+		err := fn(d.Names[i], d.Values[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByField returns the document of the given table. If field is not the name
+// of a table, it returns the field of the only joined document that contains it.
+// It returns an error if more than one document contains that field.
+func (d *Document) GetByField(field string) (types.Value, error) {
+	for i := range d.Names {
+		if d.Names[i] == field {
+			return d.Values[i], nil
+		}
+	}
+
+	var found types.Value
+	for _, v := range d.Values {
+		if v.Type() != types.DocumentValue {
+			continue
+		}
+
+		fv, err := types.As[types.Document](v).GetByField(field)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if found != nil {
+			return nil, errors.Errorf("field %q is ambiguous", field)
+		}
+		found = fv
+	}
+
+	if found == nil {
+		return nil, types.ErrFieldNotFound
+	}
+
+	return found, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	return document.MarshalJSON(d)
+}
@@ -0,0 +1,265 @@
+package join
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/types"
+)
+
+// A NestedLoopOperator joins every document of the stream with every document
+// of another stream, and outputs the pairs that satisfy the join condition.
+type NestedLoopOperator struct {
+	stream.BaseOperator
+	// Right is the stream whose documents are joined with each incoming document.
+	Right *stream.Stream
+	// Alias is the name under which the documents of the right stream are stored
+	// in the joined document.
+	Alias string
+	// On is the join condition. If nil, every pair of documents is returned.
+	On expr.Expr
+	// Outer indicates that incoming documents without any match must still be
+	// returned, joined with NULL, like with a LEFT JOIN.
+	Outer bool
+}
+
+// NestedLoop creates an operator that iterates over the right stream for each incoming document
+// and outputs the joined documents that satisfy the on condition.
+func NestedLoop(right *stream.Stream, alias string, on expr.Expr) *NestedLoopOperator {
+	return &NestedLoopOperator{Right: right, Alias: alias, On: on}
+}
+
+// LeftNestedLoop does the same as NestedLoop but also outputs incoming documents
+// that don't match any document of the right stream.
+func LeftNestedLoop(right *stream.Stream, alias string, on expr.Expr) *NestedLoopOperator {
+	return &NestedLoopOperator{Right: right, Alias: alias, On: on, Outer: true}
+}
+
+// Iterate implements the Operator interface.
+func (op *NestedLoopOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var j joiner
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		return j.join(out, streamValues(out, op.Right), op.Alias, op.On, op.Outer, fn)
+	})
+}
+
+func (op *NestedLoopOperator) String() string {
+	if op.Outer {
+		return joinString("join.LeftNestedLoop", op.Right.String(), op.On)
+	}
+
+	return joinString("join.NestedLoop", op.Right.String(), op.On)
+}
+
+// An IndexLookupOperator joins every document of the stream with the documents of a table
+// whose indexed value, or primary key, equals the evaluation of an expression.
+type IndexLookupOperator struct {
+	stream.BaseOperator
+	TableName string
+	// Alias is the name under which the documents of the table are stored
+	// in the joined document.
+	Alias string
+	// IndexName is the name of the index used to look up the documents.
+	// If empty, the primary key of the table is used.
+	IndexName string
+	// Key is evaluated for each incoming document and looked up in the index.
+	Key expr.Expr
+	// On is the join condition. It is evaluated on each document returned
+	// by the lookup.
+	On expr.Expr
+	// Outer indicates that incoming documents without any match must still be
+	// returned, joined with NULL, like with a LEFT JOIN.
+	Outer bool
+}
+
+// IndexLookup creates an operator that looks up the documents of a table using the given index
+// and the value of key, for each incoming document, and outputs the joined documents that satisfy the on condition.
+// If indexName is empty, the primary key of the table is used.
+func IndexLookup(tableName, alias, indexName string, key, on expr.Expr) *IndexLookupOperator {
+	return &IndexLookupOperator{TableName: tableName, Alias: alias, IndexName: indexName, Key: key, On: on}
+}
+
+// LeftIndexLookup does the same as IndexLookup but also outputs incoming documents
+// that don't match any document of the table.
+func LeftIndexLookup(tableName, alias, indexName string, key, on expr.Expr) *IndexLookupOperator {
+	return &IndexLookupOperator{TableName: tableName, Alias: alias, IndexName: indexName, Key: key, On: on, Outer: true}
+}
+
+// Iterate implements the Operator interface.
+func (op *IndexLookupOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var j joiner
+
+	// the key is evaluated by the range, using the incoming environment
+	rng := stream.Range{Min: expr.LiteralExprList{op.Key}, Exact: true}
+
+	var lookup stream.Operator
+	if op.IndexName == "" {
+		s := table.Scan(op.TableName, rng)
+		s.Alias = op.Alias
+		lookup = s
+	} else {
+		s := index.Scan(op.IndexName, rng)
+		s.Alias = op.Alias
+		lookup = s
+	}
+	right := stream.New(lookup)
+	empty := new(stream.Stream)
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		v, err := op.Key.Eval(out)
+		if err != nil {
+			return err
+		}
+
+		// NULL never equals anything
+		if v.Type() == types.NullValue {
+			return j.join(out, streamValues(out, empty), op.Alias, op.On, op.Outer, fn)
+		}
+
+		return j.join(out, streamValues(out, right), op.Alias, op.On, op.Outer, fn)
+	})
+}
+
+func (op *IndexLookupOperator) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(strconv.Quote(op.TableName))
+	if op.Alias != "" && op.Alias != op.TableName {
+		sb.WriteString(" AS ")
+		sb.WriteString(op.Alias)
+	}
+	sb.WriteString(", ")
+	if op.IndexName != "" {
+		sb.WriteString(strconv.Quote(op.IndexName))
+	} else {
+		sb.WriteString("pk")
+	}
+	sb.WriteString(", ")
+	sb.WriteString(op.Key.String())
+
+	if op.Outer {
+		return joinString("join.LeftIndexLookup", sb.String(), op.On)
+	}
+
+	return joinString("join.IndexLookup", sb.String(), op.On)
+}
+
+func joinString(name string, right string, on expr.Expr) string {
+	var sb strings.Builder
+
+	sb.WriteString(name)
+	sb.WriteRune('(')
+	sb.WriteString(right)
+	if on != nil {
+		sb.WriteString(", ")
+		sb.WriteString(fmt.Sprint(on))
+	}
+	sb.WriteRune(')')
+
+	return sb.String()
+}
+
+// streamValues returns a function that iterates over the documents of a stream.
+func streamValues(in *environment.Environment, s *stream.Stream) func(fn func(v types.Value) error) error {
+	return func(fn func(v types.Value) error) error {
+		return s.Iterate(in, func(out *environment.Environment) error {
+			d, ok := out.GetDocument()
+			if !ok {
+				return errors.New("missing document")
+			}
+
+			return fn(types.NewDocumentValue(d))
+		})
+	}
+}
+
+// joiner joins an incoming document with the values returned by an iterator,
+// usually the documents of a stream.
+type joiner struct {
+	env environment.Environment
+}
+
+func (j *joiner) join(out *environment.Environment, right func(fn func(v types.Value) error) error, alias string, on expr.Expr, outer bool, fn func(out *environment.Environment) error) error {
+	left, err := leftDocument(out)
+	if err != nil {
+		return err
+	}
+
+	aliasPath := document.Path{document.PathFragment{FieldName: alias}}
+
+	j.env.SetOuter(out)
+
+	var matched, closed bool
+	err = right(func(v types.Value) error {
+		j.env.SetDocument(left.With(alias, v))
+		j.env.Set(aliasPath, v)
+
+		if on != nil {
+			ok, err := isTruthy(on, &j.env)
+			if err != nil || !ok {
+				return err
+			}
+		}
+
+		matched = true
+		err := fn(&j.env)
+		if errors.Is(err, stream.ErrStreamClosed) {
+			closed = true
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if closed {
+		return stream.ErrStreamClosed
+	}
+
+	if matched || !outer {
+		return nil
+	}
+
+	v := types.NewNullValue()
+	j.env.SetDocument(left.With(alias, v))
+	j.env.Set(aliasPath, v)
+
+	return fn(&j.env)
+}
+
+func isTruthy(e expr.Expr, env *environment.Environment) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	return types.IsTruthy(v)
+}
+
+// leftDocument returns the incoming document as a joined document.
+// If it's not already the result of a join, it is stored under the name of its table.
+func leftDocument(env *environment.Environment) (*Document, error) {
+	d, ok := env.GetDocument()
+	if !ok {
+		return nil, errors.New("missing document")
+	}
+
+	if jd, ok := d.(*Document); ok {
+		return jd, nil
+	}
+
+	name, ok := env.Get(environment.AliasKey)
+	if !ok || name.Type() != types.TextValue {
+		return nil, errors.New("cannot join a document that doesn't belong to a table")
+	}
+
+	return NewDocument([]string{types.As[string](name)}, []types.Value{types.NewDocumentValue(d)}), nil
+}
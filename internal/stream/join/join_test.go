@@ -0,0 +1,184 @@
+package join_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/join"
+	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       stream.Operator
+		expected testutil.Docs
+	}{
+		{
+			"nested loop",
+			join.NestedLoop(stream.New(table.Scan("b")), "b", parser.MustParseExpr("a.x = b.y")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 2, "y": 10}}`,
+			),
+		},
+		{
+			"nested loop/no condition",
+			join.NestedLoop(stream.New(table.Scan("b")), "b", nil),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 2, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 3, "y": 30}}`,
+				`{"a": {"id": 2, "x": 20}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 2, "x": 20}, "b": {"id": 2, "y": 10}}`,
+				`{"a": {"id": 2, "x": 20}, "b": {"id": 3, "y": 30}}`,
+			),
+		},
+		{
+			"left nested loop",
+			join.LeftNestedLoop(stream.New(table.Scan("b")), "b", parser.MustParseExpr("a.x = b.y")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 2, "y": 10}}`,
+				`{"a": {"id": 2, "x": 20}, "b": null}`,
+			),
+		},
+		{
+			"index lookup",
+			join.IndexLookup("b", "b", "b_y_idx", parser.MustParseExpr("a.x"), parser.MustParseExpr("a.x = b.y")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 2, "y": 10}}`,
+			),
+		},
+		{
+			"index lookup/pk",
+			join.IndexLookup("b", "b", "", parser.MustParseExpr("a.id"), parser.MustParseExpr("a.id = b.id")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 2, "x": 20}, "b": {"id": 2, "y": 10}}`,
+			),
+		},
+		{
+			"left index lookup",
+			join.LeftIndexLookup("b", "b", "b_y_idx", parser.MustParseExpr("a.x"), parser.MustParseExpr("a.x = b.y")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 1, "y": 10}}`,
+				`{"a": {"id": 1, "x": 10}, "b": {"id": 2, "y": 10}}`,
+				`{"a": {"id": 2, "x": 20}, "b": null}`,
+			),
+		},
+		{
+			"unnest",
+			join.Unnest(parser.MustParseExpr("[a.id, a.x]"), "v", nil),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "v": 1}`,
+				`{"a": {"id": 1, "x": 10}, "v": 10}`,
+				`{"a": {"id": 2, "x": 20}, "v": 2}`,
+				`{"a": {"id": 2, "x": 20}, "v": 20}`,
+			),
+		},
+		{
+			"unnest/condition",
+			join.Unnest(parser.MustParseExpr("[a.id, a.x]"), "v", parser.MustParseExpr("v > 5")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "v": 10}`,
+				`{"a": {"id": 2, "x": 20}, "v": 20}`,
+			),
+		},
+		{
+			"left unnest",
+			join.LeftUnnest(parser.MustParseExpr("[a.x]"), "v", parser.MustParseExpr("v = 10")),
+			testutil.MakeDocuments(t,
+				`{"a": {"id": 1, "x": 10}, "v": 10}`,
+				`{"a": {"id": 2, "x": 20}, "v": null}`,
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			testutil.MustExec(t, db, tx, `
+				CREATE TABLE a (id INTEGER PRIMARY KEY, x INTEGER);
+				CREATE TABLE b (id INTEGER PRIMARY KEY, y INTEGER);
+				CREATE INDEX b_y_idx ON b(y);
+				INSERT INTO a (id, x) VALUES (1, 10), (2, 20);
+				INSERT INTO b (id, y) VALUES (1, 10), (2, 10), (3, 30);
+			`)
+
+			s := stream.New(table.Scan("a")).Pipe(test.op)
+
+			var env environment.Environment
+			env.Tx = tx
+			env.Catalog = db.Catalog
+
+			var got testutil.Docs
+			err := s.Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				var fb document.FieldBuffer
+				err := fb.Copy(d)
+				assert.NoError(t, err)
+
+				got = append(got, &fb)
+				return nil
+			})
+			assert.NoError(t, err)
+			test.expected.RequireEqual(t, got)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, `join.NestedLoop(table.Scan("b"), a.x = b.y)`,
+			join.NestedLoop(stream.New(table.Scan("b")), "b", parser.MustParseExpr("a.x = b.y")).String())
+		require.Equal(t, `join.LeftNestedLoop(table.Scan("b"))`,
+			join.LeftNestedLoop(stream.New(table.Scan("b")), "b", nil).String())
+		require.Equal(t, `join.IndexLookup("b" AS c, "b_y_idx", a.x, a.x = c.y)`,
+			join.IndexLookup("b", "c", "b_y_idx", parser.MustParseExpr("a.x"), parser.MustParseExpr("a.x = c.y")).String())
+		require.Equal(t, `join.Unnest(a.items AS i)`,
+			join.Unnest(parser.MustParseExpr("a.items"), "i", nil).String())
+		require.Equal(t, `join.LeftUnnest(a.items AS i, i > 1)`,
+			join.LeftUnnest(parser.MustParseExpr("a.items"), "i", parser.MustParseExpr("i > 1")).String())
+		require.Equal(t, `join.LeftIndexLookup("b", pk, a.x, a.x = b.id)`,
+			join.LeftIndexLookup("b", "b", "", parser.MustParseExpr("a.x"), parser.MustParseExpr("a.x = b.id")).String())
+	})
+}
+
+func TestDocument(t *testing.T) {
+	d := join.NewDocument([]string{"a", "b"}, []types.Value{
+		types.NewDocumentValue(testutil.MakeDocument(t, `{"x": 1, "y": 2}`)),
+		types.NewDocumentValue(testutil.MakeDocument(t, `{"y": 3, "z": 4}`)),
+	})
+
+	v, err := d.GetByField("x")
+	assert.NoError(t, err)
+	require.Equal(t, types.NewIntegerValue(1), v)
+
+	v, err = d.GetByField("z")
+	assert.NoError(t, err)
+	require.Equal(t, types.NewIntegerValue(4), v)
+
+	_, err = d.GetByField("y")
+	assert.Error(t, err)
+
+	_, err = d.GetByField("w")
+	assert.ErrorIs(t, err, types.ErrFieldNotFound)
+
+	v, err = expr.Path(document.NewPath("b", "y")).Eval(environment.New(d))
+	assert.NoError(t, err)
+	require.Equal(t, types.NewIntegerValue(3), v)
+
+	testutil.RequireDocJSONEq(t, d, `{"a": {"x": 1, "y": 2}, "b": {"y": 3, "z": 4}}`)
+}
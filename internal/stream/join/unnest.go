@@ -0,0 +1,78 @@
+package join
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// An UnnestOperator joins every document of the stream with each value
+// of an array computed from that document.
+type UnnestOperator struct {
+	stream.BaseOperator
+	// Expr is evaluated for each incoming document and must return an array.
+	Expr expr.Expr
+	// Alias is the name under which the values of the array are stored
+	// in the joined document.
+	Alias string
+	// On is the join condition. If nil, every value of the array is returned.
+	On expr.Expr
+	// Outer indicates that incoming documents whose array is empty or NULL,
+	// or whose values don't match the join condition, must still be
+	// returned, joined with NULL, like with a LEFT JOIN.
+	Outer bool
+}
+
+// Unnest creates an operator that evaluates e for each incoming document
+// and outputs the document joined with each value of the resulting array
+// that satisfies the on condition.
+func Unnest(e expr.Expr, alias string, on expr.Expr) *UnnestOperator {
+	return &UnnestOperator{Expr: e, Alias: alias, On: on}
+}
+
+// LeftUnnest does the same as Unnest but also outputs incoming documents
+// that don't match any value of the array.
+func LeftUnnest(e expr.Expr, alias string, on expr.Expr) *UnnestOperator {
+	return &UnnestOperator{Expr: e, Alias: alias, On: on, Outer: true}
+}
+
+// Iterate implements the Operator interface.
+func (op *UnnestOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var j joiner
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		v, err := op.Expr.Eval(out)
+		if err != nil {
+			return err
+		}
+
+		var values func(fn func(v types.Value) error) error
+		switch v.Type() {
+		case types.NullValue:
+			values = func(fn func(v types.Value) error) error { return nil }
+		case types.ArrayValue:
+			values = func(fn func(v types.Value) error) error {
+				return types.As[types.Array](v).Iterate(func(i int, v types.Value) error {
+					return fn(v)
+				})
+			}
+		default:
+			return fmt.Errorf("UNNEST expects an array, got %s", v.Type())
+		}
+
+		return j.join(out, values, op.Alias, op.On, op.Outer, fn)
+	})
+}
+
+func (op *UnnestOperator) String() string {
+	right := fmt.Sprintf("%s AS %s", op.Expr, op.Alias)
+
+	if op.Outer {
+		return joinString("join.LeftUnnest", right, op.On)
+	}
+
+	return joinString("join.Unnest", right, op.On)
+}
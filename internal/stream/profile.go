@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/genjidb/genji/internal/environment"
+)
+
+// ProfileOperator measures the previous operator: the number of environments
+// it produced, the time spent producing them and the number of bytes read
+// from the session of the transaction.
+// The measures include the operators located before the previous one.
+type ProfileOperator struct {
+	BaseOperator
+
+	Rows      int64
+	Duration  time.Duration
+	BytesRead int64
+}
+
+// Iterate forwards the environments produced by the previous operator,
+// excluding the time spent and the bytes read by the next operators
+// from the measures.
+func (op *ProfileOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	start := time.Now()
+	startRead := bytesRead(in)
+
+	var downstream time.Duration
+	var downstreamRead int64
+
+	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
+		op.Rows++
+
+		t := time.Now()
+		r := bytesRead(in)
+
+		err := fn(out)
+
+		downstream += time.Since(t)
+		downstreamRead += bytesRead(in) - r
+		return err
+	})
+
+	op.Duration += time.Since(start) - downstream
+	op.BytesRead += bytesRead(in) - startRead - downstreamRead
+	return err
+}
+
+func (op *ProfileOperator) String() string {
+	return "profile()"
+}
+
+func bytesRead(env *environment.Environment) int64 {
+	tx := env.GetTx()
+	if tx == nil {
+		return 0
+	}
+
+	return tx.Session.BytesRead()
+}
+
+// Profile inserts a ProfileOperator after each operator of the stream
+// and returns them in the same order as the operators.
+func Profile(s *Stream) []*ProfileOperator {
+	var profiles []*ProfileOperator
+
+	op := s.First()
+	for op != nil {
+		next := op.GetNext()
+
+		p := new(ProfileOperator)
+		InsertAfter(op, p)
+		profiles = append(profiles, p)
+
+		op = next
+	}
+
+	if len(profiles) > 0 {
+		s.Op = profiles[len(profiles)-1]
+	}
+
+	return profiles
+}
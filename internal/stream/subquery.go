@@ -0,0 +1,57 @@
+package stream
+
+import (
+	"strings"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// A SubqueryOperator outputs the documents of a stream, like if they were
+// the documents of a table named after the alias of the subquery.
+type SubqueryOperator struct {
+	BaseOperator
+	Stream *Stream
+	// Alias is the name under which the documents can be referenced
+	// by qualified paths.
+	Alias string
+}
+
+// Subquery creates an operator that iterates over the documents of s
+// and makes them available under the given alias.
+func Subquery(s *Stream, alias string) *SubqueryOperator {
+	return &SubqueryOperator{Stream: s, Alias: alias}
+}
+
+// Iterate implements the Operator interface.
+func (op *SubqueryOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.AliasKey, types.NewTextValue(op.Alias))
+
+	aliasPath := document.Path{document.PathFragment{FieldName: op.Alias}}
+
+	return op.Stream.Iterate(in, func(out *environment.Environment) error {
+		if out.Doc == nil {
+			return nil
+		}
+
+		newEnv.SetDocument(out.Doc)
+		newEnv.Set(aliasPath, types.NewDocumentValue(out.Doc))
+
+		return fn(&newEnv)
+	})
+}
+
+func (op *SubqueryOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("stream.Subquery(")
+	s.WriteString(op.Stream.String())
+	s.WriteString(" AS ")
+	s.WriteString(op.Alias)
+	s.WriteRune(')')
+
+	return s.String()
+}
@@ -0,0 +1,36 @@
+package table
+
+import (
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// countChange counts a document written by the statement being run.
+func countChange(env *environment.Environment) {
+	if c := env.GetChanges(); c != nil {
+		c.RowsAffected++
+	}
+}
+
+// countInsert counts a document inserted by the statement being run.
+// If its key is a single integer, i.e. a docid or an integer primary key,
+// it is recorded as the last insert id.
+func countInsert(env *environment.Environment, key *tree.Key) error {
+	c := env.GetChanges()
+	if c == nil {
+		return nil
+	}
+
+	c.RowsAffected++
+
+	vs, err := key.Decode()
+	if err != nil {
+		return err
+	}
+	if len(vs) == 1 && vs[0].Type() == types.IntegerValue {
+		c.LastInsertID = types.As[int64](vs[0])
+	}
+
+	return nil
+}
@@ -7,15 +7,24 @@ import (
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
 )
 
 // A DeleteOperator replaces documents in the table
 type DeleteOperator struct {
 	stream.BaseOperator
 	Name string
+
+	// Update is set when documents are deleted to be inserted again
+	// with a new primary key. Instead of running the ON DELETE actions
+	// of the foreign keys referencing them, the deletion fails if their
+	// referenced values are modified while still referenced.
+	Update bool
 }
 
 // Delete deletes documents from the table. Incoming documents must implement the document.Keyer interface.
+// It runs the DELETE triggers of the table for each document and the ON DELETE actions
+// of the foreign keys referencing it.
 func Delete(tableName string) *DeleteOperator {
 	return &DeleteOperator{Name: tableName}
 }
@@ -23,6 +32,8 @@ func Delete(tableName string) *DeleteOperator {
 // Iterate implements the Operator interface.
 func (op *DeleteOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var table *database.Table
+	var before, after []*database.TriggerInfo
+	var refs []database.TableReference
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
 		if table == nil {
@@ -31,6 +42,10 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, f func(out *envir
 			if err != nil {
 				return err
 			}
+
+			before = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerBefore, database.TriggerDelete)
+			after = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerAfter, database.TriggerDelete)
+			refs = out.GetCatalog().GetTableReferences(op.Name)
 		}
 
 		key, ok := out.GetKey()
@@ -38,7 +53,60 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, f func(out *envir
 			return errors.New("missing key")
 		}
 
-		err := table.Delete(key)
+		var old, stored types.Document
+		if len(before) > 0 || len(after) > 0 || len(refs) > 0 {
+			d, ok := out.GetDocument()
+			if !ok {
+				return errors.New("missing document")
+			}
+
+			var err error
+			old, err = copyDocument(d)
+			if err != nil {
+				return err
+			}
+
+			stored = old
+			if op.Update && len(refs) > 0 {
+				// the incoming document is the updated one
+				d, err = table.GetDocument(key)
+				if err != nil {
+					return err
+				}
+
+				stored, err = copyDocument(d)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err := runTriggers(out, before, old, nil)
+		if err != nil {
+			return err
+		}
+
+		err = table.Delete(key)
+		if err != nil {
+			return err
+		}
+
+		// documents deleted by an UPDATE are counted
+		// when they are inserted again
+		if !op.Update {
+			countChange(out)
+		}
+
+		if op.Update {
+			err = onUpdate(out, refs, stored, old)
+		} else {
+			err = onDelete(out, refs, stored)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = runTriggers(out, after, old, nil)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,96 @@
+package table
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// RunForeignKeyAction applies an action to the documents of a table that reference
+// the given values through the foreign key constraint tc: ForeignKeyCascade deletes them,
+// ForeignKeySetNull sets their referencing paths to NULL and ForeignKeyRestrict returns
+// an error if there are any.
+// It is set by the statement package, which depends on this package.
+var RunForeignKeyAction func(env *environment.Environment, tableName string, tc *database.TableConstraint, action database.ForeignKeyAction, values []types.Value) error
+
+// referencedValues returns the values of d referenced by the foreign key constraint tc,
+// or nil if one of them is NULL or missing, in which case d cannot be referenced.
+func referencedValues(tc *database.TableConstraint, d types.Document) ([]types.Value, error) {
+	vs := make([]types.Value, 0, len(tc.ForeignKey.Paths))
+	for _, p := range tc.ForeignKey.Paths {
+		v, err := p.GetValueFromDocument(d)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v.Type() == types.NullValue {
+			return nil, nil
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+// onDelete runs the ON DELETE actions of the foreign keys referencing
+// the deleted document d.
+func onDelete(env *environment.Environment, refs []database.TableReference, d types.Document) error {
+	for _, ref := range refs {
+		vs, err := referencedValues(ref.Constraint, d)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			continue
+		}
+
+		err = RunForeignKeyAction(env, ref.TableName, ref.Constraint, ref.Constraint.ForeignKey.OnDelete, vs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// onUpdate ensures the values of old referenced by foreign keys
+// are not modified by new while they are still referenced.
+func onUpdate(env *environment.Environment, refs []database.TableReference, old, new types.Document) error {
+	for _, ref := range refs {
+		oldValues, err := referencedValues(ref.Constraint, old)
+		if err != nil {
+			return err
+		}
+		if oldValues == nil {
+			continue
+		}
+
+		newValues, err := referencedValues(ref.Constraint, new)
+		if err != nil {
+			return err
+		}
+
+		modified := newValues == nil
+		for i := 0; !modified && i < len(oldValues); i++ {
+			ok, err := types.IsEqual(oldValues[i], newValues[i])
+			if err != nil {
+				return err
+			}
+			modified = !ok
+		}
+		if !modified {
+			continue
+		}
+
+		err = RunForeignKeyAction(env, ref.TableName, ref.Constraint, database.ForeignKeyRestrict, oldValues)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -11,9 +11,15 @@ import (
 )
 
 // A InsertOperator inserts incoming documents to the table.
+// It runs the INSERT triggers of the table for each document.
 type InsertOperator struct {
 	stream.BaseOperator
 	Name string
+
+	// Update is set when documents are inserted again by an UPDATE
+	// with a new primary key. They are counted as modified documents
+	// but their key is not recorded as the last insert id.
+	Update bool
 }
 
 // Insert inserts incoming documents to the table.
@@ -27,6 +33,7 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 	newEnv.Set(environment.TableKey, types.NewTextValue(op.Name))
 
 	var table *database.Table
+	var before, after []*database.TriggerInfo
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
 		newEnv.SetOuter(out)
 
@@ -41,6 +48,14 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 			if err != nil {
 				return err
 			}
+
+			before = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerBefore, database.TriggerInsert)
+			after = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerAfter, database.TriggerInsert)
+		}
+
+		err = runTriggers(out, before, nil, d)
+		if err != nil {
+			return err
 		}
 
 		key, d, err := table.Insert(d)
@@ -48,6 +63,20 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 			return err
 		}
 
+		if op.Update {
+			countChange(out)
+		} else {
+			err = countInsert(out, key)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = runTriggers(out, after, nil, d)
+		if err != nil {
+			return err
+		}
+
 		newEnv.SetKey(key)
 		newEnv.SetDocument(d)
 
@@ -7,6 +7,7 @@ import (
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
 )
 
 // A ReplaceOperator replaces documents in the table
@@ -16,6 +17,8 @@ type ReplaceOperator struct {
 }
 
 // Replace replaces documents in the table. Incoming documents must implement the document.Keyer interface.
+// It runs the UPDATE triggers of the table for each document and fails if a document
+// still referenced by a foreign key is modified.
 func Replace(tableName string) *ReplaceOperator {
 	return &ReplaceOperator{Name: tableName}
 }
@@ -23,6 +26,8 @@ func Replace(tableName string) *ReplaceOperator {
 // Iterate implements the Operator interface.
 func (op *ReplaceOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var table *database.Table
+	var before, after []*database.TriggerInfo
+	var refs []database.TableReference
 
 	it := func(out *environment.Environment) error {
 		d, ok := out.GetDocument()
@@ -36,6 +41,10 @@ func (op *ReplaceOperator) Iterate(in *environment.Environment, f func(out *envi
 			if err != nil {
 				return err
 			}
+
+			before = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerBefore, database.TriggerUpdate)
+			after = out.GetCatalog().GetTableTriggers(op.Name, database.TriggerAfter, database.TriggerUpdate)
+			refs = out.GetCatalog().GetTableReferences(op.Name)
 		}
 
 		key, ok := out.GetKey()
@@ -43,7 +52,36 @@ func (op *ReplaceOperator) Iterate(in *environment.Environment, f func(out *envi
 			return errors.New("missing key")
 		}
 
-		_, err := table.Replace(key, d)
+		var old types.Document
+		if len(before) > 0 || len(after) > 0 || len(refs) > 0 {
+			d, err := table.GetDocument(key)
+			if err != nil {
+				return err
+			}
+
+			old, err = copyDocument(d)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := runTriggers(out, before, old, d)
+		if err != nil {
+			return err
+		}
+
+		_, err = table.Replace(key, d)
+		if err != nil {
+			return err
+		}
+		countChange(out)
+
+		err = onUpdate(out, refs, old, d)
+		if err != nil {
+			return err
+		}
+
+		err = runTriggers(out, after, old, d)
 		if err != nil {
 			return err
 		}
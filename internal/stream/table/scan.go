@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
@@ -16,8 +17,11 @@ import (
 type ScanOperator struct {
 	stream.BaseOperator
 	TableName string
-	Ranges    stream.Ranges
-	Reverse   bool
+	// Alias is the name under which the documents can be referenced
+	// by qualified paths. If empty, the table name is used.
+	Alias   string
+	Ranges  stream.Ranges
+	Reverse bool
 }
 
 // Scan creates an iterator that iterates over each document of the given table that match the given ranges.
@@ -42,6 +46,10 @@ func (it *ScanOperator) String() string {
 	s.WriteRune('(')
 
 	s.WriteString(strconv.Quote(it.TableName))
+	if it.Alias != "" && it.Alias != it.TableName {
+		s.WriteString(" AS ")
+		s.WriteString(it.Alias)
+	}
 	if len(it.Ranges) > 0 {
 		s.WriteString(", [")
 		for i, r := range it.Ranges {
@@ -64,6 +72,13 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 	var newEnv environment.Environment
 	newEnv.SetOuter(in)
 	newEnv.Set(environment.TableKey, types.NewTextValue(it.TableName))
+	// documents can be referenced by the table name or by its alias
+	name := it.Alias
+	if name == "" {
+		name = it.TableName
+	}
+	newEnv.Set(environment.AliasKey, types.NewTextValue(name))
+	alias := document.Path{document.PathFragment{FieldName: name}}
 
 	table, err := in.GetCatalog().GetTable(in.GetTx(), it.TableName)
 	if err != nil {
@@ -85,6 +100,7 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 		err = table.IterateOnRange(rng, it.Reverse, func(key *tree.Key, d types.Document) error {
 			newEnv.SetKey(key)
 			newEnv.SetDocument(d)
+			newEnv.Set(alias, types.NewDocumentValue(d))
 
 			return fn(&newEnv)
 		})
@@ -0,0 +1,34 @@
+package table
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// RunTrigger runs the statement of a trigger in the transaction of the given environment.
+// old and new are the OLD and NEW documents of the trigger, they are nil if the event
+// doesn't provide them.
+// It is set by the statement package, which depends on this package.
+var RunTrigger func(env *environment.Environment, info *database.TriggerInfo, old, new types.Document) error
+
+// runTriggers runs the given triggers in order.
+func runTriggers(env *environment.Environment, triggers []*database.TriggerInfo, old, new types.Document) error {
+	for _, t := range triggers {
+		err := RunTrigger(env, t, old, new)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyDocument returns a copy of d that remains valid
+// after the document is modified in the table.
+func copyDocument(d types.Document) (types.Document, error) {
+	fb := document.NewFieldBuffer()
+	err := fb.Copy(d)
+	return fb, err
+}
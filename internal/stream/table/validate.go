@@ -64,6 +64,18 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return err
 		}
 
+		// ensure the documents referenced by FOREIGN KEY constraints exist
+		for _, tc := range info.TableConstraints {
+			if tc.ForeignKey == nil {
+				continue
+			}
+
+			err = catalog.ValidateForeignKey(tx, info, tc, doc)
+			if err != nil {
+				return err
+			}
+		}
+
 		return fn(&newEnv)
 	})
 }
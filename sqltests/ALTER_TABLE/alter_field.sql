@@ -0,0 +1,108 @@
+-- setup:
+CREATE TABLE test(
+    a int primary key,
+    b int,
+    c (d int),
+    e text DEFAULT "foo",
+    f int CHECK (f > 0),
+    type int,
+    ...
+);
+INSERT INTO test (a, b, c, e, f) VALUES (1, 10, {d: 1}, "1.5", 1), (2, NULL, {d: 2}, "2", 2);
+
+-- test: integer to double
+ALTER TABLE test ALTER FIELD b TYPE DOUBLE;
+SELECT a, b, typeof(b) AS t FROM test;
+/* result:
+{
+  "a": 1,
+  "b": 10.0,
+  "t": "double"
+}
+{
+  "a": 2,
+  "b": null,
+  "t": "null"
+}
+*/
+
+-- test: catalog
+ALTER TABLE test ALTER FIELD b TYPE DOUBLE;
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b DOUBLE, c (d INTEGER), e TEXT DEFAULT \"foo\", f INTEGER, type INTEGER, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_check CHECK (f > 0), ...)"
+}
+*/
+
+-- test: nested field
+ALTER TABLE test ALTER FIELD c.d TYPE TEXT;
+SELECT c FROM test;
+/* result:
+{
+  "c": {"d": "1"}
+}
+{
+  "c": {"d": "2"}
+}
+*/
+
+-- test: text to double
+ALTER TABLE test ALTER FIELD e TYPE DOUBLE;
+-- error:
+
+-- test: text to integer
+ALTER TABLE test ALTER FIELD e TYPE INTEGER;
+-- error:
+
+-- test: failed conversion
+UPDATE test SET e = "2" WHERE a = 1;
+ALTER TABLE test ALTER FIELD e TYPE INTEGER;
+-- error:
+
+-- test: field named type
+ALTER TABLE test ALTER FIELD type TYPE TEXT;
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c (d INTEGER), e TEXT DEFAULT \"foo\", f INTEGER, type TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_check CHECK (f > 0), ...)"
+}
+*/
+
+-- test: document
+ALTER TABLE test ALTER FIELD c TYPE DOCUMENT;
+INSERT INTO test (a, c) VALUES (3, {d: "foo", g: 1});
+SELECT c FROM test;
+/* result:
+{
+  "c": {"d": 1.0}
+}
+{
+  "c": {"d": 2.0}
+}
+{
+  "c": {"d": "foo", "g": 1.0}
+}
+*/
+
+-- test: unknown field
+ALTER TABLE test ALTER FIELD z TYPE TEXT;
+-- error:
+
+-- test: primary key
+ALTER TABLE test ALTER FIELD a TYPE DOUBLE;
+-- error:
+
+-- test: check constraint
+ALTER TABLE test ALTER FIELD f TYPE DOUBLE;
+-- error:
+
+-- test: index
+CREATE INDEX ON test(b);
+ALTER TABLE test ALTER FIELD b TYPE DOUBLE;
+-- error:
+
+-- test: partial index predicate
+CREATE INDEX ON test(a) WHERE b > 10;
+ALTER TABLE test ALTER FIELD b TYPE DOUBLE;
+-- error:
@@ -19,3 +19,30 @@ ALTER TABLE unknown RENAME TO test2;
 CREATE TABLE test2;
 ALTER TABLE test2 RENAME TO test;
 -- error:
+
+-- test: rename table used by a view
+CREATE VIEW v AS SELECT a FROM test;
+ALTER TABLE test RENAME TO test2;
+-- error: cannot rename table "test": view "v" depends on it
+
+-- test: rename table used by a view of a view
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW w AS SELECT a FROM v;
+ALTER TABLE test RENAME TO test2;
+-- error: cannot rename table "test": views "v", "w" depend on it
+
+-- test: add field to existing documents
+INSERT INTO test (a) VALUES (1);
+ALTER TABLE test ADD FIELD b INTEGER DEFAULT 10;
+SELECT * FROM test;
+/* result:
+{
+  "a": 1,
+  "b": 10
+}
+*/
+
+-- test: add not null field to existing documents
+INSERT INTO test (a) VALUES (1);
+ALTER TABLE test ADD FIELD b INTEGER NOT NULL;
+-- error: NOT NULL constraint error: [b]
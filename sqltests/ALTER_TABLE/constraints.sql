@@ -0,0 +1,191 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int, c int UNIQUE, d text);
+INSERT INTO test (a, b, c, d) VALUES (1, 10, 1, "x"), (2, 20, 2, "x"), (3, NULL, 3, "y");
+
+-- test: add check
+ALTER TABLE test ADD CONSTRAINT positive_b CHECK (b > 0);
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c INTEGER, d TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_c_unique UNIQUE (c), CONSTRAINT positive_b CHECK (b > 0))"
+}
+*/
+
+-- test: add check without name
+ALTER TABLE test ADD CHECK (b > 0);
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c INTEGER, d TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_c_unique UNIQUE (c), CONSTRAINT test_check CHECK (b > 0))"
+}
+*/
+
+-- test: check is enforced
+ALTER TABLE test ADD CONSTRAINT positive_b CHECK (b > 0);
+INSERT INTO test (a, b) VALUES (4, -1);
+-- error:
+
+-- test: check violated by existing document
+ALTER TABLE test ADD CONSTRAINT big_b CHECK (b > 10);
+-- error: cannot add constraint "big_b" on document {a: 1, b: 10, c: 1, d: "x"}: document violates check constraint "big_b"
+
+-- test: add unique
+ALTER TABLE test ADD UNIQUE (b);
+SELECT name, sql FROM __genji_catalog WHERE type = "index" ORDER BY name;
+/* result:
+{
+  "name": "test_b_idx",
+  "sql": "CREATE UNIQUE INDEX test_b_idx ON test (b)"
+}
+{
+  "name": "test_c_idx",
+  "sql": "CREATE UNIQUE INDEX test_c_idx ON test (c)"
+}
+*/
+
+-- test: unique is enforced
+ALTER TABLE test ADD CONSTRAINT unique_b UNIQUE (b);
+INSERT INTO test (a, b) VALUES (4, 10);
+-- error:
+
+-- test: unique is used by the planner
+ALTER TABLE test ADD UNIQUE (b);
+EXPLAIN SELECT * FROM test WHERE b = 10;
+/* result:
+{
+  "plan": 'index.Scan("test_b_idx", [{"min": [10], "exact": true}])'
+}
+*/
+
+-- test: unique violated by existing documents
+ALTER TABLE test ADD UNIQUE (d);
+-- error: cannot add constraint "test_d_unique" on document {a: 1, b: 10, c: 1, d: "x"}: UNIQUE constraint error: [d]
+
+-- test: duplicate unique
+ALTER TABLE test ADD UNIQUE (c);
+-- error:
+
+-- test: add primary key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD PRIMARY KEY (c);
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c INTEGER NOT NULL, d TEXT, CONSTRAINT test_c_unique UNIQUE (c), CONSTRAINT test_pk PRIMARY KEY (c))"
+}
+*/
+
+-- test: primary key is used by the planner
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD PRIMARY KEY (c);
+EXPLAIN SELECT * FROM test WHERE c = 2;
+/* result:
+{
+  "plan": 'table.Scan("test", [{"min": [2], "exact": true}])'
+}
+*/
+
+-- test: primary key is enforced
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD PRIMARY KEY (b);
+-- error: cannot add constraint "test_pk" on document {a: 3, c: 3, d: "y"}: missing primary key at path "b"
+
+-- test: primary key violated by existing documents
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD PRIMARY KEY (d);
+-- error: cannot add constraint "test_pk" on document {a: 2, b: 20, c: 2, d: "x"}: PRIMARY KEY constraint error: [d]
+
+-- test: add second primary key
+ALTER TABLE test ADD PRIMARY KEY (c);
+-- error: multiple primary keys for table "test" are not allowed
+
+-- test: add field with primary key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD FIELD e INTEGER PRIMARY KEY;
+-- error: NOT NULL constraint error: [e]
+
+-- test: indexes are rebuilt with the new key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+ALTER TABLE test ADD PRIMARY KEY (d, a);
+SELECT * FROM test WHERE c = 2;
+/* result:
+{
+  "a": 2,
+  "b": 20,
+  "c": 2,
+  "d": "x"
+}
+*/
+
+-- test: drop check
+ALTER TABLE test ADD CONSTRAINT positive_b CHECK (b > 0);
+ALTER TABLE test DROP CONSTRAINT positive_b;
+INSERT INTO test (a, b) VALUES (4, -1);
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c INTEGER, d TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_c_unique UNIQUE (c))"
+}
+*/
+
+-- test: drop unique
+ALTER TABLE test DROP CONSTRAINT test_c_unique;
+INSERT INTO test (a, c) VALUES (4, 1);
+SELECT name FROM __genji_catalog WHERE type = "index";
+/* result:
+*/
+
+-- test: drop unknown constraint
+ALTER TABLE test DROP CONSTRAINT unknown;
+-- error:
+
+-- test: drop primary key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+INSERT INTO test (a, c) VALUES (1, 4);
+SELECT a, c FROM test ORDER BY c;
+/* result:
+{
+  "a": 1,
+  "c": 1
+}
+{
+  "a": 2,
+  "c": 2
+}
+{
+  "a": 3,
+  "c": 3
+}
+{
+  "a": 1,
+  "c": 4
+}
+*/
+
+-- test: drop primary key creates a docid sequence
+ALTER TABLE test DROP CONSTRAINT test_pk;
+SELECT name, sql FROM __genji_catalog WHERE name IN ("test", "test_seq");
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, c INTEGER, d TEXT, CONSTRAINT test_c_unique UNIQUE (c))"
+}
+{
+  "name": "test_seq",
+  "sql": "CREATE SEQUENCE test_seq CACHE 64"
+}
+*/
+
+-- test: indexes are rebuilt without the key
+ALTER TABLE test DROP CONSTRAINT test_pk;
+SELECT a FROM test WHERE c = 3;
+/* result:
+{
+  "a": 3
+}
+*/
+
+-- test: drop primary key referenced by a foreign key
+CREATE TABLE child (id INT PRIMARY KEY, a INT REFERENCES test);
+ALTER TABLE test DROP CONSTRAINT test_pk;
+-- error: cannot drop constraint "test_pk": it is referenced by foreign key "child_a_fkey" of table "child"
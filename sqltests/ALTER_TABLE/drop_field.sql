@@ -0,0 +1,99 @@
+-- setup:
+CREATE TABLE test(
+    a int primary key,
+    b int,
+    c (d text, e double),
+    f int CHECK (f > 0),
+    g int UNIQUE,
+    h text
+);
+INSERT INTO test (a, b, c, f, g, h) VALUES (1, 10, {d: "foo", e: 1.5}, 1, 1, "x"), (2, 20, {d: "bar"}, 2, 2, "y");
+
+-- test: top-level field
+ALTER TABLE test DROP FIELD b;
+SELECT * FROM test;
+/* result:
+{
+  "a": 1,
+  "c": {"d": "foo", "e": 1.5},
+  "f": 1,
+  "g": 1,
+  "h": "x"
+}
+{
+  "a": 2,
+  "c": {"d": "bar"},
+  "f": 2,
+  "g": 2,
+  "h": "y"
+}
+*/
+
+-- test: catalog
+ALTER TABLE test DROP FIELD b;
+SELECT sql FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, c (d TEXT, e DOUBLE), f INTEGER, g INTEGER, h TEXT, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_check CHECK (f > 0), CONSTRAINT test_g_unique UNIQUE (g))"
+}
+*/
+
+-- test: nested field
+ALTER TABLE test DROP FIELD c.d;
+SELECT c FROM test;
+/* result:
+{
+  "c": {"e": 1.5}
+}
+{
+  "c": {}
+}
+*/
+
+-- test: insert after drop
+ALTER TABLE test DROP FIELD h;
+INSERT INTO test (a, b, c, f, g) VALUES (3, 30, {d: "baz", e: 2}, 3, 3);
+SELECT a, b, h FROM test WHERE a = 3;
+/* result:
+{
+  "a": 3,
+  "b": 30,
+  "h": null
+}
+*/
+
+-- test: insert dropped field
+ALTER TABLE test DROP FIELD h;
+INSERT INTO test (a, h) VALUES (3, "z");
+SELECT * FROM test WHERE a = 3;
+/* result:
+{
+  "a": 3
+}
+*/
+
+-- test: unknown field
+ALTER TABLE test DROP FIELD z;
+-- error:
+
+-- test: primary key
+ALTER TABLE test DROP FIELD a;
+-- error:
+
+-- test: check constraint
+ALTER TABLE test DROP FIELD f;
+-- error:
+
+-- test: unique constraint
+ALTER TABLE test DROP FIELD g;
+-- error:
+
+-- test: index on nested field
+CREATE INDEX ON test(c.e);
+ALTER TABLE test DROP FIELD c;
+-- error:
+
+-- test: partial index predicate
+CREATE INDEX ON test(b) WHERE h = "x";
+ALTER TABLE test DROP FIELD h;
+-- error:
@@ -0,0 +1,101 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int, c int);
+CREATE INDEX test_b_idx ON test(b);
+CREATE TABLE foo(a int);
+INSERT INTO test (a, b, c) VALUES (1, 1, 1), (2, 1, 2), (3, 2, 3), (4, 2, 4);
+INSERT INTO foo (a) VALUES (1), (2);
+
+-- test: table
+ANALYZE test;
+SELECT table_name, paths, row_count, distinct_counts, len(histogram) AS buckets FROM __genji_stats;
+/* result:
+{
+    "table_name": "test",
+    "paths": ["a"],
+    "row_count": 4,
+    "distinct_counts": [4.0],
+    "buckets": 4
+}
+{
+    "table_name": "test",
+    "paths": ["b"],
+    "row_count": 4,
+    "distinct_counts": [2.0],
+    "buckets": 2
+}
+*/
+
+-- test: all tables
+ANALYZE;
+SELECT table_name, paths FROM __genji_stats;
+/* result:
+{
+    "table_name": "test",
+    "paths": ["a"]
+}
+{
+    "table_name": "test",
+    "paths": ["b"]
+}
+*/
+
+-- test: analyze twice
+ANALYZE test;
+INSERT INTO test (a, b, c) VALUES (5, 3, 5);
+ANALYZE test;
+SELECT paths, row_count, distinct_counts FROM __genji_stats;
+/* result:
+{
+    "paths": ["a"],
+    "row_count": 5,
+    "distinct_counts": [5.0]
+}
+{
+    "paths": ["b"],
+    "row_count": 5,
+    "distinct_counts": [3.0]
+}
+*/
+
+-- test: drop table
+ANALYZE test;
+DROP TABLE test;
+SELECT COUNT(*) FROM __genji_stats;
+/* result:
+{
+    "COUNT(*)": 0
+}
+*/
+
+-- test: drop index
+ANALYZE test;
+DROP INDEX test_b_idx;
+SELECT table_name, paths FROM __genji_stats;
+/* result:
+{
+    "table_name": "test",
+    "paths": ["a"]
+}
+*/
+
+-- test: indexes on the same paths
+CREATE INDEX test_b_idx2 ON test(b);
+CREATE INDEX test_a_idx ON test(a);
+ANALYZE test;
+DROP INDEX test_b_idx;
+DROP INDEX test_a_idx;
+SELECT table_name, paths FROM __genji_stats;
+/* result:
+{
+    "table_name": "test",
+    "paths": ["a"]
+}
+{
+    "table_name": "test",
+    "paths": ["b"]
+}
+*/
+
+-- test: unknown table
+ANALYZE unknown;
+-- error:
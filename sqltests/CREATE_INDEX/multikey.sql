@@ -0,0 +1,29 @@
+-- setup:
+CREATE TABLE test (a int, b array, c text);
+
+-- test: array field
+CREATE UNIQUE INDEX test_b_idx ON test(b[*]);
+SELECT name, owner.table_name AS table_name, sql FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  "name": "test_b_idx",
+  "table_name": "test",
+  "sql": "CREATE UNIQUE INDEX test_b_idx ON test (b[*])"
+}
+*/
+
+-- test: non array field
+CREATE INDEX ON test(c[*]);
+-- error:
+
+-- test: multiple paths
+CREATE INDEX ON test(b[*], c);
+-- error: cannot index "b" with other paths: composite multikey indexes are not supported
+
+-- test: multiple paths, multikey last
+CREATE INDEX ON test(c, b[*]);
+-- error: cannot index "b" with other paths: composite multikey indexes are not supported
+
+-- test: wildcard inside the path
+CREATE INDEX ON test(b[*].c);
+-- error:
@@ -0,0 +1,61 @@
+-- setup:
+CREATE TABLE test (id int PRIMARY KEY, a int, status text);
+INSERT INTO test (id, a, status) VALUES (1, 10, 'done'), (2, 20, 'pending'), (3, 10, 'pending'), (4, 30, NULL);
+
+-- test: predicate
+CREATE INDEX test_a_idx ON test(a) WHERE status != 'done';
+SELECT name, owner.table_name AS table_name, sql FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  "name": "test_a_idx",
+  "table_name": "test",
+  "sql": "CREATE INDEX test_a_idx ON test (a) WHERE status != \"done\""
+}
+*/
+
+-- test: only matching documents are indexed
+CREATE INDEX test_a_idx ON test(a) WHERE status != 'done';
+SELECT id FROM test WHERE a >= 10 AND status = 'pending';
+/* result:
+{
+  "id": 3
+}
+{
+  "id": 2
+}
+*/
+
+-- test: documents are added and removed when the predicate changes
+CREATE INDEX test_a_idx ON test(a) WHERE status != 'done';
+UPDATE test SET status = 'pending' WHERE id = 1;
+UPDATE test SET status = 'done' WHERE id = 3;
+INSERT INTO test (id, a, status) VALUES (5, 5, 'pending'), (6, 6, 'done');
+DELETE FROM test WHERE id = 2;
+SELECT id FROM test WHERE a >= 0 AND status = 'pending';
+/* result:
+{
+  "id": 5
+}
+{
+  "id": 1
+}
+*/
+
+-- test: unique
+CREATE UNIQUE INDEX test_a_idx ON test(a) WHERE status = 'pending';
+INSERT INTO test (id, a, status) VALUES (5, 10, 'done');
+SELECT COUNT(*) AS count FROM test WHERE a = 10;
+/* result:
+{
+  "count": 3
+}
+*/
+
+-- test: unique conflict
+CREATE UNIQUE INDEX test_a_idx ON test(a) WHERE status = 'pending';
+INSERT INTO test (id, a, status) VALUES (5, 20, 'pending');
+-- error:
+
+-- test: missing predicate
+CREATE INDEX test_a_idx ON test(a) WHERE;
+-- error:
@@ -0,0 +1,168 @@
+-- setup:
+CREATE TABLE customers(id INT PRIMARY KEY, email TEXT UNIQUE, name TEXT);
+
+-- test: as field constraint
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "orders";
+/* result:
+{
+  "name": "orders",
+  "sql": "CREATE TABLE orders (id INTEGER NOT NULL, customer_id INTEGER, CONSTRAINT orders_pk PRIMARY KEY (id), CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers (id))"
+}
+*/
+
+-- test: as field constraint, with paths and action
+CREATE TABLE orders(id INT PRIMARY KEY, email TEXT REFERENCES customers(email) ON DELETE CASCADE);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "orders";
+/* result:
+{
+  "name": "orders",
+  "sql": "CREATE TABLE orders (id INTEGER NOT NULL, email TEXT, CONSTRAINT orders_pk PRIMARY KEY (id), CONSTRAINT orders_email_fkey FOREIGN KEY (email) REFERENCES customers (email) ON DELETE CASCADE)"
+}
+*/
+
+-- test: as table constraint
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT, CONSTRAINT fk_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE SET NULL);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "orders";
+/* result:
+{
+  "name": "orders",
+  "sql": "CREATE TABLE orders (id INTEGER NOT NULL, customer_id INTEGER, CONSTRAINT orders_pk PRIMARY KEY (id), CONSTRAINT fk_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE SET NULL)"
+}
+*/
+
+-- test: self reference
+CREATE TABLE employees(id INT PRIMARY KEY, manager_id INT REFERENCES employees ON DELETE RESTRICT);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "employees";
+/* result:
+{
+  "name": "employees",
+  "sql": "CREATE TABLE employees (id INTEGER NOT NULL, manager_id INTEGER, CONSTRAINT employees_pk PRIMARY KEY (id), CONSTRAINT employees_manager_id_fkey FOREIGN KEY (manager_id) REFERENCES employees (id))"
+}
+*/
+
+-- test: unknown table
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES unknown);
+-- error:
+
+-- test: not unique
+CREATE TABLE orders(id INT PRIMARY KEY, name TEXT REFERENCES customers(name));
+-- error:
+
+-- test: no primary key
+CREATE TABLE other(a INT);
+CREATE TABLE orders(id INT PRIMARY KEY, a INT REFERENCES other);
+-- error:
+
+-- test: paths mismatch
+CREATE TABLE orders(id INT PRIMARY KEY, a INT, b INT, FOREIGN KEY (a, b) REFERENCES customers (id));
+-- error:
+
+-- test: invalid action
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE NOTHING);
+-- error:
+
+-- test: insert
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+INSERT INTO customers (id, email) VALUES (1, "a@b.c");
+INSERT INTO orders (id, customer_id) VALUES (1, 1), (2, NULL), (3, 1.0);
+INSERT INTO orders (id) VALUES (4);
+SELECT id, customer_id FROM orders;
+/* result:
+{
+  "id": 1,
+  "customer_id": 1
+}
+{
+  "id": 2,
+  "customer_id": null
+}
+{
+  "id": 3,
+  "customer_id": 1
+}
+{
+  "id": 4,
+  "customer_id": null
+}
+*/
+
+-- test: insert missing reference
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+-- error: document violates foreign key constraint "orders_customer_id_fkey"
+
+-- test: insert with unique reference
+CREATE TABLE orders(id INT PRIMARY KEY, email TEXT REFERENCES customers(email));
+INSERT INTO customers (id, email) VALUES (1, "a@b.c");
+INSERT INTO orders (id, email) VALUES (1, "a@b.c");
+INSERT INTO orders (id, email) VALUES (2, "d@e.f");
+-- error: document violates foreign key constraint "orders_email_fkey"
+
+-- test: insert self reference
+CREATE TABLE employees(id INT PRIMARY KEY, manager_id INT REFERENCES employees);
+INSERT INTO employees (id, manager_id) VALUES (1, 1), (2, 1);
+SELECT id, manager_id FROM employees;
+/* result:
+{
+  "id": 1,
+  "manager_id": 1
+}
+{
+  "id": 2,
+  "manager_id": 1
+}
+*/
+
+-- test: update referencing document
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+INSERT INTO customers (id) VALUES (1);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+UPDATE orders SET customer_id = 2;
+-- error: document violates foreign key constraint "orders_customer_id_fkey"
+
+-- test: update referenced document
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+INSERT INTO customers (id, name) VALUES (1, "a"), (2, "b");
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+UPDATE customers SET name = "c";
+UPDATE customers SET id = id + 10 WHERE id = 2;
+SELECT id, name FROM customers;
+/* result:
+{
+  "id": 1,
+  "name": "c"
+}
+{
+  "id": 12,
+  "name": "c"
+}
+*/
+
+-- test: update referenced key
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE);
+INSERT INTO customers (id) VALUES (1);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+UPDATE customers SET id = 2;
+-- error: document is still referenced by foreign key "orders_customer_id_fkey" of table "orders"
+
+-- test: drop referenced table
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+DROP TABLE customers;
+-- error:
+
+-- test: drop referenced unique constraint
+CREATE TABLE orders(id INT PRIMARY KEY, email TEXT REFERENCES customers(email));
+ALTER TABLE customers DROP CONSTRAINT customers_email_unique;
+-- error:
+
+-- test: rename referenced table
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+ALTER TABLE customers RENAME TO clients;
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "orders";
+/* result:
+{
+  "name": "orders",
+  "sql": "CREATE TABLE orders (id INTEGER NOT NULL, customer_id INTEGER, CONSTRAINT orders_pk PRIMARY KEY (id), CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES clients (id))"
+}
+*/
@@ -48,6 +48,26 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 }
 */
 
+-- test: TIMESTAMP
+CREATE TABLE test (a TIMESTAMP);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TIMESTAMP)"
+}
+*/
+
+-- test: INTERVAL
+CREATE TABLE test (a INTERVAL);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTERVAL)"
+}
+*/
+
 -- test: ARRAY
 CREATE TABLE test (a ARRAY);
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
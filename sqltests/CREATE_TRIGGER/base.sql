@@ -0,0 +1,160 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int);
+CREATE TABLE audit(op text, a int, old_b int, new_b int);
+CREATE TABLE counters(name text primary key, n int);
+INSERT INTO counters (name, n) VALUES ("test", 0);
+
+-- test: catalog
+CREATE TRIGGER test_insert AFTER INSERT ON test DO INSERT INTO audit (op, a) VALUES ("insert", NEW.a);
+SELECT name, type, sql, owner FROM __genji_catalog WHERE name = "test_insert";
+/* result:
+{
+  "name": "test_insert",
+  "type": "trigger",
+  "sql": "CREATE TRIGGER test_insert AFTER INSERT ON test FOR EACH ROW DO INSERT INTO audit (op, a) VALUES (\"insert\", NEW.a)",
+  "owner": {
+    "table_name": "test"
+  }
+}
+*/
+
+-- test: after insert
+CREATE TRIGGER test_insert AFTER INSERT ON test FOR EACH ROW DO INSERT INTO audit (op, a, new_b) VALUES ("insert", NEW.a, NEW.b);
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20);
+SELECT op, a, new_b FROM audit;
+/* result:
+{
+  "op": "insert",
+  "a": 1,
+  "new_b": 10
+}
+{
+  "op": "insert",
+  "a": 2,
+  "new_b": 20
+}
+*/
+
+-- test: before insert
+CREATE TRIGGER test_insert BEFORE INSERT ON test DO UPDATE counters SET n = n + NEW.b WHERE name = "test";
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20);
+SELECT n FROM counters;
+/* result:
+{
+  "n": 30
+}
+*/
+
+-- test: after update
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20);
+CREATE TRIGGER test_update AFTER UPDATE ON test DO INSERT INTO audit VALUES {op: "update", a: OLD.a, old_b: OLD.b, new_b: NEW.b};
+UPDATE test SET b = b + 1 WHERE a = 2;
+SELECT * FROM audit;
+/* result:
+{
+  "op": "update",
+  "a": 2,
+  "old_b": 20,
+  "new_b": 21
+}
+*/
+
+-- test: after delete
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20);
+CREATE TRIGGER test_delete AFTER DELETE ON test DO INSERT INTO audit (op, a, old_b) VALUES ("delete", OLD.a, OLD.b);
+DELETE FROM test WHERE a = 1;
+SELECT op, a, old_b FROM audit;
+/* result:
+{
+  "op": "delete",
+  "a": 1,
+  "old_b": 10
+}
+*/
+
+-- test: before delete
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20);
+CREATE TRIGGER test_delete BEFORE DELETE ON test DO DELETE FROM counters;
+DELETE FROM test;
+SELECT COUNT(*) FROM counters;
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: counter
+CREATE TRIGGER test_inc AFTER INSERT ON test DO UPDATE counters SET n = n + 1 WHERE name = "test";
+CREATE TRIGGER test_dec AFTER DELETE ON test DO UPDATE counters SET n = n - 1 WHERE name = "test";
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20), (3, 30);
+DELETE FROM test WHERE a > 1;
+SELECT n FROM counters;
+/* result:
+{
+  "n": 1
+}
+*/
+
+-- test: triggers run in name order
+CREATE TRIGGER b_trigger AFTER INSERT ON test DO INSERT INTO audit (op) VALUES ("b");
+CREATE TRIGGER a_trigger AFTER INSERT ON test DO INSERT INTO audit (op) VALUES ("a");
+INSERT INTO test (a, b) VALUES (1, 10);
+SELECT op FROM audit;
+/* result:
+{
+  "op": "a"
+}
+{
+  "op": "b"
+}
+*/
+
+-- test: errors abort the statement
+CREATE TABLE strict(a int not null);
+CREATE TRIGGER test_insert AFTER INSERT ON test DO INSERT INTO strict (a) VALUES (NULL);
+INSERT INTO test (a, b) VALUES (1, 10);
+-- error:
+
+-- test: trigger cannot fire itself
+CREATE TRIGGER test_insert AFTER INSERT ON test DO INSERT INTO test (a, b) VALUES (NEW.a + 100, NEW.b);
+INSERT INTO test (a, b) VALUES (1, 10);
+-- error: trigger "test_insert": a trigger cannot fire itself
+
+-- test: if not exists
+CREATE TRIGGER test_insert AFTER INSERT ON test DO DELETE FROM audit;
+CREATE TRIGGER IF NOT EXISTS test_insert AFTER DELETE ON test DO DELETE FROM audit;
+SELECT sql FROM __genji_catalog WHERE name = "test_insert";
+/* result:
+{
+  "sql": "CREATE TRIGGER test_insert AFTER INSERT ON test FOR EACH ROW DO DELETE FROM audit"
+}
+*/
+
+-- test: duplicate
+CREATE TRIGGER test_insert AFTER INSERT ON test DO DELETE FROM audit;
+CREATE TRIGGER test_insert AFTER DELETE ON test DO DELETE FROM audit;
+-- error:
+
+-- test: unknown table
+CREATE TRIGGER test_insert AFTER INSERT ON unknown DO DELETE FROM audit;
+-- error:
+
+-- test: unknown table in statement
+CREATE TRIGGER test_insert AFTER INSERT ON test DO DELETE FROM unknown;
+-- error:
+
+-- test: read-only table
+CREATE TRIGGER test_insert AFTER INSERT ON __genji_catalog DO DELETE FROM audit;
+-- error:
+
+-- test: rename table
+CREATE TRIGGER test_insert AFTER INSERT ON test DO INSERT INTO audit (op, a) VALUES ("insert", NEW.a);
+ALTER TABLE test RENAME TO test2;
+INSERT INTO test2 (a, b) VALUES (1, 10);
+SELECT op, a FROM audit;
+/* result:
+{
+  "op": "insert",
+  "a": 1
+}
+*/
@@ -0,0 +1,141 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int, c text);
+CREATE TABLE other(a int primary key, d text);
+INSERT INTO test (a, b, c) VALUES (1, 10, "x"), (2, 20, "y"), (3, 30, "x");
+INSERT INTO other (a, d) VALUES (1, "foo"), (3, "bar");
+
+-- test: catalog
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT name, type, sql FROM __genji_catalog WHERE name = "v";
+/* result:
+{
+  "name": "v",
+  "type": "view",
+  "sql": "CREATE VIEW v AS SELECT a, b FROM test WHERE c = \"x\""
+}
+*/
+
+-- test: select
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT * FROM v;
+/* result:
+{
+  "a": 1,
+  "b": 10
+}
+{
+  "a": 3,
+  "b": 30
+}
+*/
+
+-- test: where
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT b FROM v WHERE a > 1;
+/* result:
+{
+  "b": 30
+}
+*/
+
+-- test: alias
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT w.b FROM v AS w WHERE w.a = 1;
+/* result:
+{
+  "w.b": 10
+}
+*/
+
+-- test: join
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT v.b, other.d FROM v JOIN other ON v.a = other.a;
+/* result:
+{
+  "v.b": 10,
+  "other.d": "foo"
+}
+{
+  "v.b": 30,
+  "other.d": "bar"
+}
+*/
+
+-- test: join with view on the right
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+SELECT other.d, v.b FROM other JOIN v ON v.a = other.a;
+/* result:
+{
+  "other.d": "foo",
+  "v.b": 10
+}
+{
+  "other.d": "bar",
+  "v.b": 30
+}
+*/
+
+-- test: aggregation
+CREATE VIEW v AS SELECT c, COUNT(*) AS n FROM test GROUP BY c;
+SELECT * FROM v WHERE n > 1;
+/* result:
+{
+  "c": "x",
+  "n": 2
+}
+*/
+
+-- test: view of a view
+CREATE VIEW v AS SELECT a, b FROM test WHERE c = "x";
+CREATE VIEW w AS SELECT b FROM v WHERE a = 3;
+SELECT * FROM w;
+/* result:
+{
+  "b": 30
+}
+*/
+
+-- test: reflects changes
+CREATE VIEW v AS SELECT a FROM test WHERE c = "x";
+INSERT INTO test (a, b, c) VALUES (4, 40, "x");
+SELECT COUNT(*) FROM v;
+/* result:
+{
+  "COUNT(*)": 3
+}
+*/
+
+-- test: if not exists
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW IF NOT EXISTS v AS SELECT b FROM test;
+SELECT sql FROM __genji_catalog WHERE name = "v";
+/* result:
+{
+  "sql": "CREATE VIEW v AS SELECT a FROM test"
+}
+*/
+
+-- test: duplicate
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW v AS SELECT b FROM test;
+-- error:
+
+-- test: same name as a table
+CREATE VIEW test AS SELECT a FROM other;
+-- error:
+
+-- test: unknown table
+CREATE VIEW v AS SELECT a FROM unknown;
+-- error:
+
+-- test: self reference
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW w AS SELECT a FROM v;
+DROP VIEW v;
+CREATE VIEW v AS SELECT a FROM w;
+-- error:
+
+-- test: insert into a view
+CREATE VIEW v AS SELECT a FROM test;
+INSERT INTO v (a) VALUES (10);
+-- error:
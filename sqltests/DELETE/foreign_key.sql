@@ -0,0 +1,111 @@
+-- setup:
+CREATE TABLE customers(id INT PRIMARY KEY, email TEXT UNIQUE);
+INSERT INTO customers (id, email) VALUES (1, "a@b.c"), (2, "d@e.f");
+
+-- test: restrict
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+DELETE FROM customers WHERE id = 1;
+-- error: document is still referenced by foreign key "orders_customer_id_fkey" of table "orders"
+
+-- test: restrict, not referenced
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE RESTRICT);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+DELETE FROM customers WHERE id = 2;
+SELECT id FROM customers;
+/* result:
+{
+  "id": 1
+}
+*/
+
+-- test: cascade
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE);
+CREATE INDEX on orders(customer_id);
+INSERT INTO orders (id, customer_id) VALUES (1, 1), (2, 2), (3, 1);
+DELETE FROM customers WHERE id = 1;
+SELECT id, customer_id FROM orders;
+/* result:
+{
+  "id": 2,
+  "customer_id": 2
+}
+*/
+
+-- test: cascade, unique reference
+CREATE TABLE orders(id INT PRIMARY KEY, email TEXT REFERENCES customers(email) ON DELETE CASCADE);
+INSERT INTO orders (id, email) VALUES (1, "a@b.c"), (2, "d@e.f");
+DELETE FROM customers WHERE id = 2;
+SELECT id, email FROM orders;
+/* result:
+{
+  "id": 1,
+  "email": "a@b.c"
+}
+*/
+
+-- test: cascade, nested
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE);
+CREATE TABLE items(id INT PRIMARY KEY, order_id INT REFERENCES orders ON DELETE CASCADE);
+INSERT INTO orders (id, customer_id) VALUES (1, 1), (2, 2);
+INSERT INTO items (id, order_id) VALUES (1, 1), (2, 1), (3, 2);
+DELETE FROM customers WHERE id = 1;
+SELECT id, order_id FROM items;
+/* result:
+{
+  "id": 3,
+  "order_id": 2
+}
+*/
+
+-- test: cascade, nested restrict
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE);
+CREATE TABLE items(id INT PRIMARY KEY, order_id INT REFERENCES orders);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+INSERT INTO items (id, order_id) VALUES (1, 1);
+DELETE FROM customers WHERE id = 1;
+-- error: document is still referenced by foreign key "items_order_id_fkey" of table "items"
+
+-- test: cascade, self reference
+CREATE TABLE employees(id INT PRIMARY KEY, manager_id INT REFERENCES employees ON DELETE CASCADE);
+INSERT INTO employees (id, manager_id) VALUES (1, NULL), (2, 1), (3, 2), (4, NULL);
+DELETE FROM employees WHERE id = 1;
+SELECT id FROM employees;
+/* result:
+{
+  "id": 4
+}
+*/
+
+-- test: set null
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE SET NULL);
+INSERT INTO orders (id, customer_id) VALUES (1, 1), (2, 2);
+DELETE FROM customers WHERE id = 1;
+SELECT id, customer_id FROM orders;
+/* result:
+{
+  "id": 1,
+  "customer_id": null
+}
+{
+  "id": 2,
+  "customer_id": 2
+}
+*/
+
+-- test: set null, not null
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT NOT NULL REFERENCES customers ON DELETE SET NULL);
+INSERT INTO orders (id, customer_id) VALUES (1, 1);
+DELETE FROM customers WHERE id = 1;
+-- error:
+
+-- test: delete all
+CREATE TABLE orders(id INT PRIMARY KEY, customer_id INT REFERENCES customers ON DELETE CASCADE);
+INSERT INTO orders (id, customer_id) VALUES (1, 1), (2, 2);
+DELETE FROM customers;
+SELECT COUNT(*) FROM orders;
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
@@ -0,0 +1,42 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int);
+CREATE TABLE other(a int primary key, c int);
+CREATE TABLE unused(a int);
+
+-- test: view
+CREATE VIEW v AS SELECT a FROM test;
+DROP TABLE test;
+-- error: cannot drop table "test": view "v" depends on it
+
+-- test: join and subquery
+CREATE VIEW v1 AS SELECT test.a FROM test JOIN other ON test.a = other.a;
+CREATE VIEW v2 AS SELECT a FROM unused WHERE a IN (SELECT c FROM other);
+DROP TABLE other;
+-- error: cannot drop table "other": views "v1", "v2" depend on it
+
+-- test: view of a view
+CREATE VIEW v AS SELECT a, b FROM test;
+CREATE VIEW w AS SELECT a FROM v;
+DROP TABLE test;
+-- error: cannot drop table "test": views "v", "w" depend on it
+
+-- test: after dropping the view
+CREATE VIEW v AS SELECT a FROM test;
+DROP VIEW v;
+DROP TABLE test;
+SELECT COUNT(*) FROM __genji_catalog WHERE name = "test";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: other tables
+CREATE VIEW v AS SELECT a FROM test;
+DROP TABLE unused;
+SELECT COUNT(*) FROM __genji_catalog WHERE name = "unused";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
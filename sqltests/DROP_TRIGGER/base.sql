@@ -0,0 +1,36 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int);
+CREATE TABLE audit(op text, a int);
+CREATE TRIGGER test_insert AFTER INSERT ON test DO INSERT INTO audit (op, a) VALUES ("insert", NEW.a);
+
+-- test: drop
+DROP TRIGGER test_insert;
+INSERT INTO test (a, b) VALUES (1, 10);
+SELECT COUNT(*) FROM audit;
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: unknown trigger
+DROP TRIGGER unknown;
+-- error:
+
+-- test: if exists
+DROP TRIGGER IF EXISTS unknown;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{
+  "COUNT(*)": 1
+}
+*/
+
+-- test: drop table drops its triggers
+DROP TABLE test;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
@@ -0,0 +1,54 @@
+-- setup:
+CREATE TABLE test(a int primary key, b int);
+CREATE VIEW v AS SELECT a FROM test;
+
+-- test: drop
+DROP VIEW v;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
+
+-- test: select after drop
+DROP VIEW v;
+SELECT * FROM v;
+-- error:
+
+-- test: unknown view
+DROP VIEW unknown;
+-- error:
+
+-- test: if exists
+DROP VIEW IF EXISTS unknown;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "COUNT(*)": 1
+}
+*/
+
+-- test: drop a table
+DROP VIEW test;
+-- error:
+
+-- test: drop table does not drop a view
+DROP TABLE v;
+-- error:
+
+-- test: view used by another view
+CREATE VIEW w AS SELECT a FROM v;
+DROP VIEW v;
+-- error: cannot drop view "v": view "w" depends on it
+
+-- test: after dropping the dependent view
+CREATE VIEW w AS SELECT a FROM v;
+DROP VIEW w;
+DROP VIEW v;
+SELECT COUNT(*) FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
@@ -18,3 +18,39 @@ SELECT a % 2 FROM test GROUP BY a % 2
 {"a % 2": 0}
 {"a % 2": 1}
 */
+
+-- test: GROUP BY multiple expressions
+SELECT a % 2, a > 2, COUNT(*) FROM test GROUP BY a % 2, a > 2
+/* result:
+{"a % 2": 0, "a > 2": false, "COUNT(*)": 1}
+{"a % 2": 0, "a > 2": true, "COUNT(*)": 1}
+{"a % 2": 1, "a > 2": false, "COUNT(*)": 1}
+{"a % 2": 1, "a > 2": true, "COUNT(*)": 2}
+*/
+
+-- test: HAVING
+SELECT a % 2, SUM(a) FROM test GROUP BY a % 2 HAVING SUM(a) > 6
+/* result:
+{"a % 2": 1, "SUM(a)": 9}
+*/
+
+-- test: HAVING with aggregate not in projection
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING COUNT(*) = 2
+/* result:
+{"a % 2": 0}
+*/
+
+-- test: HAVING with GROUP BY expression
+SELECT a % 2 AS m, COUNT(*) FROM test GROUP BY a % 2 HAVING a % 2 = 0
+/* result:
+{"m": 0, "COUNT(*)": 2}
+*/
+
+-- test: HAVING without GROUP BY
+SELECT COUNT(*) FROM test HAVING COUNT(*) > 10
+/* result:
+*/
+
+-- test: HAVING with field not in GROUP BY
+SELECT a % 2 FROM test GROUP BY a % 2 HAVING a > 1
+-- error:
@@ -0,0 +1,38 @@
+-- setup:
+CREATE TABLE foo(
+  id INT PRIMARY KEY,
+  tags ARRAY,
+  info (
+      ...
+  )
+);
+INSERT INTO foo VALUES
+  (1, ["a", "b"], {name: "foo", address: {city: "Lyon"}}),
+  (2, ["b", "c"], {name: "bar"});
+
+-- test: array_contains in WHERE
+SELECT id FROM foo WHERE array_contains(tags, "c");
+/* result:
+{"id": 2}
+*/
+
+-- test: projection
+SELECT id, array_append(tags, "d") AS tags, document_keys(info) AS keys FROM foo WHERE id = 1;
+/* result:
+{"id": 1, "tags": ["a", "b", "d"], "keys": ["name", "address"]}
+*/
+
+-- test: json_extract
+SELECT id, json_extract(info, '$.address.city') AS city FROM foo;
+/* result:
+{"id": 1, "city": "Lyon"}
+{"id": 2, "city": null}
+*/
+
+-- test: update
+UPDATE foo SET tags = array_remove(tags, "b"), info = document_merge(info, {name: "baz"});
+SELECT id, tags, info.name AS name FROM foo;
+/* result:
+{"id": 1, "tags": ["a"], "name": "baz"}
+{"id": 2, "tags": ["c"], "name": "baz"}
+*/
@@ -0,0 +1,45 @@
+-- setup:
+CREATE TABLE durations(id int PRIMARY KEY, d INTERVAL NOT NULL);
+CREATE INDEX durations_d ON durations(d);
+INSERT INTO durations (id, d) VALUES
+    (1, INTERVAL '1 month'),
+    (2, INTERVAL '300 days'),
+    (3, INTERVAL '30 days'),
+    (4, INTERVAL '29 days 23 hours'),
+    (5, INTERVAL '1 year');
+
+-- test: order by
+SELECT id, d FROM durations ORDER BY d;
+/* result:
+{"id": 4, "d": INTERVAL '29 days 23 hours'}
+{"id": 3, "d": INTERVAL '30 days'}
+{"id": 1, "d": INTERVAL '1 month'}
+{"id": 2, "d": INTERVAL '300 days'}
+{"id": 5, "d": INTERVAL '1 year'}
+*/
+
+-- test: order by, no index
+DROP INDEX durations_d;
+SELECT id, d FROM durations ORDER BY d DESC;
+/* result:
+{"id": 5, "d": INTERVAL '1 year'}
+{"id": 2, "d": INTERVAL '300 days'}
+{"id": 1, "d": INTERVAL '1 month'}
+{"id": 3, "d": INTERVAL '30 days'}
+{"id": 4, "d": INTERVAL '29 days 23 hours'}
+*/
+
+-- test: compare
+SELECT id FROM durations WHERE d > INTERVAL '1 month' ORDER BY id;
+/* result:
+{"id": 2}
+{"id": 5}
+*/
+
+-- test: compare, no index
+DROP INDEX durations_d;
+SELECT id FROM durations WHERE d > INTERVAL '1 month' ORDER BY id;
+/* result:
+{"id": 2}
+{"id": 5}
+*/
@@ -0,0 +1,128 @@
+-- setup:
+CREATE TABLE customers(id int PRIMARY KEY, name text);
+CREATE TABLE orders(id int PRIMARY KEY, customer_id int, total double);
+INSERT INTO customers (id, name) VALUES (1, 'foo'), (2, 'bar'), (3, 'baz');
+INSERT INTO orders (id, customer_id, total) VALUES (10, 1, 5.5), (11, 1, 20.0), (12, 2, 8.0), (13, 4, 1.0);
+
+-- test: inner join
+SELECT c.name, o.total FROM customers c JOIN orders o ON c.id = o.customer_id;
+/* result:
+{"c.name": "foo", "o.total": 5.5}
+{"c.name": "foo", "o.total": 20.0}
+{"c.name": "bar", "o.total": 8.0}
+*/
+
+-- test: inner join with table names
+SELECT customers.name, orders.id FROM customers INNER JOIN orders ON customers.id = orders.customer_id;
+/* result:
+{"customers.name": "foo", "orders.id": 10}
+{"customers.name": "foo", "orders.id": 11}
+{"customers.name": "bar", "orders.id": 12}
+*/
+
+-- test: wildcard
+SELECT * FROM customers AS c JOIN orders AS o ON c.id = o.customer_id WHERE o.id = 12;
+/* result:
+{
+    "c": {"id": 2, "name": "bar"},
+    "o": {"id": 12, "customer_id": 2, "total": 8.0}
+}
+*/
+
+-- test: left join
+SELECT c.name, o.id FROM customers c LEFT JOIN orders o ON c.id = o.customer_id;
+/* result:
+{"c.name": "foo", "o.id": 10}
+{"c.name": "foo", "o.id": 11}
+{"c.name": "bar", "o.id": 12}
+{"c.name": "baz", "o.id": NULL}
+*/
+
+-- test: left outer join with wildcard
+SELECT * FROM customers c LEFT OUTER JOIN orders o ON c.id = o.customer_id WHERE c.id = 3;
+/* result:
+{
+    "c": {"id": 3, "name": "baz"},
+    "o": NULL
+}
+*/
+
+-- test: left join with IS NULL
+SELECT c.name FROM customers c LEFT JOIN orders o ON c.id = o.customer_id WHERE o.id IS NULL;
+/* result:
+{"c.name": "baz"}
+*/
+
+-- test: unqualified paths
+SELECT name, total FROM customers c JOIN orders o ON c.id = customer_id WHERE total > 6;
+/* result:
+{"name": "foo", "total": 20.0}
+{"name": "bar", "total": 8.0}
+*/
+
+-- test: ambiguous paths
+SELECT id FROM customers c JOIN orders o ON c.id = o.customer_id;
+-- error:
+
+-- test: multiple joins
+SELECT c.name, o.id, o2.id FROM customers c JOIN orders o ON c.id = o.customer_id JOIN orders o2 ON o2.customer_id = c.id AND o2.id != o.id;
+/* result:
+{"c.name": "foo", "o.id": 10, "o2.id": 11}
+{"c.name": "foo", "o.id": 11, "o2.id": 10}
+*/
+
+-- test: join without condition
+SELECT c.id, o.id FROM customers c JOIN orders o WHERE c.id = 1 AND o.id < 12;
+/* result:
+{"c.id": 1, "o.id": 10}
+{"c.id": 1, "o.id": 11}
+*/
+
+-- test: order by
+SELECT c.name, o.total FROM customers c JOIN orders o ON c.id = o.customer_id ORDER BY o.total DESC;
+/* result:
+{"c.name": "foo", "o.total": 20.0}
+{"c.name": "bar", "o.total": 8.0}
+{"c.name": "foo", "o.total": 5.5}
+*/
+
+-- test: group by
+SELECT c.name, COUNT(*) FROM customers c JOIN orders o ON c.id = o.customer_id GROUP BY c.name;
+/* result:
+{"c.name": "bar", "COUNT(*)": 1}
+{"c.name": "foo", "COUNT(*)": 2}
+*/
+
+-- test: group by unqualified
+SELECT name, SUM(total) FROM customers c JOIN orders o ON c.id = o.customer_id GROUP BY name;
+/* result:
+{"name": "bar", "SUM(total)": 8.0}
+{"name": "foo", "SUM(total)": 25.5}
+*/
+
+-- test: limit
+SELECT o.id FROM customers c JOIN orders o ON c.id = o.customer_id LIMIT 1;
+/* result:
+{"o.id": 10}
+*/
+
+-- test: duplicate table name
+SELECT * FROM customers JOIN customers ON customers.id = customers.id;
+-- error:
+
+-- test: unknown table
+SELECT * FROM customers c JOIN unknown u ON c.id = u.id;
+-- error:
+
+-- test: single table alias
+SELECT t.name FROM customers AS t WHERE t.id > 1;
+/* result:
+{"t.name": "bar"}
+{"t.name": "baz"}
+*/
+
+-- test: single table qualified by its name
+SELECT customers.name FROM customers WHERE customers.id = 1;
+/* result:
+{"customers.name": "foo"}
+*/
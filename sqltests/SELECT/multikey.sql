@@ -0,0 +1,36 @@
+-- setup:
+CREATE TABLE posts(id int PRIMARY KEY, tags array);
+
+CREATE UNIQUE INDEX ON posts(tags[*]);
+
+-- test: unique elements
+INSERT INTO posts (id, tags) VALUES (1, ["go", "db"]), (2, ["rust"]);
+SELECT id FROM posts WHERE "db" IN tags;
+/* result:
+{
+    "id": 1
+}
+*/
+
+-- test: duplicate elements in the same array
+INSERT INTO posts (id, tags) VALUES (1, ["go", "go"]);
+SELECT id FROM posts WHERE "go" IN tags;
+/* result:
+{
+    "id": 1
+}
+*/
+
+-- test: duplicate elements in different arrays
+INSERT INTO posts (id, tags) VALUES (1, ["go", "db"]);
+INSERT INTO posts (id, tags) VALUES (2, ["rust", "go"]);
+-- error:
+
+-- test: NULL elements are not unique
+INSERT INTO posts (id, tags) VALUES (1, [NULL]), (2, [NULL]);
+SELECT COUNT(*) FROM posts;
+/* result:
+{
+    "COUNT(*)": 2
+}
+*/
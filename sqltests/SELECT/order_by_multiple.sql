@@ -0,0 +1,92 @@
+-- setup:
+CREATE TABLE test(a int, b int, c text);
+INSERT INTO test (a, b, c) VALUES (1, 2, 'foo'), (2, 1, 'ba'), (1, null, 'b'), (2, 3, 'bazz'), (null, 1, 'baz');
+
+-- suite: no index
+
+-- suite: with composite index
+CREATE INDEX ON test(a, b);
+
+-- test: asc, asc
+SELECT a, b FROM test ORDER BY a, b;
+/* result:
+{"a": null, "b": 1}
+{"a": 1, "b": null}
+{"a": 1, "b": 2}
+{"a": 2, "b": 1}
+{"a": 2, "b": 3}
+*/
+
+-- test: desc, desc
+SELECT a, b FROM test ORDER BY a DESC, b DESC;
+/* result:
+{"a": 2, "b": 3}
+{"a": 2, "b": 1}
+{"a": 1, "b": 2}
+{"a": 1, "b": null}
+{"a": null, "b": 1}
+*/
+
+-- test: asc, desc
+SELECT a, b FROM test ORDER BY a ASC, b DESC;
+/* result:
+{"a": null, "b": 1}
+{"a": 1, "b": 2}
+{"a": 1, "b": null}
+{"a": 2, "b": 3}
+{"a": 2, "b": 1}
+*/
+
+-- test: desc, asc
+SELECT a, b FROM test ORDER BY a DESC, b;
+/* result:
+{"a": 2, "b": 1}
+{"a": 2, "b": 3}
+{"a": 1, "b": null}
+{"a": 1, "b": 2}
+{"a": null, "b": 1}
+*/
+
+-- test: nulls last
+SELECT a, b FROM test ORDER BY a NULLS LAST, b NULLS LAST;
+/* result:
+{"a": 1, "b": 2}
+{"a": 1, "b": null}
+{"a": 2, "b": 1}
+{"a": 2, "b": 3}
+{"a": null, "b": 1}
+*/
+
+-- test: desc nulls first
+SELECT a, b FROM test ORDER BY a DESC NULLS FIRST, b DESC NULLS FIRST;
+/* result:
+{"a": null, "b": 1}
+{"a": 2, "b": 3}
+{"a": 2, "b": 1}
+{"a": 1, "b": null}
+{"a": 1, "b": 2}
+*/
+
+-- test: expression
+SELECT c FROM test ORDER BY len(c) DESC, c;
+/* result:
+{"c": "bazz"}
+{"c": "baz"}
+{"c": "foo"}
+{"c": "ba"}
+{"c": "b"}
+*/
+
+-- test: text desc
+SELECT c FROM test ORDER BY a, c DESC;
+/* result:
+{"c": "baz"}
+{"c": "foo"}
+{"c": "b"}
+{"c": "bazz"}
+{"c": "ba"}
+*/
+
+-- test: missing NULLS position
+SELECT * FROM test ORDER BY a NULLS;
+-- error:
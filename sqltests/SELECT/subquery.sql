@@ -0,0 +1,123 @@
+-- setup:
+CREATE TABLE customers(id int PRIMARY KEY, name text);
+CREATE TABLE orders(id int PRIMARY KEY, customer_id int, total double);
+INSERT INTO customers (id, name) VALUES (1, 'foo'), (2, 'bar'), (3, 'baz');
+INSERT INTO orders (id, customer_id, total) VALUES (10, 1, 5.5), (11, 1, 20.0), (12, 2, 8.0), (13, 4, 1.0);
+
+-- test: IN
+SELECT name FROM customers WHERE id IN (SELECT customer_id FROM orders);
+/* result:
+{"name": "foo"}
+{"name": "bar"}
+*/
+
+-- test: NOT IN
+SELECT name FROM customers WHERE id NOT IN (SELECT customer_id FROM orders);
+/* result:
+{"name": "baz"}
+*/
+
+-- test: IN with empty subquery
+SELECT name FROM customers WHERE id IN (SELECT customer_id FROM orders WHERE total > 100);
+/* result:
+*/
+
+-- test: EXISTS
+SELECT name FROM customers c WHERE EXISTS (SELECT 1 FROM orders o WHERE o.customer_id = c.id);
+/* result:
+{"name": "foo"}
+{"name": "bar"}
+*/
+
+-- test: NOT EXISTS
+SELECT name FROM customers c WHERE NOT EXISTS (SELECT * FROM orders o WHERE o.customer_id = c.id);
+/* result:
+{"name": "baz"}
+*/
+
+-- test: correlated subquery using table names
+SELECT id FROM orders WHERE EXISTS (SELECT * FROM customers WHERE customers.id = orders.customer_id AND name = 'bar');
+/* result:
+{"id": 12}
+*/
+
+-- test: scalar subquery
+SELECT id FROM orders WHERE total = (SELECT MAX(total) FROM orders);
+/* result:
+{"id": 11}
+*/
+
+-- test: scalar subquery in projection
+SELECT name, (SELECT COUNT(*) FROM orders o WHERE o.customer_id = c.id) AS n FROM customers c;
+/* result:
+{"name": "foo", "n": 2}
+{"name": "bar", "n": 1}
+{"name": "baz", "n": 0}
+*/
+
+-- test: scalar subquery without FROM
+SELECT (SELECT name FROM customers WHERE id = 2) AS name;
+/* result:
+{"name": "bar"}
+*/
+
+-- test: scalar subquery without result
+SELECT (SELECT name FROM customers WHERE id = 10) AS name;
+/* result:
+{"name": NULL}
+*/
+
+-- test: scalar subquery with multiple rows
+SELECT (SELECT name FROM customers) AS name;
+-- error:
+
+-- test: scalar subquery with multiple columns
+SELECT (SELECT id, name FROM customers WHERE id = 1) AS name;
+-- error:
+
+-- test: FROM subquery
+SELECT s.total FROM (SELECT total FROM orders WHERE total > 6) AS s;
+/* result:
+{"s.total": 20.0}
+{"s.total": 8.0}
+*/
+
+-- test: FROM subquery with order by and limit
+SELECT * FROM (SELECT id, total FROM orders ORDER BY total DESC LIMIT 2) s;
+/* result:
+{"id": 11, "total": 20.0}
+{"id": 12, "total": 8.0}
+*/
+
+-- test: FROM subquery with aggregation
+SELECT MAX(n) FROM (SELECT customer_id, COUNT(*) AS n FROM orders GROUP BY customer_id) AS s;
+/* result:
+{"MAX(n)": 2}
+*/
+
+-- test: FROM subquery without alias
+SELECT * FROM (SELECT id FROM orders);
+-- error:
+
+-- test: JOIN subquery
+SELECT c.name, s.total FROM customers c JOIN (SELECT customer_id, total FROM orders WHERE total > 6) AS s ON s.customer_id = c.id;
+/* result:
+{"c.name": "foo", "s.total": 20.0}
+{"c.name": "bar", "s.total": 8.0}
+*/
+
+-- test: UPDATE with subquery
+UPDATE orders SET total = 0 WHERE customer_id IN (SELECT id FROM customers WHERE name = 'bar');
+SELECT id, total FROM orders WHERE total = 0;
+/* result:
+{"id": 12, "total": 0.0}
+*/
+
+-- test: DELETE with subquery
+DELETE FROM orders WHERE customer_id NOT IN (SELECT id FROM customers);
+SELECT id FROM orders;
+/* result:
+{"id": 10}
+{"id": 11}
+{"id": 12}
+*/
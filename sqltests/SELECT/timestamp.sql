@@ -0,0 +1,75 @@
+-- setup:
+CREATE TABLE events(id int PRIMARY KEY, at TIMESTAMP NOT NULL);
+CREATE INDEX events_at ON events(at);
+INSERT INTO events (id, at) VALUES
+    (1, '2023-01-15T10:30:00Z'),
+    (2, '2023-03-01 08:00:00'),
+    (3, '2022-12-31'),
+    (4, '2023-01-15T12:30:00+02:00');
+
+-- test: type
+SELECT id, typeof(at) AS t FROM events WHERE id = 1;
+/* result:
+{"id": 1, "t": "timestamp"}
+*/
+
+-- test: order by
+SELECT id, at FROM events ORDER BY at;
+/* result:
+{"id": 3, "at": "2022-12-31T00:00:00Z"}
+{"id": 1, "at": "2023-01-15T10:30:00Z"}
+{"id": 4, "at": "2023-01-15T10:30:00Z"}
+{"id": 2, "at": "2023-03-01T08:00:00Z"}
+*/
+
+-- test: compare with text
+SELECT id FROM events WHERE at >= '2023-01-15T10:30:00Z' AND at < '2023-02-01';
+/* result:
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: compare with text, no index
+SELECT id FROM events WHERE CAST(at AS TEXT) > '2023' AND at < '2023-02-01';
+/* result:
+{"id": 1}
+{"id": 4}
+*/
+
+-- test: interval arithmetic
+SELECT id, at + INTERVAL '1 day' AS next_day, at - CAST('2022-12-31' AS TIMESTAMP) AS since FROM events WHERE id = 1;
+/* result:
+{"id": 1, "next_day": "2023-01-16T10:30:00Z", "since": INTERVAL '15 days 10 hours 30 minutes'}
+*/
+
+-- test: interval column names
+SELECT at + INTERVAL '1 month' FROM events WHERE id = 1;
+/* result:
+{"at + INTERVAL '1 month'": "2023-02-15T10:30:00Z"}
+*/
+
+-- test: compare with invalid text
+SELECT id FROM events WHERE at > 'yesterday';
+-- error: cannot parse "yesterday" as timestamp
+
+-- test: compare with invalid text, no index
+SELECT id FROM events WHERE id = 1 AND at > 'yesterday';
+-- error: cannot parse "yesterday" as timestamp
+
+-- test: group by date_trunc
+SELECT date_trunc('month', at) AS month, COUNT(*) AS n FROM events GROUP BY date_trunc('month', at);
+/* result:
+{"month": "2022-12-01T00:00:00Z", "n": 1}
+{"month": "2023-01-01T00:00:00Z", "n": 2}
+{"month": "2023-03-01T00:00:00Z", "n": 1}
+*/
+
+-- test: min max
+SELECT MIN(at) AS first, MAX(at) AS last FROM events;
+/* result:
+{"first": "2022-12-31T00:00:00Z", "last": "2023-03-01T08:00:00Z"}
+*/
+
+-- test: invalid timestamp
+INSERT INTO events (id, at) VALUES (5, 'yesterday');
+-- error: cannot cast "yesterday" as timestamp
@@ -0,0 +1,86 @@
+-- setup:
+CREATE TABLE orders(id int PRIMARY KEY, customer text, items ARRAY);
+INSERT INTO orders (id, customer, items) VALUES
+    (1, 'foo', [{sku: 'a', qty: 2}, {sku: 'b', qty: 1}]),
+    (2, 'bar', [{sku: 'a', qty: 5}]),
+    (3, 'baz', []);
+INSERT INTO orders (id, customer) VALUES (4, 'qux');
+
+-- test: unnest
+SELECT t.id, item FROM orders t, UNNEST(t.items) AS item;
+/* result:
+{"t.id": 1, "item": {"sku": "a", "qty": 2.0}}
+{"t.id": 1, "item": {"sku": "b", "qty": 1.0}}
+{"t.id": 2, "item": {"sku": "a", "qty": 5.0}}
+*/
+
+-- test: paths of the values
+SELECT id, item.sku AS sku FROM orders, UNNEST(items) item WHERE item.qty > 1;
+/* result:
+{"id": 1, "sku": "a"}
+{"id": 2, "sku": "a"}
+*/
+
+-- test: wildcard
+SELECT * FROM orders o, UNNEST(o.items) AS i WHERE o.id = 2;
+/* result:
+{
+    "o": {"id": 2, "customer": "bar", "items": [{"sku": "a", "qty": 5.0}]},
+    "i": {"sku": "a", "qty": 5.0}
+}
+*/
+
+-- test: aggregation
+SELECT item.sku AS sku, SUM(item.qty) AS total FROM orders, UNNEST(items) AS item GROUP BY item.sku;
+/* result:
+{"sku": "a", "total": 7.0}
+{"sku": "b", "total": 1.0}
+*/
+
+-- test: count
+SELECT COUNT(*) AS n FROM orders, UNNEST(items) AS item;
+/* result:
+{"n": 3}
+*/
+
+-- test: scalar values
+SELECT id, n FROM orders, UNNEST([1, 2]) AS n WHERE id = 1;
+/* result:
+{"id": 1, "n": 1}
+{"id": 1, "n": 2}
+*/
+
+-- test: join
+SELECT id, item.sku AS sku FROM orders JOIN UNNEST(items) AS item ON item.qty = 1;
+/* result:
+{"id": 1, "sku": "b"}
+*/
+
+-- test: left join
+SELECT id, item FROM orders LEFT JOIN UNNEST(items) AS item ON true WHERE id > 2;
+/* result:
+{"id": 3, "item": null}
+{"id": 4, "item": null}
+*/
+
+-- test: followed by a join
+CREATE TABLE products(sku text PRIMARY KEY, name text);
+INSERT INTO products (sku, name) VALUES ('a', 'apple'), ('b', 'banana');
+SELECT o.id, p.name FROM orders o, UNNEST(o.items) AS i JOIN products p ON p.sku = i.sku;
+/* result:
+{"o.id": 1, "p.name": "apple"}
+{"o.id": 1, "p.name": "banana"}
+{"o.id": 2, "p.name": "apple"}
+*/
+
+-- test: not an array
+SELECT * FROM orders, UNNEST(customer) AS c;
+-- error: UNNEST expects an array, got text
+
+-- test: missing alias
+SELECT * FROM orders, UNNEST(items);
+-- error:
+
+-- test: duplicate alias
+SELECT * FROM orders, UNNEST(items) AS orders;
+-- error: table name "orders" specified more than once
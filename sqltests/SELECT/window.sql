@@ -0,0 +1,95 @@
+-- setup:
+CREATE TABLE emp(id int PRIMARY KEY, dept text, salary int);
+INSERT INTO emp (id, dept, salary) VALUES (1, 'a', 10), (2, 'a', 20), (3, 'b', 20), (4, 'a', 20), (5, 'b', 5);
+
+-- test: row_number
+SELECT id, row_number() OVER (ORDER BY id DESC) AS n FROM emp;
+/* result:
+{"id": 5, "n": 1}
+{"id": 4, "n": 2}
+{"id": 3, "n": 3}
+{"id": 2, "n": 4}
+{"id": 1, "n": 5}
+*/
+
+-- test: rank with partitions
+SELECT id, rank() OVER (PARTITION BY dept ORDER BY salary DESC) AS r, dense_rank() OVER (PARTITION BY dept ORDER BY salary DESC) AS dr FROM emp;
+/* result:
+{"id": 2, "r": 1, "dr": 1}
+{"id": 4, "r": 1, "dr": 1}
+{"id": 1, "r": 3, "dr": 2}
+{"id": 3, "r": 1, "dr": 1}
+{"id": 5, "r": 2, "dr": 2}
+*/
+
+-- test: running sum
+SELECT id, sum(salary) OVER (PARTITION BY dept ORDER BY id) AS s FROM emp;
+/* result:
+{"id": 1, "s": 10}
+{"id": 2, "s": 30}
+{"id": 4, "s": 50}
+{"id": 3, "s": 20}
+{"id": 5, "s": 25}
+*/
+
+-- test: running sum with peers
+SELECT id, sum(salary) OVER (ORDER BY salary) AS s FROM emp;
+/* result:
+{"id": 5, "s": 5}
+{"id": 1, "s": 15}
+{"id": 2, "s": 75}
+{"id": 3, "s": 75}
+{"id": 4, "s": 75}
+*/
+
+-- test: aggregate over the whole partition
+SELECT id, avg(salary) OVER (PARTITION BY dept) AS a, count(*) OVER () AS c FROM emp ORDER BY id;
+/* result:
+{"id": 1, "a": 16.666666666666668, "c": 5}
+{"id": 2, "a": 16.666666666666668, "c": 5}
+{"id": 3, "a": 12.5, "c": 5}
+{"id": 4, "a": 16.666666666666668, "c": 5}
+{"id": 5, "a": 12.5, "c": 5}
+*/
+
+-- test: lag and lead
+SELECT id, lag(salary) OVER (ORDER BY id) AS prev, lead(salary, 2, 0) OVER (ORDER BY id) AS nxt FROM emp ORDER BY id;
+/* result:
+{"id": 1, "prev": NULL, "nxt": 20}
+{"id": 2, "prev": 10, "nxt": 20}
+{"id": 3, "prev": 20, "nxt": 5}
+{"id": 4, "prev": 20, "nxt": 0}
+{"id": 5, "prev": 20, "nxt": 0}
+*/
+
+-- test: wildcard
+SELECT *, row_number() OVER (PARTITION BY dept ORDER BY id) AS n FROM emp WHERE salary > 5 ORDER BY id;
+/* result:
+{"id": 1, "dept": "a", "salary": 10, "n": 1}
+{"id": 2, "dept": "a", "salary": 20, "n": 2}
+{"id": 3, "dept": "b", "salary": 20, "n": 1}
+{"id": 4, "dept": "a", "salary": 20, "n": 3}
+*/
+
+-- test: expression
+SELECT id, row_number() OVER (ORDER BY id) * 10 AS n FROM emp WHERE id < 3;
+/* result:
+{"id": 1, "n": 10}
+{"id": 2, "n": 20}
+*/
+
+-- test: without OVER
+SELECT row_number() FROM emp;
+-- error:
+
+-- test: not a window function
+SELECT typeof(id) OVER () FROM emp;
+-- error:
+
+-- test: with GROUP BY
+SELECT dept, row_number() OVER () FROM emp GROUP BY dept;
+-- error:
+
+-- test: with aggregate
+SELECT COUNT(*), row_number() OVER () FROM emp;
+-- error:
@@ -113,3 +113,58 @@ NULL
 
 > 1000000000000000000 * 1000000000000000000 * 1000000000000000000
 1000000000000000000000000000000000000000000000000000000
+
+-- test: timestamps
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) + INTERVAL '1 day'
+CAST('2023-01-16T10:30:00Z' AS TIMESTAMP)
+
+> INTERVAL '90 minutes' + CAST('2023-01-15T10:30:00Z' AS TIMESTAMP)
+CAST('2023-01-15T12:00:00Z' AS TIMESTAMP)
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) - INTERVAL '1h30m'
+CAST('2023-01-15T09:00:00Z' AS TIMESTAMP)
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) - CAST('2023-01-15T10:00:00Z' AS TIMESTAMP)
+INTERVAL '30 minutes'
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) - CAST('2023-01-15T10:00:00Z' AS TIMESTAMP) = INTERVAL '30 minutes'
+true
+
+> CAST('2023-01-31T10:30:00Z' AS TIMESTAMP) + INTERVAL '1 month'
+CAST('2023-02-28T10:30:00Z' AS TIMESTAMP)
+
+> CAST('2024-01-31T10:30:00Z' AS TIMESTAMP) + INTERVAL '1 month'
+CAST('2024-02-29T10:30:00Z' AS TIMESTAMP)
+
+> CAST('2023-03-31T10:30:00Z' AS TIMESTAMP) - INTERVAL '1 month 1 hour'
+CAST('2023-02-28T09:30:00Z' AS TIMESTAMP)
+
+> CAST('2023-08-31T10:30:00Z' AS TIMESTAMP) + INTERVAL '6 months'
+CAST('2024-02-29T10:30:00Z' AS TIMESTAMP)
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) - INTERVAL '1 year 2 months 1 hour'
+CAST('2021-11-15T09:30:00Z' AS TIMESTAMP)
+
+> INTERVAL '1 month' + INTERVAL '1 day'
+INTERVAL '1 month 1 day'
+
+> INTERVAL '1 month' - INTERVAL '1 day'
+INTERVAL '1 month -1 day'
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) + CAST('2023-01-15T10:00:00Z' AS TIMESTAMP)
+NULL
+
+! CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) + 5
+'cannot add timestamp and integer, use an interval instead'
+
+! CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) - 5.0
+'cannot subtract double from timestamp, use an interval instead'
+
+! INTERVAL '1 day' + 1
+'cannot add interval and integer, use an interval instead'
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) * 2
+NULL
+
+> CAST('2023-01-15T10:30:00Z' AS TIMESTAMP) + NULL
+NULL
@@ -111,6 +111,50 @@ false
 
 ! CAST ('{"a": 1' AS DOCUMENT)
 
+> CAST ('2023-01-15T10:30:00+02:00' AS TIMESTAMP)
+CAST ('2023-01-15T08:30:00Z' AS TIMESTAMP)
+
+> CAST ('2023-01-15 10:30:00.5' AS TIMESTAMP)
+CAST ('2023-01-15T10:30:00.5Z' AS TIMESTAMP)
+
+> CAST ('2023-01-15' AS TIMESTAMP)
+CAST ('2023-01-15T00:00:00Z' AS TIMESTAMP)
+
+! CAST ('2023-13-15' AS TIMESTAMP)
+'cannot cast "2023-13-15" as timestamp'
+
+-- test: source(TIMESTAMP)
+> CAST (CAST ('2023-01-15T10:30:00Z' AS TIMESTAMP) AS TIMESTAMP)
+CAST ('2023-01-15T10:30:00Z' AS TIMESTAMP)
+
+> CAST (CAST ('2023-01-15T10:30:00.123Z' AS TIMESTAMP) AS TEXT)
+'2023-01-15T10:30:00.123Z'
+
+! CAST (CAST ('2023-01-15T10:30:00Z' AS TIMESTAMP) AS INTEGER)
+'cannot cast timestamp as integer'
+
+! CAST (CAST ('2023-01-15T10:30:00Z' AS TIMESTAMP) AS BOOL)
+'cannot cast timestamp as bool'
+
+! CAST (CAST ('2023-01-15T10:30:00Z' AS TIMESTAMP) AS BLOB)
+'cannot cast timestamp as blob'
+
+> CAST ('1 year 2 days' AS INTERVAL)
+INTERVAL '1 year 2 days'
+
+> CAST ('90m' AS INTERVAL)
+INTERVAL '1 hour 30 minutes'
+
+! CAST ('1 fortnight' AS INTERVAL)
+'cannot cast "1 fortnight" as interval'
+
+-- test: source(INTERVAL)
+> CAST (INTERVAL '1 month 2 hours' AS TEXT)
+'1 month 2 hours'
+
+! CAST (INTERVAL '1 day' AS INTEGER)
+'cannot cast interval as integer'
+
 -- test: source(BLOB)
 > CAST ('\xAF' AS BLOB)
 '\xAF'
@@ -102,4 +102,43 @@ false
 {a: 1, b: {c: [1, true, ['hello'], {a: [1]}]}}
 
 > typeof({a: 1, b: {c: [1, true, ['hello'], {a: [1]}]}})
-'document'
\ No newline at end of file
+'document'
+-- test: interval
+> INTERVAL '1 day'
+INTERVAL '1 day'
+
+> INTERVAL '1 week 2 days'
+INTERVAL '9 days'
+
+> INTERVAL '1.5 hours'
+INTERVAL '1 hour 30 minutes'
+
+> INTERVAL '-1 hour'
+INTERVAL '-1 hour'
+
+> INTERVAL '1h30m'
+INTERVAL '1 hour 30 minutes'
+
+> INTERVAL '10 seconds 5 milliseconds'
+INTERVAL '10.005 seconds'
+
+> INTERVAL '1 year 14 months 2 days'
+INTERVAL '2 years 2 months 2 days'
+
+> INTERVAL '0 seconds'
+INTERVAL '0 seconds'
+
+> typeof(INTERVAL '1 day')
+'interval'
+
+! INTERVAL 'a day'
+'invalid interval'
+
+! INTERVAL '1.5 months'
+'invalid interval'
+
+! INTERVAL '1 fortnight'
+'invalid interval unit "fortnight"'
+
+! INTERVAL 10
+'found 10, expected string'
@@ -0,0 +1,143 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, a int, b text, c int, d (e double), ...);
+CREATE INDEX test_a_b_idx ON test(a, b);
+CREATE INDEX test_d_e_idx ON test(d.e);
+INSERT INTO test (id, a, b, c, d) VALUES (1, 5, 'x', 1, {e: 1.5}), (2, 15, 'y', 2, {e: 2}), (3, 20, 'a', 3, {e: 3}), (4, 25, NULL, 4, {e: 4});
+
+-- test: projected paths are indexed
+EXPLAIN SELECT a, b FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.CoveringScan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Project(a, b)'
+}
+*/
+
+-- test: projected paths are indexed result
+SELECT a, b FROM test WHERE a > 10;
+/* result:
+{
+    "a": 15,
+    "b": "y"
+}
+{
+    "a": 20,
+    "b": "a"
+}
+{
+    "a": 25,
+    "b": null
+}
+*/
+
+-- test: primary key, filters and order by
+EXPLAIN SELECT id, a FROM test WHERE a > 10 AND b < 'z' ORDER BY b;
+/* result:
+{
+    "plan": 'index.CoveringScan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Filter(b < "z") | docs.Project(id, a) | docs.TempTreeSort(b)'
+}
+*/
+
+-- test: primary key, filters and order by result
+SELECT id, a, pk() FROM test WHERE a > 10 AND b < 'z' ORDER BY b;
+/* result:
+{
+    "id": 3,
+    "a": 20,
+    "pk()": [3]
+}
+{
+    "id": 2,
+    "a": 15,
+    "pk()": [2]
+}
+*/
+
+-- test: alias
+EXPLAIN SELECT t.a, t.b FROM test AS t WHERE t.a > 10 ORDER BY t.a DESC LIMIT 2;
+/* result:
+{
+    "plan": 'index.CoveringScanReverse("test_a_b_idx" AS t, [{"min": [10], "exclusive": true}]) | docs.Project(t.a, t.b) | docs.Take(2)'
+}
+*/
+
+-- test: alias result
+SELECT t.a, t.b FROM test AS t WHERE t.a > 10 ORDER BY t.a DESC LIMIT 2;
+/* result:
+{
+    "t.a": 25,
+    "t.b": null
+}
+{
+    "t.a": 20,
+    "t.b": "a"
+}
+*/
+
+-- test: nested path
+EXPLAIN SELECT d.e, id FROM test WHERE d.e >= 2;
+/* result:
+{
+    "plan": 'index.CoveringScan("test_d_e_idx", [{"min": [2]}]) | docs.Project(d.e, id)'
+}
+*/
+
+-- test: nested path result
+SELECT d.e, id FROM test WHERE d.e >= 3;
+/* result:
+{
+    "d.e": 3.0,
+    "id": 3
+}
+{
+    "d.e": 4.0,
+    "id": 4
+}
+*/
+
+-- test: path not indexed
+EXPLAIN SELECT a, c FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Project(a, c)'
+}
+*/
+
+-- test: path not indexed in filter
+EXPLAIN SELECT a FROM test WHERE a > 10 AND c > 1;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.Filter(c > 1) | docs.Project(a)'
+}
+*/
+
+-- test: nested path not indexed
+EXPLAIN SELECT d FROM test WHERE d.e >= 2;
+/* result:
+{
+    "plan": 'index.Scan("test_d_e_idx", [{"min": [2]}]) | docs.Project(d)'
+}
+*/
+
+-- test: wildcard
+EXPLAIN SELECT * FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b_idx", [{"min": [10], "exclusive": true}])'
+}
+*/
+
+-- test: aggregation
+EXPLAIN SELECT COUNT(*) FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | docs.GroupAggregate(NULL, COUNT(*)) | docs.Project(COUNT(*))'
+}
+*/
+
+-- test: update
+EXPLAIN UPDATE test SET c = 10 WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b_idx", [{"min": [10], "exclusive": true}]) | paths.Set(c, 10) | table.Validate("test") | index.Delete("test_a_b_idx") | index.Delete("test_d_e_idx") | table.Replace("test") | index.Insert("test_a_b_idx") | index.Insert("test_d_e_idx") | discard()'
+}
+*/
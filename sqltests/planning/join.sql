@@ -0,0 +1,91 @@
+-- setup:
+CREATE TABLE a(id int PRIMARY KEY, x int);
+CREATE TABLE b(id int PRIMARY KEY, y int, z int);
+CREATE INDEX b_y_idx ON b(y);
+CREATE UNIQUE INDEX b_z_idx ON b(z);
+CREATE INDEX a_x_idx ON a(x);
+INSERT INTO a (id, x) VALUES (1, 10), (2, 20), (3, NULL);
+INSERT INTO b (id, y, z) VALUES (1, 10, 100), (2, 10, 200), (3, 30, 300);
+
+-- test: index lookup
+EXPLAIN SELECT * FROM a JOIN b ON a.x = b.y;
+/* result:
+{
+    "plan": 'table.Scan("a") | join.IndexLookup("b", "b_y_idx", a.x, a.x = b.y)'
+}
+*/
+
+-- test: primary key lookup
+EXPLAIN SELECT * FROM a JOIN b ON b.id = a.id;
+/* result:
+{
+    "plan": 'table.Scan("a") | join.IndexLookup("b", pk, a.id, b.id = a.id)'
+}
+*/
+
+-- test: unique index first
+EXPLAIN SELECT * FROM a LEFT JOIN b ON a.x = b.y AND b.z = a.x;
+/* result:
+{
+    "plan": 'table.Scan("a") | join.LeftIndexLookup("b", "b_z_idx", a.x, a.x = b.y AND b.z = a.x)'
+}
+*/
+
+-- test: aliases
+EXPLAIN SELECT * FROM a AS t JOIN b AS u ON t.x = u.y WHERE t.id > 1;
+/* result:
+{
+    "plan": 'table.Scan("a" AS t) | join.IndexLookup("b" AS u, "b_y_idx", t.x, t.x = u.y) | docs.Filter(t.id > 1)'
+}
+*/
+
+-- test: non indexed path
+EXPLAIN SELECT * FROM a JOIN b ON a.x = b.id + 1;
+/* result:
+{
+    "plan": 'table.Scan("a") | join.NestedLoop(table.Scan("b"), a.x = b.id + 1)'
+}
+*/
+
+-- test: unqualified path
+EXPLAIN SELECT * FROM a JOIN b ON x = b.y;
+/* result:
+{
+    "plan": 'table.Scan("a") | join.NestedLoop(table.Scan("b"), x = b.y)'
+}
+*/
+
+-- test: index lookup results
+SELECT a.id, b.id FROM a LEFT JOIN b ON a.x = b.y;
+/* result:
+{"a.id": 1, "b.id": 1}
+{"a.id": 1, "b.id": 2}
+{"a.id": 2, "b.id": NULL}
+{"a.id": 3, "b.id": NULL}
+*/
+
+-- test: single table alias
+EXPLAIN SELECT * FROM a AS t WHERE t.x > 10 ORDER BY t.x;
+/* result:
+{
+    "plan": 'index.Scan("a_x_idx" AS t, [{"min": [10], "exclusive": true}])'
+}
+*/
+
+-- test: unnest
+CREATE TABLE c(id int PRIMARY KEY, tags ARRAY);
+EXPLAIN SELECT * FROM c, UNNEST(c.tags) AS tag WHERE c.id = 1 AND tag = 'foo';
+/* result:
+{
+    "plan": 'table.Scan("c") | join.Unnest(c.tags AS tag) | docs.Filter(c.id = 1 AND tag = "foo")'
+}
+*/
+
+-- test: lookup after unnest
+CREATE TABLE d(id int PRIMARY KEY, items ARRAY);
+EXPLAIN SELECT * FROM d, UNNEST(d.items) AS i JOIN b ON b.z = i.z;
+/* result:
+{
+    "plan": 'table.Scan("d") | join.Unnest(d.items AS i) | join.IndexLookup("b", "b_z_idx", i.z, b.z = i.z)'
+}
+*/
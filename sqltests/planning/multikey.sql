@@ -0,0 +1,86 @@
+-- setup:
+CREATE TABLE posts(id int PRIMARY KEY, tags array, n int);
+
+CREATE INDEX posts_tags_idx ON posts(tags[*]);
+
+CREATE INDEX posts_n_idx ON posts(n);
+
+INSERT INTO
+    posts (id, tags, n)
+VALUES
+    (1, ["go", "db"], 1),
+    (2, ["rust"], 2),
+    (3, ["go", "go"], 3),
+    (4, [], 4),
+    (5, NULL, 5),
+    (6, [1, 2.5], 6);
+
+-- test: IN
+EXPLAIN SELECT * FROM posts WHERE "go" IN tags;
+/* result:
+{
+    "plan": 'index.Scan("posts_tags_idx", [{"min": ["go"], "exact": true}])'
+}
+*/
+
+-- test: IN results
+SELECT id FROM posts WHERE "go" IN tags;
+/* result:
+{
+    "id": 1
+}
+{
+    "id": 3
+}
+*/
+
+-- test: IN with numbers
+SELECT id FROM posts WHERE 1 IN tags;
+/* result:
+{
+    "id": 6
+}
+*/
+
+-- test: IN with other filters
+EXPLAIN SELECT * FROM posts WHERE "go" IN tags AND n > 1;
+/* result:
+{
+    "plan": 'index.Scan("posts_tags_idx", [{"min": ["go"], "exact": true}]) | docs.Filter(n > 1)'
+}
+*/
+
+-- test: equality uses regular indexes
+EXPLAIN SELECT * FROM posts WHERE tags = ["go"] AND n = 1;
+/* result:
+{
+    "plan": 'index.Scan("posts_n_idx", [{"min": [1], "exact": true}]) | docs.Filter(tags = ["go"])'
+}
+*/
+
+-- test: no ORDER BY on multikey indexes
+EXPLAIN SELECT * FROM posts ORDER BY tags;
+/* result:
+{
+    "plan": 'table.Scan("posts") | docs.TempTreeSort(tags)'
+}
+*/
+
+-- test: after UPDATE
+UPDATE posts SET tags = ["db"] WHERE id = 1;
+SELECT id FROM posts WHERE "go" IN tags;
+/* result:
+{
+    "id": 3
+}
+*/
+
+-- test: after DELETE
+DELETE FROM posts WHERE id = 3;
+SELECT id FROM posts WHERE "go" IN tags;
+/* result:
+{
+    "id": 1
+}
+*/
+
@@ -0,0 +1,132 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int, c int, d int);
+CREATE INDEX test_b_idx ON test(b);
+CREATE INDEX test_c_idx ON test(c);
+INSERT INTO test (a, b, c, d) VALUES (1, 1, 10, 1), (2, 2, 20, 2), (3, 3, 30, 3), (4, 1, 30, 4), (5, 5, 50, 5);
+
+-- test: two indexes
+EXPLAIN SELECT * FROM test WHERE b = 1 OR c = 30;
+/* result:
+{
+    "plan": 'index.Union("test", index.Scan("test_b_idx", [{"min": [1], "exact": true}]), index.Scan("test_c_idx", [{"min": [30], "exact": true}]))'
+}
+*/
+
+-- test: documents are returned once
+SELECT a FROM test WHERE b = 1 OR c = 30;
+/* result:
+{
+    "a": 1
+}
+{
+    "a": 3
+}
+{
+    "a": 4
+}
+*/
+
+-- test: index and primary key
+EXPLAIN SELECT * FROM test WHERE b = 1 OR a = 5;
+/* result:
+{
+    "plan": 'index.Union("test", index.Scan("test_b_idx", [{"min": [1], "exact": true}]), table.Scan("test", [{"min": [5], "exact": true}]))'
+}
+*/
+
+-- test: partially indexed operand
+EXPLAIN SELECT * FROM test WHERE (b = 1 AND d = 4) OR c = 30;
+/* result:
+{
+    "plan": 'index.Union("test", index.Scan("test_b_idx", [{"min": [1], "exact": true}]), index.Scan("test_c_idx", [{"min": [30], "exact": true}])) | docs.Filter((b = 1 AND d = 4) OR c = 30)'
+}
+*/
+
+-- test: partially indexed operand result
+SELECT a FROM test WHERE (b = 1 AND d = 4) OR c = 30;
+/* result:
+{
+    "a": 3
+}
+{
+    "a": 4
+}
+*/
+
+-- test: with other filters
+EXPLAIN SELECT * FROM test WHERE d = 1 AND (b = 1 OR c = 30);
+/* result:
+{
+    "plan": 'index.Union("test", index.Scan("test_b_idx", [{"min": [1], "exact": true}]), index.Scan("test_c_idx", [{"min": [30], "exact": true}])) | docs.Filter(d = 1)'
+}
+*/
+
+-- test: cheaper index
+EXPLAIN SELECT * FROM test WHERE a = 1 AND (b = 1 OR c = 30);
+/* result:
+{
+    "plan": 'table.Scan("test", [{"min": [1], "exact": true}]) | docs.Filter((b = 1 OR c = 30))'
+}
+*/
+
+-- test: operand without index
+EXPLAIN SELECT * FROM test WHERE b = 1 OR d = 30;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b = 1 OR d = 30)'
+}
+*/
+
+-- test: ranges more expensive than a scan
+EXPLAIN SELECT * FROM test WHERE b > 1 OR c > 30;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b > 1 OR c > 30)'
+}
+*/
+
+-- test: alias
+EXPLAIN SELECT t.a FROM test AS t WHERE t.b = 1 OR t.c = 30;
+/* result:
+{
+    "plan": 'index.Union("test" AS t, index.Scan("test_b_idx", [{"min": [1], "exact": true}]), index.Scan("test_c_idx", [{"min": [30], "exact": true}])) | docs.Project(t.a)'
+}
+*/
+
+-- test: delete
+DELETE FROM test WHERE b = 1 OR c = 30;
+SELECT a FROM test;
+/* result:
+{
+    "a": 2
+}
+{
+    "a": 5
+}
+*/
+
+-- test: statistics
+CREATE TABLE foo(a int PRIMARY KEY, b int, c int, d int);
+CREATE INDEX foo_b_idx ON foo(b);
+CREATE INDEX foo_c_idx ON foo(c);
+INSERT INTO foo (a, b, c, d) VALUES (1, 1, 1, 1), (2, 0, 2, 2), (3, 1, 3, 3), (4, 0, 4, 4), (5, 1, 5, 5), (6, 0, 6, 6), (7, 1, 7, 7), (8, 0, 8, 8), (9, 1, 9, 9), (10, 0, 10, 10), (11, 1, 11, 11), (12, 0, 12, 12), (13, 1, 13, 13), (14, 0, 14, 14), (15, 1, 15, 15), (16, 0, 16, 16), (17, 1, 17, 17), (18, 0, 18, 18), (19, 1, 19, 19), (20, 0, 20, 20);
+ANALYZE foo;
+EXPLAIN SELECT * FROM foo WHERE c = 1 OR c = 5;
+/* result:
+{
+    "plan": 'index.Union("foo", index.Scan("foo_c_idx", [{"min": [1], "exact": true}]), index.Scan("foo_c_idx", [{"min": [5], "exact": true}]))'
+}
+*/
+
+-- test: statistics, more expensive than a scan
+CREATE TABLE foo(a int PRIMARY KEY, b int, c int, d int);
+CREATE INDEX foo_b_idx ON foo(b);
+CREATE INDEX foo_c_idx ON foo(c);
+INSERT INTO foo (a, b, c, d) VALUES (1, 1, 1, 1), (2, 0, 2, 2), (3, 1, 3, 3), (4, 0, 4, 4), (5, 1, 5, 5), (6, 0, 6, 6), (7, 1, 7, 7), (8, 0, 8, 8), (9, 1, 9, 9), (10, 0, 10, 10), (11, 1, 11, 11), (12, 0, 12, 12), (13, 1, 13, 13), (14, 0, 14, 14), (15, 1, 15, 15), (16, 0, 16, 16), (17, 1, 17, 17), (18, 0, 18, 18), (19, 1, 19, 19), (20, 0, 20, 20);
+ANALYZE foo;
+EXPLAIN SELECT * FROM foo WHERE b = 1 OR c = 5;
+/* result:
+{
+    "plan": 'table.Scan("foo") | docs.Filter(b = 1 OR c = 5)'
+}
+*/
@@ -84,3 +84,67 @@ EXPLAIN SELECT * FROM test WHERE b = 10 ORDER BY a DESC;
     "plan": 'index.ScanReverse("test_a_b") | docs.Filter(b = 10)'
 }
 */
+
+-- test: multiple indexed field paths, ASC
+EXPLAIN SELECT * FROM test ORDER BY a, b;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b")'
+}
+*/
+
+-- test: multiple indexed field paths, DESC
+EXPLAIN SELECT * FROM test ORDER BY a DESC, b DESC;
+/* result:
+{
+    "plan": 'index.ScanReverse("test_a_b")'
+}
+*/
+
+-- test: multiple indexed field paths, mixed directions
+EXPLAIN SELECT * FROM test ORDER BY a, b DESC;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(a, b DESC)'
+}
+*/
+
+-- test: multiple indexed field paths, NULLS LAST
+EXPLAIN SELECT * FROM test ORDER BY a, b NULLS LAST;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(a, b NULLS LAST)'
+}
+*/
+
+-- test: multiple field paths not matching the index
+EXPLAIN SELECT * FROM test ORDER BY a, c;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(a, c)'
+}
+*/
+
+-- test: multiple field paths in the wrong order
+EXPLAIN SELECT * FROM test ORDER BY b, a;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(b, a)'
+}
+*/
+
+-- test: filtering and sorting on multiple paths
+EXPLAIN SELECT * FROM test WHERE a > 10 ORDER BY a DESC, b DESC;
+/* result:
+{
+    "plan": 'index.ScanReverse("test_a_b", [{"min": [10], "exclusive": true}])'
+}
+*/
+
+-- test: group by and order by on multiple paths
+EXPLAIN SELECT a, b FROM test GROUP BY a, b ORDER BY a, b;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b") | docs.GroupAggregate([a, b]) | docs.Project(a, b)'
+}
+*/
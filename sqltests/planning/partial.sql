@@ -0,0 +1,136 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, a int, b int, status text);
+CREATE INDEX test_a_idx ON test(a) WHERE status != 'done';
+CREATE INDEX test_b_idx ON test(b) WHERE b > 10 AND status IS NOT NULL;
+INSERT INTO test (id, a, b, status) VALUES (1, 1, 5, 'done'), (2, 2, 15, 'pending'), (3, 3, 25, 'failed'), (4, 4, 35, NULL);
+
+-- test: predicate not implied
+EXPLAIN SELECT * FROM test WHERE a > 1;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a > 1)'
+}
+*/
+
+-- test: same condition
+EXPLAIN SELECT * FROM test WHERE a > 1 AND status != 'done';
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx", [{"min": [1], "exclusive": true}]) | docs.Filter(status != "done")'
+}
+*/
+
+-- test: same condition result
+SELECT id FROM test WHERE a > 1 AND status != 'done';
+/* result:
+{
+    "id": 2
+}
+{
+    "id": 3
+}
+*/
+
+-- test: equality implies the predicate
+EXPLAIN SELECT * FROM test WHERE a > 1 AND status = 'pending';
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx", [{"min": [1], "exclusive": true}]) | docs.Filter(status = "pending")'
+}
+*/
+
+-- test: IN implies the predicate
+EXPLAIN SELECT * FROM test WHERE a > 1 AND status IN ('pending', 'failed');
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx", [{"min": [1], "exclusive": true}]) | docs.Filter(status IN ["pending", "failed"])'
+}
+*/
+
+-- test: IN doesn't imply the predicate
+EXPLAIN SELECT * FROM test WHERE a > 1 AND status IN ('pending', 'done');
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a > 1) | docs.Filter(status IN ["pending", "done"])'
+}
+*/
+
+-- test: parameter
+EXPLAIN SELECT * FROM test WHERE a > 1 AND status = ?;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a > 1) | docs.Filter(status = ?)'
+}
+*/
+
+-- test: alias
+EXPLAIN SELECT * FROM test AS t WHERE t.a > 1 AND t.status != 'done';
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx" AS t, [{"min": [1], "exclusive": true}]) | docs.Filter(t.status != "done")'
+}
+*/
+
+-- test: order by
+EXPLAIN SELECT * FROM test WHERE status = 'failed' ORDER BY a;
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx") | docs.Filter(status = "failed")'
+}
+*/
+
+-- test: range implies the predicate
+EXPLAIN SELECT * FROM test WHERE b >= 20 AND status = 'failed';
+/* result:
+{
+    "plan": 'index.Scan("test_b_idx", [{"min": [20]}]) | docs.Filter(status = "failed")'
+}
+*/
+
+-- test: range implies the predicate result
+SELECT id FROM test WHERE b >= 20 AND status = 'failed';
+/* result:
+{
+    "id": 3
+}
+*/
+
+-- test: range doesn't imply the predicate
+EXPLAIN SELECT * FROM test WHERE b >= 10 AND status = 'failed';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b >= 10) | docs.Filter(status = "failed")'
+}
+*/
+
+-- test: between
+EXPLAIN SELECT * FROM test WHERE b BETWEEN 11 AND 30 AND status != 'done';
+/* result:
+{
+    "plan": 'index.Scan("test_b_idx", [{"min": [11], "max": [30]}]) | docs.Filter(status != "done")'
+}
+*/
+
+-- test: missing condition
+EXPLAIN SELECT * FROM test WHERE b > 20;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b > 20)'
+}
+*/
+
+-- test: OR
+EXPLAIN SELECT * FROM test WHERE (a = 1 AND status = 'pending') OR id = 4;
+/* result:
+{
+    "plan": 'index.Union("test", index.Scan("test_a_idx", [{"min": [1], "exact": true}]), table.Scan("test", [{"min": [4], "exact": true}])) | docs.Filter((a = 1 AND status = "pending") OR id = 4)'
+}
+*/
+
+-- test: OR not implied
+EXPLAIN SELECT * FROM test WHERE a = 1 OR id = 4;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a = 1 OR id = 4)'
+}
+*/
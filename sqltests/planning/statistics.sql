@@ -0,0 +1,77 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int, c int);
+CREATE INDEX test_b_idx ON test(b);
+CREATE INDEX test_c_idx ON test(c);
+INSERT INTO test (a, b, c) VALUES (1, 1, 1), (2, 0, 2), (3, 1, 3), (4, 0, 4), (5, 1, 5), (6, 0, 6), (7, 1, 7), (8, 0, 8), (9, 1, 9), (10, 0, 10), (11, 1, 11), (12, 0, 12), (13, 1, 13), (14, 0, 14), (15, 1, 15), (16, 0, 16), (17, 1, 17), (18, 0, 18), (19, 1, 19), (20, 0, 20);
+
+-- test: without statistics
+EXPLAIN SELECT * FROM test WHERE b = 1 AND c = 5;
+/* result:
+{
+    "plan": 'index.Scan("test_b_idx", [{"min": [1], "exact": true}]) | docs.Filter(c = 5)'
+}
+*/
+
+-- test: most selective index
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b = 1 AND c = 5;
+/* result:
+{
+    "plan": 'index.Scan("test_c_idx", [{"min": [5], "exact": true}]) | docs.Filter(b = 1)'
+}
+*/
+
+-- test: histogram
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a < 18 AND c > 15;
+/* result:
+{
+    "plan": 'index.Scan("test_c_idx", [{"min": [15], "exclusive": true}]) | docs.Filter(a < 18)'
+}
+*/
+
+-- test: primary key
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a < 3 AND c > 5;
+/* result:
+{
+    "plan": 'table.Scan("test", [{"max": [3], "exclusive": true}]) | docs.Filter(c > 5)'
+}
+*/
+
+-- test: index created after ANALYZE
+ANALYZE test;
+CREATE INDEX test_b_c_idx ON test(b, c);
+EXPLAIN SELECT * FROM test WHERE b = 1 AND c = 5;
+/* result:
+{
+    "plan": 'index.Scan("test_b_c_idx", [{"min": [1, 5], "exact": true}])'
+}
+*/
+
+-- test: in list
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b IN (0, 1) AND c IN (5, 6, 6, NULL);
+/* result:
+{
+    "plan": 'index.Scan("test_c_idx", [{"min": [5], "exact": true}, {"min": [6], "exact": true}]) | docs.Filter(b IN [0, 1])'
+}
+*/
+
+-- test: constant expression
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a < 8 AND c > CAST("18" AS INT);
+/* result:
+{
+    "plan": 'index.Scan("test_c_idx", [{"min": [CAST("18" AS integer)], "exclusive": true}]) | docs.Filter(a < 8)'
+}
+*/
+
+-- test: table scan
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b >= 0;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b >= 0)'
+}
+*/
@@ -84,4 +84,22 @@ EXPLAIN SELECT * FROM test WHERE a IN (1, b + 3);
  {
     "plan": 'table.Scan("test") | docs.Filter(a IN [1, b + 3])'
  }
-*/
\ No newline at end of file
+*/
+-- test: IN with constant values
+EXPLAIN SELECT * FROM test WHERE a IN (2, 4, 4, NULL);
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [2], "exact": true}, {"min": [4], "exact": true}])'
+}
+*/
+
+-- test: IN with constant values, result
+SELECT a FROM test WHERE a IN (2, 4, 4, NULL);
+/* result:
+{
+    "a": 2
+}
+{
+    "a": 4
+}
+*/
@@ -0,0 +1,27 @@
+-- setup:
+CREATE TABLE test(a int, b int);
+CREATE INDEX test_a ON test(a);
+
+-- test: sort for each window
+EXPLAIN SELECT row_number() OVER (PARTITION BY b ORDER BY a), rank() OVER (ORDER BY b DESC) FROM test;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(b, a) | docs.Window(ROW_NUMBER() OVER (PARTITION BY b ORDER BY a)) | docs.TempTreeSortReverse(b) | docs.Window(RANK() OVER (ORDER BY b DESC)) | docs.Project(ROW_NUMBER() OVER (PARTITION BY b ORDER BY a), RANK() OVER (ORDER BY b DESC))'
+}
+*/
+
+-- test: same window
+EXPLAIN SELECT row_number() OVER (ORDER BY b), SUM(a) OVER (ORDER BY b) FROM test;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.TempTreeSort(b) | docs.Window(ROW_NUMBER() OVER (ORDER BY b), SUM(a) OVER (ORDER BY b)) | docs.Project(ROW_NUMBER() OVER (ORDER BY b), SUM(a) OVER (ORDER BY b))'
+}
+*/
+
+-- test: indexed window
+EXPLAIN SELECT row_number() OVER (ORDER BY a) FROM test WHERE b > 10 ORDER BY a DESC;
+/* result:
+{
+    "plan": 'index.Scan("test_a") | docs.Filter(b > 10) | docs.Window(ROW_NUMBER() OVER (ORDER BY a)) | docs.Project(ROW_NUMBER() OVER (ORDER BY a)) | docs.TempTreeSortReverse(a)'
+}
+*/
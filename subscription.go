@@ -0,0 +1,99 @@
+package genji
+
+import (
+	"context"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/types"
+)
+
+// ChangeType is the kind of write described by a change:
+// ChangeInsert, ChangeReplace or ChangeDelete.
+type ChangeType = database.ChangeType
+
+// Kinds of changes.
+const (
+	ChangeInsert  = database.ChangeInsert
+	ChangeReplace = database.ChangeReplace
+	ChangeDelete  = database.ChangeDelete
+)
+
+// A Change describes a document written by a committed transaction.
+type Change struct {
+	Type      ChangeType
+	TableName string
+	// Key is an array holding the values of the key of the document,
+	// as returned by the pk() function.
+	Key types.Value
+	// Document is the inserted document, the new version of a replaced document
+	// or the deleted document.
+	Document types.Document
+	// Ops are the set and delete operations transforming the old version
+	// of a replaced document into the new one.
+	Ops []document.Op
+}
+
+// A ChangeSet holds the changes of a committed transaction,
+// in the order they were made.
+type ChangeSet struct {
+	// Sequence is the commit sequence number of the transaction,
+	// as returned by the commit_sequence() function.
+	Sequence uint64
+	Changes  []Change
+}
+
+// Subscription delivers the changes of committed transactions.
+type Subscription struct {
+	sub *database.Subscriber
+}
+
+// Subscribe returns a subscription receiving the documents inserted, replaced and deleted
+// in the given tables, after each successful commit.
+// If no table is given, it receives the changes made to all the tables.
+// Only the transactions started after the subscription are observed.
+// Change sets are queued in memory until they are read, without limit:
+// the subscription must be read continuously and closed once it is no longer used.
+func (db *DB) Subscribe(tables ...string) *Subscription {
+	return &Subscription{
+		sub: db.DB.Subscribe(tables...),
+	}
+}
+
+// Next returns the changes of the next committed transaction, waiting for one to commit
+// if necessary. Change sets are returned in commit order.
+// Once the subscription or the database is closed, it returns an error for which
+// IsSubscriptionClosedError is true.
+func (s *Subscription) Next(ctx context.Context) (*ChangeSet, error) {
+	cs, err := s.sub.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	set := ChangeSet{
+		Sequence: cs.Sequence,
+		Changes:  make([]Change, 0, len(cs.Changes)),
+	}
+
+	for _, c := range cs.Changes {
+		vs, err := c.Key.Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		set.Changes = append(set.Changes, Change{
+			Type:      c.Type,
+			TableName: c.TableName,
+			Key:       types.NewArrayValue(document.NewValueBuffer(vs...)),
+			Document:  c.Document,
+			Ops:       c.Ops,
+		})
+	}
+
+	return &set, nil
+}
+
+// Close the subscription.
+func (s *Subscription) Close() error {
+	return s.sub.Close()
+}
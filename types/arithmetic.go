@@ -3,16 +3,20 @@ package types
 import (
 	"fmt"
 	"math"
+	"time"
 )
 
 // Add u to v and return the result.
 // Only numeric values and booleans can be added together.
+// An interval can also be added to a timestamp or to another interval.
 func Add(v1, v2 Value) (res Value, err error) {
 	return calculateValues(v1, v2, '+')
 }
 
 // Sub calculates v - u and returns the result.
 // Only numeric values and booleans can be calculated together.
+// An interval can also be subtracted from a timestamp or from another interval,
+// and subtracting two timestamps returns the interval between them.
 func Sub(v1, v2 Value) (res Value, err error) {
 	return calculateValues(v1, v2, '-')
 }
@@ -67,6 +71,11 @@ func calculateValues(a, b Value, operator byte) (res Value, err error) {
 		return NewNullValue(), nil
 	}
 
+	if a.Type() == TimestampValue || b.Type() == TimestampValue ||
+		a.Type() == IntervalValue || b.Type() == IntervalValue {
+		return calculateTimestamps(a, b, operator)
+	}
+
 	if a.Type().IsNumber() && b.Type().IsNumber() {
 		if a.Type() == DoubleValue || b.Type() == DoubleValue {
 			return calculateFloats(a, b, operator)
@@ -183,6 +192,59 @@ func calculateFloats(a, b Value, operator byte) (res Value, err error) {
 	}
 }
 
+// calculateTimestamps supports the following operations:
+//
+//	timestamp + interval  -> timestamp
+//	interval + timestamp  -> timestamp
+//	timestamp - interval  -> timestamp
+//	timestamp - timestamp -> interval
+//	interval + interval   -> interval
+//	interval - interval   -> interval
+//
+// Adding or subtracting a number and a timestamp or an interval returns an error,
+// as the unit of the number is unknown.
+// Any other operation returns NULL.
+func calculateTimestamps(a, b Value, operator byte) (res Value, err error) {
+	if operator != '+' && operator != '-' {
+		return NewNullValue(), nil
+	}
+
+	if a.Type().IsNumber() || b.Type().IsNumber() {
+		if operator == '+' {
+			return nil, fmt.Errorf("cannot add %s and %s, use an interval instead", a.Type(), b.Type())
+		}
+		return nil, fmt.Errorf("cannot subtract %s from %s, use an interval instead", b.Type(), a.Type())
+	}
+
+	switch {
+	case a.Type() == TimestampValue && b.Type() == TimestampValue:
+		if operator != '-' {
+			return NewNullValue(), nil
+		}
+
+		d := As[time.Time](a).Sub(As[time.Time](b))
+		return NewIntervalValue(Interval{Duration: d}), nil
+	case a.Type() == TimestampValue && b.Type() == IntervalValue:
+		i := As[Interval](b)
+		if operator == '-' {
+			i = i.Neg()
+		}
+		return NewTimestampValue(i.AddTo(As[time.Time](a))), nil
+	case a.Type() == IntervalValue && b.Type() == TimestampValue:
+		if operator == '+' {
+			return NewTimestampValue(As[Interval](a).AddTo(As[time.Time](b))), nil
+		}
+	case a.Type() == IntervalValue && b.Type() == IntervalValue:
+		ia, ib := As[Interval](a), As[Interval](b)
+		if operator == '-' {
+			ib = ib.Neg()
+		}
+		return NewIntervalValue(Interval{Months: ia.Months + ib.Months, Duration: ia.Duration + ib.Duration}), nil
+	}
+
+	return NewNullValue(), nil
+}
+
 func convertNumberToInteger(v Value) Value {
 	switch v.Type() {
 	case IntegerValue:
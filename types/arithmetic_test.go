@@ -3,6 +3,7 @@ package types_test
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil/assert"
@@ -11,6 +12,8 @@ import (
 )
 
 func TestValueAdd(t *testing.T) {
+	ts := time.Date(2023, time.January, 15, 10, 30, 0, 0, time.UTC)
+
 	tests := []struct {
 		name           string
 		v, u, expected types.Value
@@ -31,6 +34,17 @@ func TestValueAdd(t *testing.T) {
 		{"text('120')+text('120')", types.NewTextValue("120"), types.NewTextValue("120"), types.NewNullValue(), false},
 		{"document+document", types.NewDocumentValue(document.NewFieldBuffer().Add("a", types.NewIntegerValue(10))), types.NewDocumentValue(document.NewFieldBuffer().Add("a", types.NewIntegerValue(10))), types.NewNullValue(), false},
 		{"array+array", types.NewArrayValue(document.NewValueBuffer(types.NewIntegerValue(10))), types.NewArrayValue(document.NewValueBuffer(types.NewIntegerValue(10))), types.NewNullValue(), false},
+		{"timestamp+interval(1h)", types.NewTimestampValue(ts), types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewTimestampValue(ts.Add(time.Hour)), false},
+		{"interval(1h)+timestamp", types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewTimestampValue(ts), types.NewTimestampValue(ts.Add(time.Hour)), false},
+		{"timestamp+interval(1 month)", types.NewTimestampValue(ts), types.NewIntervalValue(types.Interval{Months: 1}), types.NewTimestampValue(ts.AddDate(0, 1, 0)), false},
+		{"timestamp(jan 31)+interval(1 month)", types.NewTimestampValue(time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC)), types.NewIntervalValue(types.Interval{Months: 1}), types.NewTimestampValue(time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC)), false},
+		{"interval+interval", types.NewIntervalValue(types.Interval{Months: 1, Duration: time.Hour}), types.NewIntervalValue(types.Interval{Months: 2, Duration: time.Minute}), types.NewIntervalValue(types.Interval{Months: 3, Duration: time.Hour + time.Minute}), false},
+		{"timestamp+integer(1h)", types.NewTimestampValue(ts), types.NewIntegerValue(int64(time.Hour)), nil, true},
+		{"integer(1h)+timestamp", types.NewIntegerValue(int64(time.Hour)), types.NewTimestampValue(ts), nil, true},
+		{"timestamp+float64(1.5)", types.NewTimestampValue(ts), types.NewDoubleValue(1.5), nil, true},
+		{"interval+integer(1)", types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewIntegerValue(1), nil, true},
+		{"timestamp+timestamp", types.NewTimestampValue(ts), types.NewTimestampValue(ts), types.NewNullValue(), false},
+		{"timestamp+text('1h')", types.NewTimestampValue(ts), types.NewTextValue("1h"), types.NewNullValue(), false},
 	}
 
 	for _, test := range tests {
@@ -47,6 +61,8 @@ func TestValueAdd(t *testing.T) {
 }
 
 func TestValueSub(t *testing.T) {
+	ts := time.Date(2023, time.January, 15, 10, 30, 0, 0, time.UTC)
+
 	tests := []struct {
 		name           string
 		v, u, expected types.Value
@@ -67,6 +83,14 @@ func TestValueSub(t *testing.T) {
 		{"text('120')-text('120')", types.NewTextValue("120"), types.NewTextValue("120"), types.NewNullValue(), false},
 		{"document-document", types.NewDocumentValue(document.NewFieldBuffer().Add("a", types.NewIntegerValue(10))), types.NewDocumentValue(document.NewFieldBuffer().Add("a", types.NewIntegerValue(10))), types.NewNullValue(), false},
 		{"array-array", types.NewArrayValue(document.NewValueBuffer(types.NewIntegerValue(10))), types.NewArrayValue(document.NewValueBuffer(types.NewIntegerValue(10))), types.NewNullValue(), false},
+		{"timestamp-interval(1h)", types.NewTimestampValue(ts), types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewTimestampValue(ts.Add(-time.Hour)), false},
+		{"timestamp-interval(1 year)", types.NewTimestampValue(ts), types.NewIntervalValue(types.Interval{Months: 12}), types.NewTimestampValue(ts.AddDate(-1, 0, 0)), false},
+		{"timestamp(mar 31)-interval(1 month)", types.NewTimestampValue(time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)), types.NewIntervalValue(types.Interval{Months: 1}), types.NewTimestampValue(time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)), false},
+		{"timestamp-timestamp", types.NewTimestampValue(ts.Add(time.Hour)), types.NewTimestampValue(ts), types.NewIntervalValue(types.Interval{Duration: time.Hour}), false},
+		{"interval-interval", types.NewIntervalValue(types.Interval{Months: 1}), types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewIntervalValue(types.Interval{Months: 1, Duration: -time.Hour}), false},
+		{"interval(1h)-timestamp", types.NewIntervalValue(types.Interval{Duration: time.Hour}), types.NewTimestampValue(ts), types.NewNullValue(), false},
+		{"timestamp-integer(1h)", types.NewTimestampValue(ts), types.NewIntegerValue(int64(time.Hour)), nil, true},
+		{"integer(1h)-timestamp", types.NewIntegerValue(int64(time.Hour)), types.NewTimestampValue(ts), nil, true},
 	}
 
 	for _, test := range tests {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"sort"
 	"strings"
+	"time"
 )
 
 type operator uint8
@@ -82,6 +83,29 @@ func compare(op operator, l, r Value) (bool, error) {
 	case l.Type() == TextValue && r.Type() == TextValue:
 		return compareTexts(op, As[string](l), As[string](r)), nil
 
+	// compare timestamps together
+	case l.Type() == TimestampValue && r.Type() == TimestampValue:
+		return compareTimestamps(op, As[time.Time](l), As[time.Time](r)), nil
+
+	// compare timestamps with texts representing a timestamp,
+	// texts that are not timestamps can't be compared
+	case l.Type() == TimestampValue && r.Type() == TextValue:
+		rt, err := ParseTimestamp(As[string](r))
+		if err != nil {
+			return false, err
+		}
+		return compareTimestamps(op, As[time.Time](l), rt), nil
+	case l.Type() == TextValue && r.Type() == TimestampValue:
+		lt, err := ParseTimestamp(As[string](l))
+		if err != nil {
+			return false, err
+		}
+		return compareTimestamps(op, lt, As[time.Time](r)), nil
+
+	// compare intervals together
+	case l.Type() == IntervalValue && r.Type() == IntervalValue:
+		return compareIntervals(op, As[Interval](l), As[Interval](r)), nil
+
 	// compare blobs together
 	case r.Type() == BlobValue && l.Type() == BlobValue:
 		return compareBlobs(op, As[[]byte](l), As[[]byte](r)), nil
@@ -151,6 +175,40 @@ func compareTexts(op operator, l, r string) bool {
 	return false
 }
 
+func compareTimestamps(op operator, l, r time.Time) bool {
+	switch op {
+	case operatorEq:
+		return l.Equal(r)
+	case operatorGt:
+		return l.After(r)
+	case operatorGte:
+		return !l.Before(r)
+	case operatorLt:
+		return l.Before(r)
+	case operatorLte:
+		return !l.After(r)
+	}
+
+	return false
+}
+
+// compareIntervals compares the length of the intervals, counting a month as 30 days.
+// Intervals of the same length, like 1 month and 30 days, are ordered
+// by their number of months, to remain consistent with their encoding.
+func compareIntervals(op operator, l, r Interval) bool {
+	lh, ll := l.Length()
+	rh, rl := r.Length()
+
+	switch {
+	case lh != rh:
+		return compareIntegers(op, lh, rh)
+	case ll != rl:
+		return compareIntegers(op, ll, rl)
+	}
+
+	return compareIntegers(op, l.Months, r.Months)
+}
+
 func compareBlobs(op operator, l, r []byte) bool {
 	switch op {
 	case operatorEq:
@@ -168,7 +226,7 @@ func compareBlobs(op operator, l, r []byte) bool {
 	return false
 }
 
-func compareIntegers(op operator, l, r int64) bool {
+func compareIntegers[T int64 | uint64](op operator, l, r T) bool {
 	switch op {
 	case operatorEq:
 		return l == r
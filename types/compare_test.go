@@ -39,6 +39,20 @@ func toText(t testing.TB, x string) types.Value {
 	return types.NewTextValue(x)
 }
 
+func toTimestamp(t testing.TB, x string) types.Value {
+	ts, err := types.ParseTimestamp(x)
+	assert.NoError(t, err)
+
+	return types.NewTimestampValue(ts)
+}
+
+func toInterval(t testing.TB, x string) types.Value {
+	i, err := types.ParseInterval(x)
+	assert.NoError(t, err)
+
+	return types.NewIntervalValue(i)
+}
+
 func toBlob(t testing.TB, x string) types.Value {
 	return types.NewBlobValue([]byte(x))
 }
@@ -138,6 +152,38 @@ func TestCompare(t *testing.T) {
 		{"<=", "a", "b", true, toText},
 		{"<=", "b", "b", true, toText},
 
+		// timestamp
+		{"=", "2023-01-02", "2023-01-01", false, toTimestamp},
+		{"=", "2023-01-02", "2023-01-02T00:00:00Z", true, toTimestamp},
+		{"=", "2023-01-02T02:00:00+02:00", "2023-01-02", true, toTimestamp},
+		{"!=", "2023-01-02", "2023-01-01", true, toTimestamp},
+		{"!=", "2023-01-02", "2023-01-02", false, toTimestamp},
+		{">", "2023-01-02", "2023-01-01", true, toTimestamp},
+		{">", "2023-01-01", "2023-01-02", false, toTimestamp},
+		{">", "2023-01-02", "2023-01-02", false, toTimestamp},
+		{">=", "2023-01-02", "2023-01-01", true, toTimestamp},
+		{">=", "2023-01-01", "2023-01-02", false, toTimestamp},
+		{">=", "2023-01-02", "2023-01-02", true, toTimestamp},
+		{"<", "2023-01-02", "2023-01-01", false, toTimestamp},
+		{"<", "2023-01-01", "2023-01-02", true, toTimestamp},
+		{"<", "2023-01-02", "2023-01-02", false, toTimestamp},
+		{"<=", "2023-01-02", "2023-01-01", false, toTimestamp},
+		{"<=", "2023-01-01", "2023-01-02", true, toTimestamp},
+		{"<=", "2023-01-02", "2023-01-02", true, toTimestamp},
+
+		// interval
+		{"=", "1 day", "24 hours", true, toInterval},
+		{"=", "1 month", "30 days", false, toInterval},
+		{"!=", "1 hour", "2 hours", true, toInterval},
+		{"<", "1 month", "40 days", true, toInterval},
+		{">", "1 month", "29 days", true, toInterval},
+		{">", "1 month", "30 days", true, toInterval},
+		{"<", "1 month", "300 days", true, toInterval},
+		{">", "1 year", "300 days", true, toInterval},
+		{">", "1 month 1 hour", "1 month", true, toInterval},
+		{"<", "1 hour", "2 hours", true, toInterval},
+		{"<=", "1 year", "12 months", true, toInterval},
+
 		// blob
 		{"=", "b", "a", false, toBlob},
 		{"=", "b", "b", true, toBlob},
@@ -252,3 +298,29 @@ func TestCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareTimestampWithText(t *testing.T) {
+	ts := toTimestamp(t, "2023-01-15T10:30:00Z")
+
+	ok, err := types.IsEqual(ts, types.NewTextValue("2023-01-15 10:30:00"))
+	assert.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = types.IsGreaterThan(ts, types.NewTextValue("2023-01-15"))
+	assert.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = types.IsLesserThan(types.NewTextValue("2023-01-15"), ts)
+	assert.NoError(t, err)
+	require.True(t, ok)
+
+	// texts that are not timestamps can't be compared to a timestamp
+	_, err = types.IsEqual(ts, types.NewTextValue("foo"))
+	assert.Error(t, err)
+
+	_, err = types.IsNotEqual(ts, types.NewTextValue("foo"))
+	assert.Error(t, err)
+
+	_, err = types.IsLesserThan(types.NewTextValue("foo"), ts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Interval is an amount of time made of a number of months,
+// whose length depends on the timestamp it is added to,
+// and of a fixed duration.
+type Interval struct {
+	Months   int64
+	Duration time.Duration
+}
+
+// AddTo returns the timestamp t shifted by the interval.
+// Months are added first, then the duration.
+// If the day of t doesn't exist in the target month, the result is moved
+// to the last day of that month, i.e. January 31 + 1 month is February 28.
+func (i Interval) AddTo(t time.Time) time.Time {
+	if i.Months != 0 {
+		y, m, d := t.Date()
+
+		// time.Date normalizes the month
+		first := time.Date(y, m+time.Month(i.Months), 1, 0, 0, 0, 0, t.Location())
+		if last := first.AddDate(0, 1, -1).Day(); d > last {
+			d = last
+		}
+
+		hour, min, sec := t.Clock()
+		t = time.Date(first.Year(), first.Month(), d, hour, min, sec, t.Nanosecond(), t.Location())
+	}
+
+	return t.Add(i.Duration)
+}
+
+// MonthDuration is the length of a month used to compare intervals,
+// as done by PostgreSQL.
+const MonthDuration = 30 * 24 * time.Hour
+
+// Length returns the length of the interval in nanoseconds, counting a month as MonthDuration.
+// As it can overflow an int64, it is returned as a 128-bit signed integer.
+func (i Interval) Length() (hi int64, lo uint64) {
+	m := uint64(i.Months)
+	if i.Months < 0 {
+		m = -m
+	}
+
+	h, l := bits.Mul64(m, uint64(MonthDuration))
+	if i.Months < 0 {
+		h, l = ^h, ^l+1
+		if l == 0 {
+			h++
+		}
+	}
+
+	var dh uint64
+	if i.Duration < 0 {
+		dh = math.MaxUint64
+	}
+
+	var carry uint64
+	l, carry = bits.Add64(l, uint64(i.Duration), 0)
+	h, _ = bits.Add64(h, dh, carry)
+	return int64(h), l
+}
+
+// Neg returns the opposite of the interval.
+func (i Interval) Neg() Interval {
+	return Interval{Months: -i.Months, Duration: -i.Duration}
+}
+
+// durations of the units of an interval, from the largest to the smallest.
+// A day always lasts 24 hours, as timestamps are stored in UTC.
+var intervalDurations = []struct {
+	name string
+	d    time.Duration
+}{
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+	{"microsecond", time.Microsecond},
+	{"nanosecond", time.Nanosecond},
+}
+
+// String returns a representation of the interval that can be parsed by ParseInterval,
+// using years, months, days, hours, minutes and seconds.
+// Example: 1 year 2 months 3 days 4 hours 5 minutes 6.5 seconds
+func (i Interval) String() string {
+	var parts []string
+
+	addPart := func(n int64, unit string, neg bool) {
+		s := strconv.FormatInt(n, 10)
+		if neg {
+			s = "-" + s
+		}
+		if n != 1 {
+			unit += "s"
+		}
+		parts = append(parts, s+" "+unit)
+	}
+
+	months, neg := i.Months, i.Months < 0
+	if neg {
+		months = -months
+	}
+	if months >= 12 {
+		addPart(months/12, "year", neg)
+	}
+	if months%12 != 0 {
+		addPart(months%12, "month", neg)
+	}
+
+	d, neg := i.Duration, i.Duration < 0
+	if neg {
+		d = -d
+	}
+	for _, u := range intervalDurations[1:4] {
+		if d >= u.d {
+			addPart(int64(d/u.d), u.name, neg)
+			d %= u.d
+		}
+	}
+	if d > 0 {
+		s := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		if neg {
+			s = "-" + s
+		}
+		if d == time.Second {
+			s += " second"
+		} else {
+			s += " seconds"
+		}
+		parts = append(parts, s)
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseInterval parses either a Go duration string (e.g. 1h30m)
+// or a list of quantities followed by a unit (e.g. 1 month 2 days).
+// Units can be singular or plural. Months and years can't be fractional,
+// as their length is not fixed.
+func ParseInterval(s string) (Interval, error) {
+	var i Interval
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return i, fmt.Errorf("invalid interval %q", s)
+	}
+
+	if len(fields) == 1 {
+		d, err := time.ParseDuration(fields[0])
+		if err != nil {
+			return i, fmt.Errorf("invalid interval %q", s)
+		}
+		i.Duration = d
+		return i, nil
+	}
+
+	if len(fields)%2 != 0 {
+		return i, fmt.Errorf("invalid interval %q", s)
+	}
+
+	for j := 0; j < len(fields); j += 2 {
+		unit := strings.TrimSuffix(strings.ToLower(fields[j+1]), "s")
+
+		switch unit {
+		case "month", "year":
+			n, err := strconv.ParseInt(fields[j], 10, 64)
+			if err != nil {
+				return i, fmt.Errorf("invalid interval %q", s)
+			}
+			if unit == "year" {
+				n *= 12
+			}
+			i.Months += n
+			continue
+		}
+
+		n, err := strconv.ParseFloat(fields[j], 64)
+		if err != nil {
+			return i, fmt.Errorf("invalid interval %q", s)
+		}
+
+		d, ok := intervalUnit(unit)
+		if !ok {
+			return i, fmt.Errorf("invalid interval unit %q", fields[j+1])
+		}
+
+		i.Duration += time.Duration(n * float64(d))
+	}
+
+	return i, nil
+}
+
+func intervalUnit(name string) (time.Duration, bool) {
+	for _, u := range intervalDurations {
+		if u.name == name {
+			return u.d, true
+		}
+	}
+
+	return 0, false
+}
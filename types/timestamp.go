@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// list of layouts accepted when parsing a timestamp from a text,
+// tried in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a text representation of a timestamp.
+// It accepts RFC 3339 timestamps, with or without time zone,
+// using either a T or a space to separate the date and the time,
+// as well as dates alone (e.g. 2006-01-02).
+// Timestamps without time zone are considered to be in UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse %q as timestamp", s)
+}
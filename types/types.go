@@ -31,6 +31,10 @@ const (
 
 	DoubleValue ValueType = 0xD0
 
+	TimestampValue ValueType = 0xD5
+
+	IntervalValue ValueType = 0xD8
+
 	TextValue ValueType = 0xDA
 
 	BlobValue ValueType = 0xE0
@@ -50,6 +54,10 @@ func (t ValueType) String() string {
 		return "integer"
 	case DoubleValue:
 		return "double"
+	case TimestampValue:
+		return "timestamp"
+	case IntervalValue:
+		return "interval"
 	case BlobValue:
 		return "blob"
 	case TextValue:
@@ -8,6 +8,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/stringutil"
@@ -53,6 +54,23 @@ func NewDoubleValue(x float64) Value {
 	}
 }
 
+// NewTimestampValue encodes x and returns a value.
+// Timestamps are always stored in UTC.
+func NewTimestampValue(x time.Time) Value {
+	return &value[time.Time]{
+		tp: TimestampValue,
+		v:  x.UTC(),
+	}
+}
+
+// NewIntervalValue encodes x and returns a value.
+func NewIntervalValue(x Interval) Value {
+	return &value[Interval]{
+		tp: IntervalValue,
+		v:  x,
+	}
+}
+
 // NewBlobValue encodes x and returns a value.
 func NewBlobValue(x []byte) Value {
 	return &value[[]byte]{
@@ -148,6 +166,10 @@ func IsZeroValue(v Value) (bool, error) {
 		return As[int64](v) == int64(0), nil
 	case DoubleValue:
 		return As[float64](v) == float64(0), nil
+	case TimestampValue:
+		return As[time.Time](v).IsZero(), nil
+	case IntervalValue:
+		return As[Interval](v) == Interval{}, nil
 	case BlobValue:
 		return As[[]byte](v) == nil, nil
 	case TextValue:
@@ -238,6 +260,16 @@ func marshalText(dst *bytes.Buffer, v Value, prefix, indent string, depth int) e
 		}
 		dst.WriteString(strconv.FormatFloat(As[float64](v), fmt, prec, 64))
 		return nil
+	case TimestampValue:
+		dst.WriteByte('"')
+		dst.WriteString(As[time.Time](v).Format(time.RFC3339Nano))
+		dst.WriteByte('"')
+		return nil
+	case IntervalValue:
+		dst.WriteString("INTERVAL '")
+		dst.WriteString(As[Interval](v).String())
+		dst.WriteByte('\'')
+		return nil
 	case TextValue:
 		dst.WriteString(strconv.Quote(As[string](v)))
 		return nil
@@ -315,10 +347,12 @@ func newline(dst *bytes.Buffer, prefix, indent string, depth int) {
 // MarshalJSON implements the json.Marshaler interface.
 func (v *value[T]) MarshalJSON() ([]byte, error) {
 	switch v.Type() {
-	case BooleanValue, IntegerValue, TextValue:
+	case BooleanValue, IntegerValue, TextValue, TimestampValue:
 		return v.MarshalText()
 	case NullValue:
 		return []byte("null"), nil
+	case IntervalValue:
+		return strconv.AppendQuote(nil, As[Interval](v).String()), nil
 	case DoubleValue:
 		f := As[float64](v)
 		abs := math.Abs(f)
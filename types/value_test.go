@@ -36,7 +36,7 @@ func TestValueMarshalText(t *testing.T) {
 			"{a: 10, \"b c\": \"foo\", `\"d e\"`: \"foo\"}",
 		},
 		{"array", document.NewValueBuffer(types.NewIntegerValue(10), types.NewTextValue("foo")), `[10, "foo"]`},
-		{"time", now, `"` + now.Format(time.RFC3339Nano) + `"`},
+		{"time", now, `"` + now.UTC().Format(time.RFC3339Nano) + `"`},
 	}
 
 	for _, test := range tests {
@@ -89,7 +89,7 @@ func TestMarshalTextIndent(t *testing.T) {
   "foo"
 ]`,
 		},
-		{"time", now, `"` + now.Format(time.RFC3339Nano) + `"`},
+		{"time", now, `"` + now.UTC().Format(time.RFC3339Nano) + `"`},
 	}
 
 	for _, test := range tests {